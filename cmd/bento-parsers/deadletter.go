@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/pkg/deadletter"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// deadLetterStdout is the -dead-letter value of the process mode that
+// writes dead letters instead of the empty event array
+const deadLetterStdout = "stdout"
+
+// deadLetterOptions is the -dead-letter flag of the process and serve modes
+type deadLetterOptions struct {
+	target string
+	sink   deadletter.Sink
+	// inband is set when the letters replace the empty result line
+	inband bool
+}
+
+func (o *deadLetterOptions) register(fs *flag.FlagSet, stdout bool) {
+	usage := "store emails no parser handled in this directory as .eml with a .json letter (empty disables)"
+	if stdout {
+		usage += `; "stdout" writes a {"dead_letter": ...} line instead of [] for Bento to route to a topic`
+	}
+	fs.StringVar(&o.target, "dead-letter", "", usage)
+}
+
+// open creates the sink; stdout is the output of the process mode with the
+// native JSON output, which writes one line per email, else nil
+func (o *deadLetterOptions) open(stdout io.Writer) {
+	switch {
+	case o.target == "":
+	case o.target == deadLetterStdout && stdout != nil:
+		o.sink = deadletter.NewStreamSink(stdout)
+		o.inband = true
+	case o.target == deadLetterStdout:
+		fatal(`-dead-letter stdout needs the process mode with -format json`)
+	default:
+		sink, err := deadletter.NewDirSink(o.target)
+		if err != nil {
+			fatal("Failed to open the dead-letter directory", logging.KeyError, err)
+		}
+		o.sink = sink
+	}
+}
+
+func (o *deadLetterOptions) enabled() bool {
+	return o.sink != nil
+}
+
+// parse runs the registry on an email, tracing the parsers when dead
+// letters are kept
+func (o *deadLetterOptions) parse(ctx context.Context, serializedEmail *email.SerializedEmail, emit base.EmitFunc) (int, *parsers.Explanation, error) {
+	if !o.enabled() {
+		count, err := parsers.ParseEmailStreamContext(ctx, serializedEmail, nil, emit)
+		return count, nil, err
+	}
+	return parsers.ExplainContext(ctx, serializedEmail, nil, nil, emit)
+}
+
+// keep stores an email that was not parsed and reports whether it did; in
+// band letters are only written for emails without events. A failure to
+// store is logged.
+func (o *deadLetterOptions) keep(ctx context.Context, source string, serializedEmail *email.SerializedEmail, count int, err error, explanation *parsers.Explanation, kafka *kafkaSource) bool {
+	if !o.enabled() || deadletter.Outcome(count, err) == "" || (o.inband && count > 0) {
+		return false
+	}
+	letter := deadletter.New(serializedEmail, source, count, err, explanation)
+	if kafka != nil {
+		letter.Kafka = kafka
+	}
+	raw, renderErr := email.Render(serializedEmail)
+	if renderErr == nil {
+		renderErr = o.sink.Put(letter, raw)
+	}
+	if renderErr != nil {
+		slog.ErrorContext(ctx, "Failed to store dead letter", logging.KeyError, renderErr)
+		return false
+	}
+	slog.InfoContext(ctx, "Stored dead letter", logging.KeyOutcome, letter.Outcome)
+	return true
+}
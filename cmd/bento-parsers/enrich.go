@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/pkg/enrich"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// enrichOptions are the -mmdb flags shared by all modes
+type enrichOptions struct {
+	paths    string
+	reload   time.Duration
+	enricher *enrich.Enricher
+}
+
+func (o *enrichOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.paths, "mmdb", "", "comma-separated MaxMind DB files (e.g. GeoLite2-ASN.mmdb,GeoLite2-City.mmdb) to add the ASN and location of event IPs from (empty disables)")
+	fs.DurationVar(&o.reload, "mmdb-reload", time.Minute, "interval to check the -mmdb files for changes (0 disables)")
+}
+
+// open reads the databases and watches them for changes
+func (o *enrichOptions) open(ctx context.Context) {
+	if o.paths == "" {
+		return
+	}
+	var paths []string
+	for _, path := range strings.Split(o.paths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	enricher, err := enrich.New(paths...)
+	if err != nil {
+		fatal("Failed to open MMDB database", logging.KeyError, err)
+	}
+	o.enricher = enricher
+	if o.reload > 0 {
+		go enricher.Watch(ctx, o.reload)
+	}
+}
+
+// wrap returns emit enriching the events, or emit itself without databases
+func (o *enrichOptions) wrap(emit base.EmitFunc) base.EmitFunc {
+	if o.enricher == nil {
+		return emit
+	}
+	return o.enricher.Wrap(emit)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/abusix/inbound-parsers/parsers"
+)
+
+// limitOptions are the registry limit flags shared by all modes
+type limitOptions struct {
+	limits parsers.Limits
+}
+
+func (o *limitOptions) register(fs *flag.FlagSet) {
+	o.limits = parsers.DefaultLimits()
+	fs.DurationVar(&o.limits.ParserTimeout, "parser-timeout", o.limits.ParserTimeout, "time one parser may take on an email (0 disables)")
+	fs.IntVar(&o.limits.MaxEmailBytes, "max-email-bytes", o.limits.MaxEmailBytes, "size of headers and bodies above which an email is refused (0 disables)")
+	fs.Int64Var(&o.limits.MaxDecodedBytes, "max-decoded-bytes", o.limits.MaxDecodedBytes, "decompressed size limit of one zip or gzip attachment (0 disables)")
+	fs.IntVar(&o.limits.QuarantineAfter, "quarantine-after", o.limits.QuarantineAfter, "panics and timeouts in a row after which a parser is skipped (0 disables)")
+	fs.DurationVar(&o.limits.QuarantineFor, "quarantine-for", o.limits.QuarantineFor, "time a parser stays quarantined")
+}
+
+// apply sets the limits of the parser registry
+func (o *limitOptions) apply() {
+	parsers.SetLimits(o.limits)
+}
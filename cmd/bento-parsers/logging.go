@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// logOptions are the logging flags shared by all modes
+type logOptions struct {
+	format       string
+	level        string
+	parserLevels string
+}
+
+func (o *logOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "log-format", "json", "log format: json or text")
+	fs.StringVar(&o.level, "log-level", "info", "minimum log level: debug, info, warn or error")
+	fs.StringVar(&o.parserLevels, "log-parser-levels", "", "log levels of single parsers, e.g. spamcop=debug,abusix=error")
+}
+
+// apply makes the configured logger the default, writing to stderr
+func (o *logOptions) apply() {
+	cfg := logging.Config{Format: o.format}
+	if err := cfg.Level.UnmarshalText([]byte(o.level)); err != nil {
+		fatal("Invalid -log-level", logging.KeyError, err)
+	}
+	parserLevels, err := logging.ParseParserLevels(o.parserLevels)
+	if err != nil {
+		fatal("Invalid -log-parser-levels", logging.KeyError, err)
+	}
+	cfg.ParserLevels = parserLevels
+	if err := logging.Setup(os.Stderr, cfg); err != nil {
+		fatal("Invalid -log-format", logging.KeyError, err)
+	}
+}
+
+// fatal logs an error and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
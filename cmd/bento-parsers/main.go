@@ -3,2330 +3,13 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
 	"os"
-	"github.com/abusix/inbound-parsers/parsers/abuse_ch"
-	"github.com/abusix/inbound-parsers/parsers/abuse_oneprovider"
-	"github.com/abusix/inbound-parsers/parsers/abusehub_nl"
-	"github.com/abusix/inbound-parsers/parsers/abusix"
-	"github.com/abusix/inbound-parsers/parsers/acastano"
-	"github.com/abusix/inbound-parsers/parsers/accenture"
-	"github.com/abusix/inbound-parsers/parsers/acedatacenter"
-	"github.com/abusix/inbound-parsers/parsers/acns"
-	"github.com/abusix/inbound-parsers/parsers/adciberespaco"
-	"github.com/abusix/inbound-parsers/parsers/agouros"
-	"github.com/abusix/inbound-parsers/parsers/aiplex"
-	"github.com/abusix/inbound-parsers/parsers/akamai"
-	"github.com/abusix/inbound-parsers/parsers/abusetrue_nl"
-	"github.com/abusix/inbound-parsers/parsers/aruba"
-	"github.com/abusix/inbound-parsers/parsers/autofusion"
-	"github.com/abusix/inbound-parsers/parsers/axur"
-	"github.com/abusix/inbound-parsers/parsers/adobe"
-	"github.com/abusix/inbound-parsers/parsers/amasha"
-	"github.com/abusix/inbound-parsers/parsers/amazon"
-	"github.com/abusix/inbound-parsers/parsers/anvisa_gov"
-	"github.com/abusix/inbound-parsers/parsers/antipiracy"
-	"github.com/abusix/inbound-parsers/parsers/ap_markmonitor"
-	"github.com/abusix/inbound-parsers/parsers/aparlay"
-	"github.com/abusix/inbound-parsers/parsers/apple"
-	"github.com/abusix/inbound-parsers/parsers/antipiracy_report"
-	"github.com/abusix/inbound-parsers/parsers/antipiracyprotection"
-	"github.com/abusix/inbound-parsers/parsers/aol"
-	"github.com/abusix/inbound-parsers/parsers/apiccopyright"
-	"github.com/abusix/inbound-parsers/parsers/arkadruk"
-	"github.com/abusix/inbound-parsers/parsers/artplanet"
-	"github.com/abusix/inbound-parsers/parsers/att"
-	"github.com/abusix/inbound-parsers/parsers/attributor"
-	"github.com/abusix/inbound-parsers/parsers/avoxi"
-	"github.com/abusix/inbound-parsers/parsers/axghouse"
-	"github.com/abusix/inbound-parsers/parsers/aws"
-	"github.com/abusix/inbound-parsers/parsers/azure"
-	"github.com/abusix/inbound-parsers/parsers/b_monitor"
-	"github.com/abusix/inbound-parsers/parsers/barrettlawgroup"
-	"github.com/abusix/inbound-parsers/parsers/base"
-	"github.com/abusix/inbound-parsers/parsers/baysidecorp"
-	"github.com/abusix/inbound-parsers/parsers/bb"
-	"github.com/abusix/inbound-parsers/parsers/bbc"
-	"github.com/abusix/inbound-parsers/parsers/bell"
-	"github.com/abusix/inbound-parsers/parsers/bellsouth"
-	"github.com/abusix/inbound-parsers/parsers/beygoo"
-	"github.com/abusix/inbound-parsers/parsers/bibo"
-	"github.com/abusix/inbound-parsers/parsers/bitninja"
-	"github.com/abusix/inbound-parsers/parsers/bka"
-	"github.com/abusix/inbound-parsers/parsers/black_dura"
-	"github.com/abusix/inbound-parsers/parsers/bluehost"
-	"github.com/abusix/inbound-parsers/parsers/bluevoyant"
-	"github.com/abusix/inbound-parsers/parsers/bnshosting"
-	"github.com/abusix/inbound-parsers/parsers/bofa"
-	"github.com/abusix/inbound-parsers/parsers/botnet_tracker"
-	"github.com/abusix/inbound-parsers/parsers/bpi"
-	"github.com/abusix/inbound-parsers/parsers/bp_corsearch"
-	"github.com/abusix/inbound-parsers/parsers/bradesco"
-	"github.com/abusix/inbound-parsers/parsers/brandmonitor"
-	"github.com/abusix/inbound-parsers/parsers/brandprotection"
-	"github.com/abusix/inbound-parsers/parsers/brandsecurity_ru"
-	"github.com/abusix/inbound-parsers/parsers/brandshield"
-	"github.com/abusix/inbound-parsers/parsers/bsi"
-	"github.com/abusix/inbound-parsers/parsers/bt"
-	"github.com/abusix/inbound-parsers/parsers/buerki"
-	"github.com/abusix/inbound-parsers/parsers/buycheaprdp"
-	"github.com/abusix/inbound-parsers/parsers/bwbmodels"
-	"github.com/abusix/inbound-parsers/parsers/bytescare"
-	"github.com/abusix/inbound-parsers/parsers/cammodelprotect"
-	"github.com/abusix/inbound-parsers/parsers/cavac"
-	"github.com/abusix/inbound-parsers/parsers/cbs"
-	"github.com/abusix/inbound-parsers/parsers/ccirc"
-	"github.com/abusix/inbound-parsers/parsers/crowdstrike"
-	"github.com/abusix/inbound-parsers/parsers/cyberint"
-	"github.com/abusix/inbound-parsers/parsers/cyble"
-	"github.com/abusix/inbound-parsers/parsers/cert_bz"
-	"github.com/abusix/inbound-parsers/parsers/cert_ee"
-	"github.com/abusix/inbound-parsers/parsers/cert_fi"
-	"github.com/abusix/inbound-parsers/parsers/cert_es"
-	"github.com/abusix/inbound-parsers/parsers/cert_gib"
-	"github.com/abusix/inbound-parsers/parsers/cert_gov"
-	"github.com/abusix/inbound-parsers/parsers/cert_hr"
-	"github.com/abusix/inbound-parsers/parsers/cert_in"
-	"github.com/abusix/inbound-parsers/parsers/cert_lt"
-	"github.com/abusix/inbound-parsers/parsers/cert_no"
-	"github.com/abusix/inbound-parsers/parsers/cert_nz"
-	"github.com/abusix/inbound-parsers/parsers/cert_pl"
-	"github.com/abusix/inbound-parsers/parsers/cert_pt"
-	"github.com/abusix/inbound-parsers/parsers/cert_rcts"
-	"github.com/abusix/inbound-parsers/parsers/cert_ro"
-	"github.com/abusix/inbound-parsers/parsers/cert_ua"
-	"github.com/abusix/inbound-parsers/parsers/certat"
-	"github.com/abusix/inbound-parsers/parsers/certbr"
-	"github.com/abusix/inbound-parsers/parsers/centurylink"
-	"github.com/abusix/inbound-parsers/parsers/chaturbate"
-	"github.com/abusix/inbound-parsers/parsers/checkphish"
-	"github.com/abusix/inbound-parsers/parsers/choopa"
-	"github.com/abusix/inbound-parsers/parsers/cloudflare"
-	"github.com/abusix/inbound-parsers/parsers/cloudflare_report"
-	"github.com/abusix/inbound-parsers/parsers/cloudns"
-	"github.com/abusix/inbound-parsers/parsers/cogent"
-	"github.com/abusix/inbound-parsers/parsers/columbiaedu"
-	"github.com/abusix/inbound-parsers/parsers/cpanel"
-	"github.com/abusix/inbound-parsers/parsers/csirt_cz"
-	"github.com/abusix/inbound-parsers/parsers/csirt_divd"
-	"github.com/abusix/inbound-parsers/parsers/colocationamerica"
-	"github.com/abusix/inbound-parsers/parsers/comcast"
-	"github.com/abusix/inbound-parsers/parsers/copyright_compliance"
-	"github.com/abusix/inbound-parsers/parsers/copyright_integrity"
-	"github.com/abusix/inbound-parsers/parsers/datapacket"
-	"github.com/abusix/inbound-parsers/parsers/digital_ocean"
-	"github.com/abusix/inbound-parsers/parsers/disney"
-	"github.com/abusix/inbound-parsers/parsers/dmca_com"
-	"github.com/abusix/inbound-parsers/parsers/dmcaforce"
-	"github.com/abusix/inbound-parsers/parsers/dnsimple"
-	"github.com/abusix/inbound-parsers/parsers/dmcapiracyprevention"
-	"github.com/abusix/inbound-parsers/parsers/dreamhost"
-	"github.com/abusix/inbound-parsers/parsers/ebay"
-	"github.com/abusix/inbound-parsers/parsers/ebrand"
-	"github.com/abusix/inbound-parsers/parsers/ecatel"
-	"github.com/abusix/inbound-parsers/parsers/enom"
-	"github.com/abusix/inbound-parsers/parsers/etsy"
-	"github.com/abusix/inbound-parsers/parsers/eyeonpiracy"
-	"github.com/abusix/inbound-parsers/parsers/fraudwatch"
-	"github.com/abusix/inbound-parsers/parsers/facebook"
-	"github.com/abusix/inbound-parsers/parsers/fastly"
-	"github.com/abusix/inbound-parsers/parsers/fbl"
-	"github.com/abusix/inbound-parsers/parsers/fox"
-	"github.com/abusix/inbound-parsers/parsers/feodotracker"
-	"github.com/abusix/inbound-parsers/parsers/gcp"
-	"github.com/abusix/inbound-parsers/parsers/godaddy"
-	"github.com/abusix/inbound-parsers/parsers/google"
-	"github.com/abusix/inbound-parsers/parsers/googlesafebrowsing"
-	"github.com/abusix/inbound-parsers/parsers/govcert_ch"
-	"github.com/abusix/inbound-parsers/parsers/group_ib"
-	"github.com/abusix/inbound-parsers/parsers/hbo"
-	"github.com/abusix/inbound-parsers/parsers/hetzner"
-	"github.com/abusix/inbound-parsers/parsers/hostdime"
-	"github.com/abusix/inbound-parsers/parsers/huawei"
-	"github.com/abusix/inbound-parsers/parsers/ibm"
-	"github.com/abusix/inbound-parsers/parsers/ifpi"
-	"github.com/abusix/inbound-parsers/parsers/incopro"
-	"github.com/abusix/inbound-parsers/parsers/instagram"
-	"github.com/abusix/inbound-parsers/parsers/interhost"
-	"github.com/abusix/inbound-parsers/parsers/internap"
-	"github.com/abusix/inbound-parsers/parsers/ionos"
-	"github.com/abusix/inbound-parsers/parsers/itv"
-	"github.com/abusix/inbound-parsers/parsers/jpcert"
-	"github.com/abusix/inbound-parsers/parsers/kabel_deutschland"
-	"github.com/abusix/inbound-parsers/parsers/laliga"
-	"github.com/abusix/inbound-parsers/parsers/korea_telecom"
-	"github.com/abusix/inbound-parsers/parsers/leaseweb"
-	"github.com/abusix/inbound-parsers/parsers/lg_uplus"
-	"github.com/abusix/inbound-parsers/parsers/limestone"
-	"github.com/abusix/inbound-parsers/parsers/linkedin"
-	"github.com/abusix/inbound-parsers/parsers/linode"
-	"github.com/abusix/inbound-parsers/parsers/markscan"
-	"github.com/abusix/inbound-parsers/parsers/microsoft"
-	"github.com/abusix/inbound-parsers/parsers/microsoft_dmca"
-	"github.com/abusix/inbound-parsers/parsers/mpa"
-	"github.com/abusix/inbound-parsers/parsers/mpaa"
-	"github.com/abusix/inbound-parsers/parsers/mih_brandprotection"
-	"github.com/abusix/inbound-parsers/parsers/namecheap"
-	"github.com/abusix/inbound-parsers/parsers/nbcuni"
-	"github.com/abusix/inbound-parsers/parsers/ncsc"
-	"github.com/abusix/inbound-parsers/parsers/ncsc_fi"
-	"github.com/abusix/inbound-parsers/parsers/ncsc_nl"
-	"github.com/abusix/inbound-parsers/parsers/netcraft"
-	"github.com/abusix/inbound-parsers/parsers/netflix"
-	"github.com/abusix/inbound-parsers/parsers/nfoservers"
-	"github.com/abusix/inbound-parsers/parsers/ntt"
-	"github.com/abusix/inbound-parsers/parsers/nocix"
-	"github.com/abusix/inbound-parsers/parsers/oneandone"
-	"github.com/abusix/inbound-parsers/parsers/opsecsecurityonline"
-	"github.com/abusix/inbound-parsers/parsers/orange"
-	"github.com/abusix/inbound-parsers/parsers/orange_fr"
-	"github.com/abusix/inbound-parsers/parsers/ovh"
-	"github.com/abusix/inbound-parsers/parsers/packet"
-	"github.com/abusix/inbound-parsers/parsers/paypal"
-	"github.com/abusix/inbound-parsers/parsers/phishfort"
-	"github.com/abusix/inbound-parsers/parsers/phishlabscom"
-	"github.com/abusix/inbound-parsers/parsers/psychz"
-	"github.com/abusix/inbound-parsers/parsers/quadranet"
-	"github.com/abusix/inbound-parsers/parsers/recordedfuture"
-	"github.com/abusix/inbound-parsers/parsers/redpoints"
-	"github.com/abusix/inbound-parsers/parsers/riaa"
-	"github.com/abusix/inbound-parsers/parsers/rackspace"
-	"github.com/abusix/inbound-parsers/parsers/riskiq"
-	"github.com/abusix/inbound-parsers/parsers/rogers"
-	"github.com/abusix/inbound-parsers/parsers/route53"
-	"github.com/abusix/inbound-parsers/parsers/sakura"
-	"github.com/abusix/inbound-parsers/parsers/scaleway"
-	"github.com/abusix/inbound-parsers/parsers/serverstack"
-	"github.com/abusix/inbound-parsers/parsers/shadowserver"
-	"github.com/abusix/inbound-parsers/parsers/sharktech"
-	"github.com/abusix/inbound-parsers/parsers/sk_broadband"
-	"github.com/abusix/inbound-parsers/parsers/sky"
-	"github.com/abusix/inbound-parsers/parsers/shopify"
-	"github.com/abusix/inbound-parsers/parsers/softlayer"
-	"github.com/abusix/inbound-parsers/parsers/sony"
-	"github.com/abusix/inbound-parsers/parsers/spamcop"
-	"github.com/abusix/inbound-parsers/parsers/spamhaus"
-	"github.com/abusix/inbound-parsers/parsers/spectrum"
-	"github.com/abusix/inbound-parsers/parsers/squarespace"
-	"github.com/abusix/inbound-parsers/parsers/strato"
-	"github.com/abusix/inbound-parsers/parsers/swisscom"
-	"github.com/abusix/inbound-parsers/parsers/telus"
-	"github.com/abusix/inbound-parsers/parsers/tencent"
-	"github.com/abusix/inbound-parsers/parsers/tescobrandprotection"
-	"github.com/abusix/inbound-parsers/parsers/twitter"
-	"github.com/abusix/inbound-parsers/parsers/tucows"
-	"github.com/abusix/inbound-parsers/parsers/viacom"
-	"github.com/abusix/inbound-parsers/parsers/twc"
-	"github.com/abusix/inbound-parsers/parsers/uceprotect"
-	"github.com/abusix/inbound-parsers/parsers/unity_media"
-	"github.com/abusix/inbound-parsers/parsers/urlhaus"
-	"github.com/abusix/inbound-parsers/parsers/valentinobrandprotection"
-	"github.com/abusix/inbound-parsers/parsers/versatel"
-	"github.com/abusix/inbound-parsers/parsers/verizon"
-	"github.com/abusix/inbound-parsers/parsers/websheriff"
-	"github.com/abusix/inbound-parsers/parsers/vpsville"
-	"github.com/abusix/inbound-parsers/parsers/vultr"
-	"github.com/abusix/inbound-parsers/parsers/wordpress"
-	"github.com/abusix/inbound-parsers/parsers/wix"
-	"github.com/abusix/inbound-parsers/parsers/yahoo"
-	"github.com/abusix/inbound-parsers/parsers/ybrandprotection"
-	"github.com/abusix/inbound-parsers/parsers/zerofox"
-	"github.com/abusix/inbound-parsers/parsers/baidu"
-	"github.com/abusix/inbound-parsers/parsers/zenlayer"
-	"github.com/abusix/inbound-parsers/parsers/cdar_westpac"
-	"github.com/abusix/inbound-parsers/parsers/centurylinkservices"
-	"github.com/abusix/inbound-parsers/parsers/circllu"
-	"github.com/abusix/inbound-parsers/parsers/ciu_online"
-	"github.com/abusix/inbound-parsers/parsers/cnsd_gob_pe"
-	"github.com/abusix/inbound-parsers/parsers/comeso"
-	"github.com/abusix/inbound-parsers/parsers/communicationvalley"
-	"github.com/abusix/inbound-parsers/parsers/comvive"
-	"github.com/abusix/inbound-parsers/parsers/counterfeittechnology"
-	"github.com/abusix/inbound-parsers/parsers/courbis"
-	"github.com/abusix/inbound-parsers/parsers/courts_in"
-	"github.com/abusix/inbound-parsers/parsers/cpragency"
-	"github.com/abusix/inbound-parsers/parsers/crdflabs"
-	"github.com/abusix/inbound-parsers/parsers/crm_wix"
-	"github.com/abusix/inbound-parsers/parsers/csa"
-	"github.com/abusix/inbound-parsers/parsers/cscglobal"
-	"github.com/abusix/inbound-parsers/parsers/csirt_br"
-	"github.com/abusix/inbound-parsers/parsers/csirt_dnofd"
-	"github.com/abusix/inbound-parsers/parsers/csirt_muni"
-	"github.com/abusix/inbound-parsers/parsers/csis"
-	"github.com/abusix/inbound-parsers/parsers/customvisuals"
-	"github.com/abusix/inbound-parsers/parsers/cyber_gc"
-	"github.com/abusix/inbound-parsers/parsers/cyber999"
-	"github.com/abusix/inbound-parsers/parsers/cybertip"
-	"github.com/abusix/inbound-parsers/parsers/cyberweb"
-	"github.com/abusix/inbound-parsers/parsers/d3lab"
-	"github.com/abusix/inbound-parsers/parsers/darklist"
-	"github.com/abusix/inbound-parsers/parsers/dcpmail"
-	"github.com/abusix/inbound-parsers/parsers/dd_tech"
-	"github.com/abusix/inbound-parsers/parsers/ddos_google"
-	"github.com/abusix/inbound-parsers/parsers/debian"
-	"github.com/abusix/inbound-parsers/parsers/defaria"
-	"github.com/abusix/inbound-parsers/parsers/deft"
-	"github.com/abusix/inbound-parsers/parsers/deloite"
-	"github.com/abusix/inbound-parsers/parsers/desmoweb"
-	"github.com/abusix/inbound-parsers/parsers/dgn"
-	"github.com/abusix/inbound-parsers/parsers/dgt"
-	"github.com/abusix/inbound-parsers/parsers/digiguardians"
-	"github.com/abusix/inbound-parsers/parsers/digiturk"
-	"github.com/abusix/inbound-parsers/parsers/djr_co"
-	"github.com/abusix/inbound-parsers/parsers/dmarc_xml"
-	"github.com/abusix/inbound-parsers/parsers/dmca_pro"
-	"github.com/abusix/inbound-parsers/parsers/dnainternet"
-	"github.com/abusix/inbound-parsers/parsers/dnsc"
-	"github.com/abusix/inbound-parsers/parsers/docusign"
-	"github.com/abusix/inbound-parsers/parsers/domainabusereporting"
-	"github.com/abusix/inbound-parsers/parsers/domainoo"
-	"github.com/abusix/inbound-parsers/parsers/doppel"
-	"github.com/abusix/inbound-parsers/parsers/dreamworldpartners"
-	"github.com/abusix/inbound-parsers/parsers/dreyfus"
-	"github.com/abusix/inbound-parsers/parsers/easysol"
-	"github.com/abusix/inbound-parsers/parsers/ebs"
-	"github.com/abusix/inbound-parsers/parsers/eca"
-	"github.com/abusix/inbound-parsers/parsers/ecucert"
-	"github.com/abusix/inbound-parsers/parsers/eisys"
-	"github.com/abusix/inbound-parsers/parsers/ellematthewsmodel"
-	"github.com/abusix/inbound-parsers/parsers/enf_meta"
-	"github.com/abusix/inbound-parsers/parsers/enfappdetex"
-	"github.com/abusix/inbound-parsers/parsers/entura"
-	"github.com/abusix/inbound-parsers/parsers/ephemeron"
-	"github.com/abusix/inbound-parsers/parsers/eq_ee"
-	"github.com/abusix/inbound-parsers/parsers/esp"
-	"github.com/abusix/inbound-parsers/parsers/espresso"
-	"github.com/abusix/inbound-parsers/parsers/etoolkit"
-	"github.com/abusix/inbound-parsers/parsers/etotalhost"
-	"github.com/abusix/inbound-parsers/parsers/europa_eu"
-	"github.com/abusix/inbound-parsers/parsers/exemail"
-	"github.com/abusix/inbound-parsers/parsers/experian"
-	"github.com/abusix/inbound-parsers/parsers/expressvpn"
-	"github.com/abusix/inbound-parsers/parsers/facct"
-	"github.com/abusix/inbound-parsers/parsers/fail2ban"
-	"github.com/abusix/inbound-parsers/parsers/fbi_ipv6home"
-	"github.com/abusix/inbound-parsers/parsers/fbs"
-	"github.com/abusix/inbound-parsers/parsers/feedback_loop"
-	"github.com/abusix/inbound-parsers/parsers/fhs"
-	"github.com/abusix/inbound-parsers/parsers/flyhosting"
-	"github.com/abusix/inbound-parsers/parsers/fmtsoperation"
-	"github.com/abusix/inbound-parsers/parsers/fondia"
-	"github.com/abusix/inbound-parsers/parsers/fraudwatchinternational"
-	"github.com/abusix/inbound-parsers/parsers/freedomtech"
-	"github.com/abusix/inbound-parsers/parsers/friendmts"
-	"github.com/abusix/inbound-parsers/parsers/fsec"
-	"github.com/abusix/inbound-parsers/parsers/fsm"
-	"github.com/abusix/inbound-parsers/parsers/gastecnologia"
-	"github.com/abusix/inbound-parsers/parsers/generic_spam_trap"
-	"github.com/abusix/inbound-parsers/parsers/ginernet"
-	"github.com/abusix/inbound-parsers/parsers/giorgioarmaniweb"
-	"github.com/abusix/inbound-parsers/parsers/gmail_parser"
-	"github.com/abusix/inbound-parsers/parsers/gmx"
-	"github.com/abusix/inbound-parsers/parsers/gmx_com"
-	"github.com/abusix/inbound-parsers/parsers/gold_parser"
-	"github.com/abusix/inbound-parsers/parsers/griffeshield"
-	"github.com/abusix/inbound-parsers/parsers/hack_hunt"
-	"github.com/abusix/inbound-parsers/parsers/heficed"
-	"github.com/abusix/inbound-parsers/parsers/herrbischoff"
-	"github.com/abusix/inbound-parsers/parsers/hfmarket"
-	"github.com/abusix/inbound-parsers/parsers/hispasec"
-	"github.com/abusix/inbound-parsers/parsers/hkcert"
-	"github.com/abusix/inbound-parsers/parsers/home"
-	"github.com/abusix/inbound-parsers/parsers/honeypots_tk"
-	"github.com/abusix/inbound-parsers/parsers/hosteurope"
-	"github.com/abusix/inbound-parsers/parsers/hostfission"
-	"github.com/abusix/inbound-parsers/parsers/hostopia"
-	"github.com/abusix/inbound-parsers/parsers/hostroyale"
-	"github.com/abusix/inbound-parsers/parsers/hotmail"
-	"github.com/abusix/inbound-parsers/parsers/humongoushibiscus"
-	"github.com/abusix/inbound-parsers/parsers/hyperfilter"
-	"github.com/abusix/inbound-parsers/parsers/ibcom"
-	"github.com/abusix/inbound-parsers/parsers/icscards"
-	"github.com/abusix/inbound-parsers/parsers/iheatwithoil"
-	"github.com/abusix/inbound-parsers/parsers/ilvasapolli"
-	"github.com/abusix/inbound-parsers/parsers/inaxas"
-	"github.com/abusix/inbound-parsers/parsers/infringements_cc"
-	"github.com/abusix/inbound-parsers/parsers/innotec"
-	"github.com/abusix/inbound-parsers/parsers/interconnect"
-	"github.com/abusix/inbound-parsers/parsers/interieur_gouv_fr"
-	"github.com/abusix/inbound-parsers/parsers/internet2"
-	"github.com/abusix/inbound-parsers/parsers/intsights"
-	"github.com/abusix/inbound-parsers/parsers/ipvanish"
-	"github.com/abusix/inbound-parsers/parsers/ipxo"
-	"github.com/abusix/inbound-parsers/parsers/irdeto"
-	"github.com/abusix/inbound-parsers/parsers/irisio"
-	"github.com/abusix/inbound-parsers/parsers/irs"
-	"github.com/abusix/inbound-parsers/parsers/isag"
-	"github.com/abusix/inbound-parsers/parsers/ish"
-	"github.com/abusix/inbound-parsers/parsers/iwf"
-	"github.com/abusix/inbound-parsers/parsers/izoologic"
-	"github.com/abusix/inbound-parsers/parsers/jcloud"
-	"github.com/abusix/inbound-parsers/parsers/jeffv"
-	"github.com/abusix/inbound-parsers/parsers/joturl"
-	"github.com/abusix/inbound-parsers/parsers/jugendschutz"
-	"github.com/abusix/inbound-parsers/parsers/juno"
-	"github.com/abusix/inbound-parsers/parsers/jutho"
-	"github.com/abusix/inbound-parsers/parsers/kilpatricktown"
-	"github.com/abusix/inbound-parsers/parsers/kinghost"
-	"github.com/abusix/inbound-parsers/parsers/kinopoisk"
-	"github.com/abusix/inbound-parsers/parsers/klingler_net"
-	"github.com/abusix/inbound-parsers/parsers/kpnmail"
-	"github.com/abusix/inbound-parsers/parsers/latam"
-	"github.com/abusix/inbound-parsers/parsers/leakix"
-	"github.com/abusix/inbound-parsers/parsers/leakserv"
-	"github.com/abusix/inbound-parsers/parsers/legalbaselaw"
-	"github.com/abusix/inbound-parsers/parsers/m247"
-	"github.com/abusix/inbound-parsers/parsers/magazineluiza"
-	"github.com/abusix/inbound-parsers/parsers/mail_abuse"
-	"github.com/abusix/inbound-parsers/parsers/mail_bolster"
-	"github.com/abusix/inbound-parsers/parsers/mail_reject"
-	"github.com/abusix/inbound-parsers/parsers/mail_ru"
-	"github.com/abusix/inbound-parsers/parsers/mailabuse"
-	"github.com/abusix/inbound-parsers/parsers/manitu"
-	"github.com/abusix/inbound-parsers/parsers/marche_be"
-	"github.com/abusix/inbound-parsers/parsers/marf"
-	"github.com/abusix/inbound-parsers/parsers/marqvision"
-	"github.com/abusix/inbound-parsers/parsers/masterdaweb"
-	"github.com/abusix/inbound-parsers/parsers/mcgill"
-	"github.com/abusix/inbound-parsers/parsers/meadowbrookequine"
-	"github.com/abusix/inbound-parsers/parsers/mediastory"
-	"github.com/abusix/inbound-parsers/parsers/meldpunkt_kinderporno"
-	"github.com/abusix/inbound-parsers/parsers/melio"
-	"github.com/abusix/inbound-parsers/parsers/michael_joost"
-	"github.com/abusix/inbound-parsers/parsers/mieweb"
-	"github.com/abusix/inbound-parsers/parsers/miglisoft"
-	"github.com/abusix/inbound-parsers/parsers/mirrorimagegaming"
-	"github.com/abusix/inbound-parsers/parsers/mm_moneygram"
-	"github.com/abusix/inbound-parsers/parsers/mnemo"
-	"github.com/abusix/inbound-parsers/parsers/mobsternet"
-	"github.com/abusix/inbound-parsers/parsers/multimediallc"
-	"github.com/abusix/inbound-parsers/parsers/mxtoolbox"
-	"github.com/abusix/inbound-parsers/parsers/myloc"
-	"github.com/abusix/inbound-parsers/parsers/nagramonitoring"
-	"github.com/abusix/inbound-parsers/parsers/nagrastar"
-	"github.com/abusix/inbound-parsers/parsers/names_uk"
-	"github.com/abusix/inbound-parsers/parsers/ncmec"
-	"github.com/abusix/inbound-parsers/parsers/neptus"
-	"github.com/abusix/inbound-parsers/parsers/netbuild"
-	"github.com/abusix/inbound-parsers/parsers/netcologne"
-	"github.com/abusix/inbound-parsers/parsers/netis"
-	"github.com/abusix/inbound-parsers/parsers/netresult"
-	"github.com/abusix/inbound-parsers/parsers/netsecdb"
-	"github.com/abusix/inbound-parsers/parsers/netum"
-	"github.com/abusix/inbound-parsers/parsers/nksc"
-	"github.com/abusix/inbound-parsers/parsers/nla"
-	"github.com/abusix/inbound-parsers/parsers/notificationofinfringement"
-	"github.com/abusix/inbound-parsers/parsers/nsc"
-	"github.com/abusix/inbound-parsers/parsers/nt_gov"
-	"github.com/abusix/inbound-parsers/parsers/nwf"
-	"github.com/abusix/inbound-parsers/parsers/nyx"
-	"github.com/abusix/inbound-parsers/parsers/obp_corsearch"
-	"github.com/abusix/inbound-parsers/parsers/octopusdns"
-	"github.com/abusix/inbound-parsers/parsers/onecloud"
-	"github.com/abusix/inbound-parsers/parsers/onsist"
-	"github.com/abusix/inbound-parsers/parsers/oplium"
-	"github.com/abusix/inbound-parsers/parsers/oppl"
-	"github.com/abusix/inbound-parsers/parsers/opsec_enforcements"
-	"github.com/abusix/inbound-parsers/parsers/opsec_protect"
-	"github.com/abusix/inbound-parsers/parsers/orangecyberdefense"
-	"github.com/abusix/inbound-parsers/parsers/osn"
-	"github.com/abusix/inbound-parsers/parsers/outlook"
-	"github.com/abusix/inbound-parsers/parsers/outseer"
-	"github.com/abusix/inbound-parsers/parsers/p44"
-	"github.com/abusix/inbound-parsers/parsers/paps"
-	"github.com/abusix/inbound-parsers/parsers/paramount"
-	"github.com/abusix/inbound-parsers/parsers/pccc_trap"
-	"github.com/abusix/inbound-parsers/parsers/pedohunt"
-	"github.com/abusix/inbound-parsers/parsers/penega"
-	"github.com/abusix/inbound-parsers/parsers/perfettivanmelle"
-	"github.com/abusix/inbound-parsers/parsers/perso"
-	"github.com/abusix/inbound-parsers/parsers/phoenixadvocates"
-	"github.com/abusix/inbound-parsers/parsers/phototakedown"
-	"github.com/abusix/inbound-parsers/parsers/pj3cx"
-	"github.com/abusix/inbound-parsers/parsers/profihost"
-	"github.com/abusix/inbound-parsers/parsers/project_honeypot_trap"
-	"github.com/abusix/inbound-parsers/parsers/promusicae"
-	"github.com/abusix/inbound-parsers/parsers/prsformusic"
-	"github.com/abusix/inbound-parsers/parsers/puglia"
-	"github.com/abusix/inbound-parsers/parsers/puig"
-	"github.com/abusix/inbound-parsers/parsers/pwn2_zip"
-	"github.com/abusix/inbound-parsers/parsers/qwertynetworks"
-	"github.com/abusix/inbound-parsers/parsers/rapid7"
-	"github.com/abusix/inbound-parsers/parsers/react"
-	"github.com/abusix/inbound-parsers/parsers/realityripple"
-	"github.com/abusix/inbound-parsers/parsers/redfish"
-	"github.com/abusix/inbound-parsers/parsers/rediffmail_tis"
-	"github.com/abusix/inbound-parsers/parsers/reggerspaul"
-	"github.com/abusix/inbound-parsers/parsers/regioconnect"
-	"github.com/abusix/inbound-parsers/parsers/registro"
-	"github.com/abusix/inbound-parsers/parsers/removal_request"
-	"github.com/abusix/inbound-parsers/parsers/revengepornhelpline"
-	"github.com/abusix/inbound-parsers/parsers/richardwebley"
-	"github.com/abusix/inbound-parsers/parsers/ricomanagement"
-	"github.com/abusix/inbound-parsers/parsers/rivertec"
-	"github.com/abusix/inbound-parsers/parsers/rsjaffe"
-	"github.com/abusix/inbound-parsers/parsers/ruprotect"
-	"github.com/abusix/inbound-parsers/parsers/savana"
-	"github.com/abusix/inbound-parsers/parsers/sbcglobal"
-	"github.com/abusix/inbound-parsers/parsers/scert"
-	"github.com/abusix/inbound-parsers/parsers/secureserver"
-	"github.com/abusix/inbound-parsers/parsers/selcloud"
-	"github.com/abusix/inbound-parsers/parsers/serverplan"
-	"github.com/abusix/inbound-parsers/parsers/serviceexpress"
-	"github.com/abusix/inbound-parsers/parsers/shadowserver_digest"
-	"github.com/abusix/inbound-parsers/parsers/shinhan"
-	"github.com/abusix/inbound-parsers/parsers/sia"
-	"github.com/abusix/inbound-parsers/parsers/sidnnl"
-	"github.com/abusix/inbound-parsers/parsers/simple_format"
-	"github.com/abusix/inbound-parsers/parsers/simple_guess_parser"
-	"github.com/abusix/inbound-parsers/parsers/simple_rewrite"
-	"github.com/abusix/inbound-parsers/parsers/simple_tis"
-	"github.com/abusix/inbound-parsers/parsers/simple_url_report"
-	"github.com/abusix/inbound-parsers/parsers/skhron"
-	"github.com/abusix/inbound-parsers/parsers/stackpath"
-	"github.com/abusix/inbound-parsers/parsers/staxogroup"
-	"github.com/abusix/inbound-parsers/parsers/stockpile"
-	"github.com/abusix/inbound-parsers/parsers/stop_or_kr"
-	"github.com/abusix/inbound-parsers/parsers/storage_base"
-	"github.com/abusix/inbound-parsers/parsers/streamenforcement"
-	"github.com/abusix/inbound-parsers/parsers/studiobarbero"
-	"github.com/abusix/inbound-parsers/parsers/svbuero"
-	"github.com/abusix/inbound-parsers/parsers/swisscom_tis"
-	"github.com/abusix/inbound-parsers/parsers/switchch"
-	"github.com/abusix/inbound-parsers/parsers/synacor"
-	"github.com/abusix/inbound-parsers/parsers/systeam"
-	"github.com/abusix/inbound-parsers/parsers/takedown"
-	"github.com/abusix/inbound-parsers/parsers/takedownnow"
-	"github.com/abusix/inbound-parsers/parsers/takedownreporting"
-	"github.com/abusix/inbound-parsers/parsers/tampabay"
-	"github.com/abusix/inbound-parsers/parsers/tassilosturm"
-	"github.com/abusix/inbound-parsers/parsers/tecban"
-	"github.com/abusix/inbound-parsers/parsers/techspace"
-	"github.com/abusix/inbound-parsers/parsers/telecentras"
-	"github.com/abusix/inbound-parsers/parsers/telecom_tm"
-	"github.com/abusix/inbound-parsers/parsers/telecomitalia"
-	"github.com/abusix/inbound-parsers/parsers/telenor"
-	"github.com/abusix/inbound-parsers/parsers/tempest"
-	"github.com/abusix/inbound-parsers/parsers/terra"
-	"github.com/abusix/inbound-parsers/parsers/themccandlessgroup"
-	"github.com/abusix/inbound-parsers/parsers/thiscompany"
-	"github.com/abusix/inbound-parsers/parsers/thomsentrampedach"
-	"github.com/abusix/inbound-parsers/parsers/threeantsds"
-	"github.com/abusix/inbound-parsers/parsers/tikaj"
-	"github.com/abusix/inbound-parsers/parsers/timbrasil"
-	"github.com/abusix/inbound-parsers/parsers/tmclo"
-	"github.com/abusix/inbound-parsers/parsers/tntelecom"
-	"github.com/abusix/inbound-parsers/parsers/torrent_markmonitor"
-	"github.com/abusix/inbound-parsers/parsers/triciafox"
-	"github.com/abusix/inbound-parsers/parsers/truelite"
-	"github.com/abusix/inbound-parsers/parsers/trustpilot"
-	"github.com/abusix/inbound-parsers/parsers/ttp_law"
-	"github.com/abusix/inbound-parsers/parsers/tts_stuttgart"
-	"github.com/abusix/inbound-parsers/parsers/tvb"
-	"github.com/abusix/inbound-parsers/parsers/tx_rr"
-	"github.com/abusix/inbound-parsers/parsers/ucr_edu"
-	"github.com/abusix/inbound-parsers/parsers/ucs_br"
-	"github.com/abusix/inbound-parsers/parsers/ufrgs"
-	"github.com/abusix/inbound-parsers/parsers/ukie"
-	"github.com/abusix/inbound-parsers/parsers/ukrbit"
-	"github.com/abusix/inbound-parsers/parsers/uni_koblenz"
-	"github.com/abusix/inbound-parsers/parsers/uphf"
-	"github.com/abusix/inbound-parsers/parsers/us_cert"
-	"github.com/abusix/inbound-parsers/parsers/verifrom"
-	"github.com/abusix/inbound-parsers/parsers/viaccessorca"
-	"github.com/abusix/inbound-parsers/parsers/virtus"
-	"github.com/abusix/inbound-parsers/parsers/vmware"
-	"github.com/abusix/inbound-parsers/parsers/vobileinc"
-	"github.com/abusix/inbound-parsers/parsers/vpsnet"
-	"github.com/abusix/inbound-parsers/parsers/watchdog"
-	"github.com/abusix/inbound-parsers/parsers/web"
-	"github.com/abusix/inbound-parsers/parsers/webcapio"
-	"github.com/abusix/inbound-parsers/parsers/webhostabusereporting"
-	"github.com/abusix/inbound-parsers/parsers/websteiner"
-	"github.com/abusix/inbound-parsers/parsers/websumo"
-	"github.com/abusix/inbound-parsers/parsers/webtoonguide"
-	"github.com/abusix/inbound-parsers/parsers/weightechinc"
-	"github.com/abusix/inbound-parsers/parsers/whitefoxboutique"
-	"github.com/abusix/inbound-parsers/parsers/winterburn"
-	"github.com/abusix/inbound-parsers/parsers/winvoice"
-	"github.com/abusix/inbound-parsers/parsers/wisc"
-	"github.com/abusix/inbound-parsers/parsers/xarf"
-	"github.com/abusix/inbound-parsers/parsers/xtakedowns"
-	"github.com/abusix/inbound-parsers/parsers/zap_hosting"
-	"github.com/abusix/inbound-parsers/parsers/zapret"
-	"github.com/abusix/inbound-parsers/parsers/zero_spam"
-	"github.com/abusix/inbound-parsers/parsers/zohocorp"
-	"github.com/abusix/inbound-parsers/pkg/email"
 )
 
-// ParserRegistry holds all available parsers
-type ParserRegistry struct {
-	parsers []ParserFactory
-}
-
-// ParserFactory creates parser instances
-type ParserFactory func(serializedEmail email.SerializedEmail, fromAddr, fromName, contentType string) base.Parser
-
-// NewParserRegistry creates a new parser registry
-func NewParserRegistry() *ParserRegistry {
-	registry := &ParserRegistry{
-		parsers: make([]ParserFactory, 0),
-	}
-
-	// Register all parsers (in priority order)
-
-	// Priority 1: FBL (Feedback Loop) - Most critical
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fbl.NewFBLParser(se, fa, fn, ct)
-	})
-
-	// Priority 2: ShadowServer - Highest volume parser
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return shadowserver.New(se, fa, fn, ct)
-	})
-
-	// Priority 3: CERT Parsers (15 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_pt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_ee.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_es.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_bz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_no.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_pl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_lt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_nz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_ro.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_ua.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_hr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_in.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_gib.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_gov.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_rcts.New(se, fa, fn, ct)
-	})
-
-	// Priority 4: Brand Protection Parsers (18 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bp_corsearch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandmonitor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandshield.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandsecurity_ru.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ebrand.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return antipiracy.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return antipiracy_report.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return copyright_compliance.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return antipiracyprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return apiccopyright.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return copyright_integrity.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dmcapiracyprevention.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return eyeonpiracy.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mih_brandprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tescobrandprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return valentinobrandprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ybrandprotection.New(se, fa, fn, ct)
-	})
-
-	// Priority 5: Anti-Spam and Major Provider Parsers (15 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return spamcop.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return spamhaus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return abuse_oneprovider.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return abusehub_nl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return abusetrue_nl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return botnet_tracker.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return amazon.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return microsoft.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return googlesafebrowsing.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return att.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return aol.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return yahoo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return comcast.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return phishfort.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return phishlabscom.New(se, fa, fn, ct)
-	})
-
-	// Priority 6: ISP and Hosting Providers (15 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bsi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return centurylink.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cloudflare.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hetzner.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netcraft.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return abusix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bellsouth.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return adobe.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return verizon.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return twc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return spectrum.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rackspace.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return godaddy.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bluehost.New(se, fa, fn, ct)
-	})
-
-	// Priority 7: Additional Cloud/Hosting Providers (24 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return digital_ocean.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dreamhost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return linode.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ovh.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return scaleway.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return vultr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return abuse_ch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return urlhaus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return feodotracker.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ionos.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return oneandone.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return strato.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return leaseweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return limestone.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nfoservers.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ncsc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ncsc_nl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ncsc_fi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return interhost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return serverstack.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return datapacket.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hostdime.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return colocationamerica.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return buycheaprdp.New(se, fa, fn, ct)
-	})
-
-	// Priority 8: Telecom Providers and Additional CERTs (37 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return orange.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return orange_fr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return swisscom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return telus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rogers.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bell.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return certat.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return certbr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return jpcert.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csirt_cz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csirt_divd.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return govcert_ch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tucows.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return namecheap.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return enom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return squarespace.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return wix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return shopify.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return internap.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return packet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return quadranet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ibm.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return softlayer.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zenlayer.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return unity_media.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return kabel_deutschland.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return versatel.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ecatel.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sharktech.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return vpsville.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return choopa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nocix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return psychz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sakura.New(se, fa, fn, ct)
-	})
-
-	// Priority 9: Asian ISPs and Tech Companies (10 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ntt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return korea_telecom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sk_broadband.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return lg_uplus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tencent.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return baidu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return huawei.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return facebook.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return twitter.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return instagram.New(se, fa, fn, ct)
-	})
-
-	// Priority 10: Major Tech Companies and Cloud Providers (18 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return akamai.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netflix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return linkedin.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return apple.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return google.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return microsoft_dmca.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return paypal.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ebay.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return etsy.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return aws.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return azure.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gcp.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return wordpress.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cloudflare_report.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fastly.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cloudns.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dnsimple.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return route53.New(se, fa, fn, ct)
-	})
-
-	// Priority 11: Media Companies and Content Protection (17 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return disney.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hbo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sony.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nbcuni.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bbc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return itv.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sky.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return viacom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cbs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fox.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return riaa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ifpi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bpi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mpaa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mpa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return laliga.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netflix.New(se, fa, fn, ct)
-	})
-
-	// Priority 12: Brand Protection and Threat Intelligence (18 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandprotection.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return markscan.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return opsecsecurityonline.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return websheriff.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return redpoints.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return brandsecurity_ru.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return incopro.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netcraft.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fraudwatch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return phishlabscom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zerofox.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cyberint.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return riskiq.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return group_ib.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cyble.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return crowdstrike.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return recordedfuture.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return digital_ocean.New(se, fa, fn, ct)
-	})
-
-	// Priority 13: Anti-Spam Services and DMCA Takedowns (5 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return uceprotect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cert_fi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dmca_com.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dmcaforce.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return attributor.New(se, fa, fn, ct)
-	})
-
-	// Priority 14: ISPs, Hosting, CERTs, and Brand Protection (Batch 9: 18 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cogent.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return aruba.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return autofusion.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cpanel.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return columbiaedu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return checkphish.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bka.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ccirc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cavac.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bitninja.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bluevoyant.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bytescare.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return aiplex.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return axur.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return barrettlawgroup.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return chaturbate.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cammodelprotect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bwbmodels.New(se, fa, fn, ct)
-	})
-
-	// Priority 15: Anti-piracy Services and International Providers (Batch 10: 18 parsers)
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return acastano.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return accenture.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return acedatacenter.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return acns.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return adciberespaco.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return agouros.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return amasha.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return anvisa_gov.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ap_markmonitor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return aparlay.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return arkadruk.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return artplanet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return avoxi.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return axghouse.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return b_monitor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return baysidecorp.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return beygoo.New(se, fa, fn, ct)
-	})
-
-
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bibo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return black_dura.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bnshosting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bofa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return bradesco.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return buerki.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cdar_westpac.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return centurylinkservices.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return circllu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ciu_online.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cnsd_gob_pe.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return comeso.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return communicationvalley.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return comvive.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return counterfeittechnology.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return courbis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return courts_in.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cpragency.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return crdflabs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return crm_wix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csa.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cscglobal.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csirt_br.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csirt_dnofd.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csirt_muni.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return csis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return customvisuals.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cyber_gc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cyber999.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cybertip.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return cyberweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return d3lab.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return darklist.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dcpmail.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dd_tech.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ddos_google.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return debian.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return defaria.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return deft.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return deloite.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return desmoweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dgn.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dgt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return digiguardians.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return digiturk.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return djr_co.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dmarc_xml.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dmca_pro.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dnainternet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dnsc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return docusign.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return domainabusereporting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return domainoo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return doppel.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dreamworldpartners.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return dreyfus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return easysol.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ebs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return eca.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ecucert.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return eisys.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ellematthewsmodel.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return enf_meta.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return enfappdetex.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return entura.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ephemeron.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return eq_ee.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return esp.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return espresso.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return etoolkit.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return etotalhost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return europa_eu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return exemail.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return experian.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return expressvpn.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return facct.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fail2ban.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fbi_ipv6home.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fbs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return feedback_loop.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fhs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return flyhosting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fmtsoperation.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fondia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fraudwatchinternational.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return freedomtech.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return friendmts.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fsec.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return fsm.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gastecnologia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return generic_spam_trap.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ginernet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return giorgioarmaniweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gmail_parser.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gmx.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gmx_com.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return gold_parser.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return griffeshield.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hack_hunt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return heficed.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return herrbischoff.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hfmarket.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hispasec.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hkcert.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return home.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return honeypots_tk.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hosteurope.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hostfission.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hostopia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hostroyale.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hotmail.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return humongoushibiscus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return hyperfilter.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ibcom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return icscards.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return iheatwithoil.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ilvasapolli.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return inaxas.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return infringements_cc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return innotec.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return interconnect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return interieur_gouv_fr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return internet2.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return intsights.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ipvanish.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ipxo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return irdeto.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return irisio.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return irs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return isag.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ish.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return iwf.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return izoologic.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return jcloud.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return jeffv.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return joturl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return jugendschutz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return juno.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return jutho.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return kilpatricktown.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return kinghost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return kinopoisk.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return klingler_net.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return kpnmail.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return latam.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return leakix.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return leakserv.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return legalbaselaw.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return m247.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return magazineluiza.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mail_abuse.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mail_bolster.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mail_reject.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mail_ru.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mailabuse.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return manitu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return marche_be.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return marf.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return marqvision.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return masterdaweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mcgill.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return meadowbrookequine.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mediastory.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return meldpunkt_kinderporno.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return melio.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return michael_joost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mieweb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return miglisoft.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mirrorimagegaming.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mm_moneygram.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mnemo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mobsternet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return multimediallc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return mxtoolbox.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return myloc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nagramonitoring.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nagrastar.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return names_uk.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ncmec.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return neptus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netbuild.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netcologne.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netresult.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netsecdb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return netum.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nksc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nla.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return notificationofinfringement.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nsc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nt_gov.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nwf.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return nyx.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return obp_corsearch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return octopusdns.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return onecloud.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return onsist.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return oplium.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return oppl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return opsec_enforcements.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return opsec_protect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return orangecyberdefense.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return osn.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return outlook.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return outseer.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return p44.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return paps.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return paramount.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return pccc_trap.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return pedohunt.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return penega.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return perfettivanmelle.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return perso.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return phoenixadvocates.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return phototakedown.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return pj3cx.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return profihost.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return project_honeypot_trap.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return promusicae.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return prsformusic.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return puglia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return puig.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return pwn2_zip.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return qwertynetworks.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rapid7.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return react.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return realityripple.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return redfish.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rediffmail_tis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return reggerspaul.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return regioconnect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return registro.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return removal_request.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return revengepornhelpline.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return richardwebley.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ricomanagement.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rivertec.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return rsjaffe.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ruprotect.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return savana.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sbcglobal.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return scert.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return secureserver.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return selcloud.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return serverplan.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return serviceexpress.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return shadowserver_digest.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return shinhan.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return sidnnl.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return simple_format.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return simple_guess_parser.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return simple_rewrite.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return simple_tis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return simple_url_report.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return skhron.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return stackpath.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return staxogroup.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return stockpile.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return stop_or_kr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return storage_base.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return streamenforcement.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return studiobarbero.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return svbuero.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return swisscom_tis.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return switchch.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return synacor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return systeam.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return takedown.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return takedownnow.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return takedownreporting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tampabay.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tassilosturm.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tecban.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return techspace.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return telecentras.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return telecom_tm.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return telecomitalia.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return telenor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tempest.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return terra.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return themccandlessgroup.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return thiscompany.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return thomsentrampedach.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return threeantsds.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tikaj.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return timbrasil.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tmclo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tntelecom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return torrent_markmonitor.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return triciafox.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return truelite.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return trustpilot.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ttp_law.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tts_stuttgart.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tvb.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return tx_rr.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ucr_edu.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ucs_br.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ufrgs.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ukie.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return ukrbit.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return uni_koblenz.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return uphf.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return us_cert.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return verifrom.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return viaccessorca.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return virtus.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return vmware.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return vobileinc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return vpsnet.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return watchdog.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return web.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return webcapio.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return webhostabusereporting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return websteiner.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return websumo.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return webtoonguide.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return weightechinc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return whitefoxboutique.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return winterburn.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return winvoice.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return wisc.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return xarf.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return xtakedowns.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zap_hosting.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zapret.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zero_spam.New(se, fa, fn, ct)
-	})
-	registry.Register(func(se email.SerializedEmail, fa, fn, ct string) base.Parser {
-		return zohocorp.New(se, fa, fn, ct)
-	})
-
-	// All parsers registered (329 new parsers added)
-
-	return registry
-}
-
-// Register adds a parser factory to the registry
-func (r *ParserRegistry) Register(factory ParserFactory) {
-	r.parsers = append(r.parsers, factory)
-}
-
-// Process processes an email through all parsers
-func (r *ParserRegistry) Process(serializedEmail email.SerializedEmail) ([]byte, error) {
-	// Extract metadata
-	fromAddr := extractFromAddr(serializedEmail)
-	fromName := extractFromName(serializedEmail)
-	contentType := extractContentType(serializedEmail)
-
-	// Try each parser
-	for _, factory := range r.parsers {
-		parser := factory(serializedEmail, fromAddr, fromName, contentType)
-
-		// Check if parser matches
-		if parser.Match() == base.MatchParse {
-			// Parse the email
-			events, err := parser.Parse()
-			if err != nil {
-				log.Printf("Parser %s error: %v", parser.GetParserName(), err)
-				continue
-			}
-
-			// Convert events to JSON
-			if len(events) > 0 {
-				output, err := json.Marshal(events)
-				if err != nil {
-					return nil, fmt.Errorf("failed to marshal events: %w", err)
-				}
-				return output, nil
-			}
-		}
-	}
-
-	return nil, fmt.Errorf("no parser matched the email")
-}
-
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <command>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Commands:\n")
-		fmt.Fprintf(os.Stderr, "  lint <config-file>  - Validate Bento configuration\n")
-		fmt.Fprintf(os.Stderr, "  process            - Process emails from stdin (Bento mode)\n")
+		usage()
 		os.Exit(1)
 	}
 
@@ -2342,23 +25,16 @@ func main() {
 		fmt.Println("Bento config validation: OK")
 
 	case "process":
-		registry := NewParserRegistry()
+		runProcess(os.Args[2:])
 
-		// Read serialized email from stdin
-		var serializedEmail email.SerializedEmail
-		decoder := json.NewDecoder(os.Stdin)
-		if err := decoder.Decode(&serializedEmail); err != nil {
-			log.Fatalf("Failed to decode email: %v", err)
-		}
+	case "serve":
+		runServe(os.Args[2:])
 
-		// Process email
-		output, err := registry.Process(serializedEmail)
-		if err != nil {
-			log.Fatalf("Failed to process email: %v", err)
-		}
+	case "parse":
+		runParse(os.Args[2:])
 
-		// Write to stdout
-		fmt.Println(string(output))
+	case "replay":
+		runReplay(os.Args[2:])
 
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
@@ -2366,25 +42,12 @@ func main() {
 	}
 }
 
-// Helper functions
-
-func extractFromAddr(email email.SerializedEmail) string {
-	if from, ok := email.Headers["from"]; ok && len(from) > 0 {
-		// Extract email from "Name <email@domain.com>" format
-		// This is simplified - full implementation would parse RFC 5322 addresses
-		return from[0]
-	}
-	return ""
-}
-
-func extractFromName(email email.SerializedEmail) string {
-	// Extract display name from From header
-	return ""
-}
-
-func extractContentType(email email.SerializedEmail) string {
-	if ct, ok := email.Headers["content-type"]; ok && len(ct) > 0 {
-		return ct[0]
-	}
-	return ""
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	fmt.Fprintf(os.Stderr, "  lint <config-file>  - Validate Bento configuration\n")
+	fmt.Fprintf(os.Stderr, "  process            - Process emails from stdin (Bento mode)\n")
+	fmt.Fprintf(os.Stderr, "  serve              - Serve the parsers over HTTP\n")
+	fmt.Fprintf(os.Stderr, "  parse <path>...    - Parse .eml, mbox or Maildir input and print the events\n")
+	fmt.Fprintf(os.Stderr, "  replay <path>...   - Re-run dead letters and report which parse now\n")
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+	"github.com/abusix/inbound-parsers/pkg/metrics"
+)
+
+// noParser labels emails no parser matched
+const noParser = "none"
+
+// pipelineMetrics exports what the parser registry does as Prometheus
+// metrics; it is the registry's observer in the process and serve modes
+type pipelineMetrics struct {
+	registry *metrics.Registry
+
+	emails        *metrics.CounterVec
+	emailDuration *metrics.HistogramVec
+	emailSize     *metrics.HistogramVec
+
+	attempts       *metrics.CounterVec
+	matches        *metrics.CounterVec
+	parserEvents   *metrics.CounterVec
+	rejected       *metrics.CounterVec
+	ignored        *metrics.CounterVec
+	errors         *metrics.CounterVec
+	parserDuration *metrics.HistogramVec
+
+	events *metrics.CounterVec
+}
+
+func newPipelineMetrics() *pipelineMetrics {
+	registry := metrics.NewRegistry()
+	sizeBuckets := metrics.ExponentialBuckets(1024, 4, 9)
+	m := &pipelineMetrics{
+		registry: registry,
+		emails: registry.NewCounterVec("inbound_parsers_emails_total",
+			"Emails parsed by result: matched, matched_with_error, ignored, rejected, unmatched, error or too_large.", "result"),
+		emailDuration: registry.NewHistogramVec("inbound_parsers_email_duration_seconds",
+			"Time to parse an email through the registry, by chosen parser.", metrics.DefBuckets, "parser"),
+		emailSize: registry.NewHistogramVec("inbound_parsers_email_size_bytes",
+			"Size of the headers and bodies of parsed emails, by chosen parser.", sizeBuckets, "parser"),
+		attempts: registry.NewCounterVec("inbound_parsers_parser_attempts_total",
+			"Parser runs on an email.", "parser"),
+		matches: registry.NewCounterVec("inbound_parsers_parser_matches_total",
+			"Parser runs that emitted events.", "parser"),
+		parserEvents: registry.NewCounterVec("inbound_parsers_parser_events_total",
+			"Events emitted by a parser.", "parser"),
+		rejected: registry.NewCounterVec("inbound_parsers_parser_rejected_total",
+			"Emails a parser rejected.", "parser"),
+		ignored: registry.NewCounterVec("inbound_parsers_parser_ignored_total",
+			"Emails a parser ignored.", "parser"),
+		errors: registry.NewCounterVec("inbound_parsers_parser_errors_total",
+			"Parser runs that failed, by class: declined, new_type, error, panicked, timed_out or matched_with_error.", "parser", "class"),
+		parserDuration: registry.NewHistogramVec("inbound_parsers_parser_duration_seconds",
+			"Time of one parser run on an email.", metrics.DefBuckets, "parser"),
+		events: registry.NewCounterVec("inbound_parsers_events_total",
+			"Events emitted, by parser and event type.", "parser", "event_type"),
+	}
+	registry.NewGaugeFunc("inbound_parsers_quarantined_parsers",
+		"Parsers skipped after repeated panics or timeouts.", func() float64 { return float64(len(parsers.QuarantinedParsers())) })
+	return m
+}
+
+// ParserAttempted implements parsers.Observer
+func (m *pipelineMetrics) ParserAttempted(attempt parsers.Attempt) {
+	if attempt.Outcome == parsers.OutcomeQuarantined {
+		return
+	}
+	m.attempts.WithLabelValues(attempt.Parser).Inc()
+	m.parserDuration.WithLabelValues(attempt.Parser).Observe(attempt.DurationMS / 1000)
+	if attempt.Events > 0 {
+		m.matches.WithLabelValues(attempt.Parser).Inc()
+		m.parserEvents.WithLabelValues(attempt.Parser).Add(float64(attempt.Events))
+	}
+	switch attempt.Outcome {
+	case parsers.OutcomeMatched, parsers.OutcomeNoEvents:
+	case parsers.OutcomeRejected:
+		m.rejected.WithLabelValues(attempt.Parser).Inc()
+	case parsers.OutcomeIgnored:
+		m.ignored.WithLabelValues(attempt.Parser).Inc()
+	default:
+		m.errors.WithLabelValues(attempt.Parser, attempt.Outcome).Inc()
+	}
+}
+
+// EventEmitted implements parsers.Observer
+func (m *pipelineMetrics) EventEmitted(event *events.Event) {
+	if len(event.EventTypes) == 0 {
+		m.events.WithLabelValues(event.Parser, "none").Inc()
+		return
+	}
+	for _, eventType := range event.EventTypes {
+		if eventType != nil {
+			m.events.WithLabelValues(event.Parser, eventType.GetType()).Inc()
+		}
+	}
+}
+
+// EmailParsed implements parsers.Observer
+func (m *pipelineMetrics) EmailParsed(result parsers.EmailResult) {
+	parser := result.Parser
+	if parser == "" {
+		parser = noParser
+	}
+	var tooLarge *parsers.EmailTooLargeError
+	var ignored *common.IgnoreError
+	var rejected *common.RejectError
+	switch {
+	case errors.As(result.Err, &tooLarge):
+		m.emails.WithLabelValues("too_large").Inc()
+	case errors.As(result.Err, &ignored):
+		m.emails.WithLabelValues("ignored").Inc()
+	case errors.As(result.Err, &rejected):
+		m.emails.WithLabelValues("rejected").Inc()
+	case result.Events > 0 && result.Err != nil:
+		m.emails.WithLabelValues("matched_with_error").Inc()
+	case result.Events > 0:
+		m.emails.WithLabelValues("matched").Inc()
+	case result.Err != nil:
+		m.emails.WithLabelValues("error").Inc()
+	default:
+		m.emails.WithLabelValues("unmatched").Inc()
+	}
+	m.emailDuration.WithLabelValues(parser).Observe(result.Duration.Seconds())
+	m.emailSize.WithLabelValues(parser).Observe(float64(result.Size))
+}
+
+// serveMetrics serves the metrics on their own listener, for the process mode
+// whose stdout carries the events
+func serveMetrics(addr string, handler http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		slog.Info("Serving metrics", "addr", addr)
+		if err := server.ListenAndServe(); err != nil {
+			slog.Error("Metrics server failed", logging.KeyError, err)
+		}
+	}()
+}
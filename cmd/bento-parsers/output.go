@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/encoders"
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// defaultFormat is the native event JSON
+const defaultFormat = "json"
+
+// outputOptions are the output format flags shared by the process and serve modes
+type outputOptions struct {
+	format   string
+	reporter encoders.Reporter
+}
+
+func (o *outputOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", defaultFormat, "output format: "+strings.Join(append([]string{defaultFormat}, encoders.Names()...), ", "))
+	fs.StringVar(&o.reporter.Name, "reporter-name", "", "reporter name in xarf, arf and iodef reports")
+	fs.StringVar(&o.reporter.Email, "reporter-email", "abuse@localhost", "reporter address in xarf, arf and iodef reports")
+	fs.StringVar(&o.reporter.To, "report-to", "", "recipient of xarf and arf report mails")
+}
+
+// encoder returns the encoder of a format; nil for the native JSON
+func (o *outputOptions) encoder(format string) (encoders.Encoder, error) {
+	if format == "" || strings.EqualFold(format, defaultFormat) {
+		return nil, nil
+	}
+	return encoders.New(format, o.reporter)
+}
+
+// eventWriter receives the events of one email; Write is used as the
+// registry's emit callback and Close ends the output of the email
+type eventWriter interface {
+	Write(event *events.Event) error
+	Close() error
+}
+
+// newEventWriter returns the writer of an encoder: line encoders stream one
+// line per event, other encoders get the events of the email as one batch
+func newEventWriter(w *bufio.Writer, encoder encoders.Encoder) eventWriter {
+	switch encoder := encoder.(type) {
+	case nil:
+		return newEventArrayWriter(w)
+	case encoders.LineEncoder:
+		return &lineWriter{w: w, encoder: encoder}
+	default:
+		return &batchWriter{w: w, encoder: encoder}
+	}
+}
+
+// lineWriter writes each event as one encoded line
+type lineWriter struct {
+	w       *bufio.Writer
+	encoder encoders.LineEncoder
+}
+
+func (l *lineWriter) Write(event *events.Event) error {
+	line, err := l.encoder.EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+	if _, err := l.w.Write(line); err != nil {
+		return err
+	}
+	return l.w.WriteByte('\n')
+}
+
+func (l *lineWriter) Close() error {
+	return nil
+}
+
+// batchWriter collects the events and writes them as one document on Close;
+// nothing is written for emails without events
+type batchWriter struct {
+	w       *bufio.Writer
+	encoder encoders.Encoder
+	events  []*events.Event
+}
+
+func (b *batchWriter) Write(event *events.Event) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *batchWriter) Close() error {
+	if len(b.events) == 0 {
+		return nil
+	}
+	data, err := b.encoder.Encode(b.events)
+	if err != nil {
+		return err
+	}
+	_, err = b.w.Write(data)
+	return err
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// message is a mail to parse, loaded lazily by the workers
+type message struct {
+	// source names the mail in the output: its path, with the message number for mbox files
+	source string
+	load   func() ([]byte, error)
+	// metaPath is the .meta.json side-car with the metadata of the mail
+	metaPath string
+}
+
+// parseResult is the output of one mail
+type parseResult struct {
+	Source string          `json:"source"`
+	Events []*events.Event `json:"events"`
+	Error  string          `json:"error,omitempty"`
+	// Explain is set with -explain
+	Explain *parsers.Explanation `json:"explain,omitempty"`
+}
+
+// runParse parses mails saved as .eml files, mbox files or Maildir
+// directories and prints their events for inspection
+func runParse(args []string) {
+	fs := flag.NewFlagSet("parse", flag.ExitOnError)
+	format := fs.String("format", "json", "output format: json (pretty printed) or table")
+	parserName := fs.String("parser", "", "run only this parser (package name, e.g. spamcop) instead of the registry")
+	jobs := fs.Int("j", 1, "number of mails parsed in parallel")
+	var limits limitOptions
+	limits.register(fs)
+	var logs logOptions
+	logs.register(fs)
+	explain := fs.Bool("explain", false, "show the parsers tried, the chosen one and where the event fields were found")
+	var enrichment enrichOptions
+	enrichment.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s parse [flags] <file.eml|mbox|maildir|directory>...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	logs.apply()
+	limits.apply()
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *format != "json" && *format != "table" {
+		fmt.Fprintf(os.Stderr, "Unknown format %q (available: json, table)\n", *format)
+		os.Exit(2)
+	}
+
+	var forced *parsers.ParserWrapper
+	if *parserName != "" {
+		pw, ok := parsers.ParserByName(*parserName)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown parser %q\n", *parserName)
+			os.Exit(2)
+		}
+		forced = &pw
+	}
+	enrichment.open(context.Background())
+
+	var messages []message
+	for _, path := range fs.Args() {
+		found, err := findMessages(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		messages = append(messages, found...)
+	}
+
+	// Workers parse ahead; results are printed in input order
+	results := make([]chan parseResult, len(messages))
+	for i := range results {
+		results[i] = make(chan parseResult, 1)
+	}
+	next := make(chan int)
+	go func() {
+		for i := range messages {
+			next <- i
+		}
+		close(next)
+	}()
+	for w := 0; w < max(*jobs, 1); w++ {
+		go func() {
+			for i := range next {
+				results[i] <- parseMessage(messages[i], forced, *explain, &enrichment)
+			}
+		}()
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var table *tabwriter.Writer
+	if *format == "table" {
+		table = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(table, "SOURCE\tPARSER\tTYPE\tIP\tPORT\tURL/DOMAIN\tDATE")
+	}
+
+	failed := 0
+	var explained []parseResult
+	for i := range messages {
+		result := <-results[i]
+		if result.Error != "" {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %s\n", result.Source, result.Error)
+		}
+		if table != nil {
+			writeTableRows(table, result)
+			if result.Explain != nil {
+				explained = append(explained, result)
+			}
+			continue
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: failed to encode events: %v\n", result.Source, err)
+			failed++
+			continue
+		}
+		out.Write(append(data, '\n'))
+	}
+	if table != nil {
+		table.Flush()
+		for _, result := range explained {
+			writeExplanation(out, result)
+		}
+	}
+	if failed > 0 {
+		out.Flush()
+		os.Exit(1)
+	}
+}
+
+// parseMessage runs a mail through the registry, or the forced parser only
+func parseMessage(m message, forced *parsers.ParserWrapper, explain bool, enrichment *enrichOptions) parseResult {
+	result := parseResult{Source: m.source, Events: []*events.Event{}}
+	raw, err := m.load()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	metadata := make(map[string]interface{})
+	if meta, err := os.ReadFile(m.metaPath); err == nil {
+		if err := json.Unmarshal(meta, &metadata); err != nil {
+			result.Error = "invalid metadata: " + err.Error()
+			return result
+		}
+	}
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		result.Error = "failed to parse mail: " + err.Error()
+		return result
+	}
+
+	collect := enrichment.wrap(func(event *events.Event) error {
+		result.Events = append(result.Events, event)
+		return nil
+	})
+	ctx := logging.WithAttrs(context.Background(), slog.String(logging.KeyEmail, m.source))
+	if explain {
+		var candidates []parsers.ParserWrapper
+		if forced != nil {
+			candidates = []parsers.ParserWrapper{*forced}
+		}
+		var count int
+		count, result.Explain, err = parsers.Explain(serializedEmail, metadata, candidates, collect)
+		if err == nil && count == 0 && forced == nil {
+			err = errors.New("no parser matched the email")
+		}
+	} else if forced != nil {
+		err = parsers.RunParser(ctx, *forced, serializedEmail, collect)
+	} else {
+		var count int
+		count, err = parsers.ParseEmailStreamContext(ctx, serializedEmail, metadata, collect)
+		if err == nil && count == 0 {
+			err = errors.New("no parser matched the email")
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func writeTableRows(table *tabwriter.Writer, result parseResult) {
+	for _, event := range result.Events {
+		eventType := "-"
+		if len(event.EventTypes) > 0 && event.EventTypes[0] != nil {
+			eventType = event.EventTypes[0].GetName()
+		}
+		port := "-"
+		if event.Port != 0 {
+			port = strconv.Itoa(event.Port)
+		}
+		target := event.URL
+		if target == "" {
+			target = event.Domain
+		}
+		date := "-"
+		if event.EventDate != nil {
+			date = event.EventDate.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.Source, orDash(event.Parser), eventType, orDash(event.IP), port, orDash(target), date)
+	}
+}
+
+// writeExplanation prints the parsers tried on a mail and the field sources
+// of its events below the table
+func writeExplanation(out io.Writer, result parseResult) {
+	explanation := result.Explain
+	fmt.Fprintf(out, "\n%s: chosen parser %s\n", result.Source, orDash(explanation.Chosen))
+	table := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "  PARSER\tPRIORITY\tTIER\tOUTCOME\tEVENTS\tMS\tREASON")
+	for _, attempt := range explanation.Attempts {
+		fmt.Fprintf(table, "  %s\t%d\t%s\t%s\t%d\t%.3f\t%s\n", attempt.Parser, attempt.Priority, attempt.Tier,
+			attempt.Outcome, attempt.Events, attempt.DurationMS, orDash(attempt.Reason))
+	}
+	table.Flush()
+	if len(explanation.Fields) == 0 {
+		return
+	}
+	table = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "  EVENT\tFIELD\tVALUE\tSOURCE\tLOCATION\tLINE\tMARKER")
+	for _, field := range explanation.Fields {
+		line := "-"
+		if field.Line > 0 {
+			line = strconv.Itoa(field.Line)
+		}
+		fmt.Fprintf(table, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\n", field.Event, field.Field, orDash(field.Value),
+			field.Source, orDash(field.Location), line, orDash(field.Marker))
+	}
+	table.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, "\t", " ")
+}
+
+// findMessages returns the mails of a path: a single .eml file, the messages
+// of an mbox file, the cur/ and new/ mails of a Maildir, or the .eml and
+// .mbox files below a directory
+func findMessages(path string) ([]message, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return fileMessages(path)
+	}
+
+	if isMaildir(path) {
+		var messages []message
+		for _, sub := range []string{"cur", "new"} {
+			entries, err := os.ReadDir(filepath.Join(path, sub))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			for _, entry := range entries {
+				if entry.Type().IsRegular() && !strings.HasSuffix(entry.Name(), ".meta.json") {
+					messages = append(messages, emlMessage(filepath.Join(path, sub, entry.Name())))
+				}
+			}
+		}
+		return messages, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && (strings.HasSuffix(p, ".eml") || strings.HasSuffix(p, ".mbox")) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var messages []message
+	for _, file := range files {
+		found, err := fileMessages(file)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, found...)
+	}
+	return messages, nil
+}
+
+func isMaildir(path string) bool {
+	info, err := os.Stat(filepath.Join(path, "cur"))
+	return err == nil && info.IsDir()
+}
+
+func emlMessage(path string) message {
+	return message{
+		source:   path,
+		load:     func() ([]byte, error) { return os.ReadFile(path) },
+		metaPath: path + ".meta.json",
+	}
+}
+
+// fileMessages returns the mail of an .eml file, or the messages of a file
+// in mbox format (starting with a "From " line)
+func fileMessages(path string) ([]message, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte("From ")) {
+		return []message{emlMessage(path)}, nil
+	}
+	var messages []message
+	for i, raw := range splitMbox(data) {
+		raw := raw
+		messages = append(messages, message{
+			source: fmt.Sprintf("%s:%d", path, i+1),
+			load:   func() ([]byte, error) { return raw, nil },
+		})
+	}
+	return messages, nil
+}
+
+// splitMbox splits an mbox file at its "From " separator lines and undoes
+// the >From quoting of the message lines
+func splitMbox(data []byte) [][]byte {
+	var messages [][]byte
+	var current *bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("From ")) {
+			if current != nil {
+				messages = append(messages, current.Bytes())
+			}
+			current = &bytes.Buffer{}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if unquoted := bytes.TrimLeft(line, ">"); len(unquoted) < len(line) && bytes.HasPrefix(unquoted, []byte("From ")) {
+			line = line[1:]
+		}
+		current.Write(line)
+	}
+	if current != nil {
+		messages = append(messages, current.Bytes())
+	}
+	return messages
+}
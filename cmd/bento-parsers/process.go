@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// runProcess reads serialized emails from stdin (one JSON document each, as
+// sent by Bento's subprocess processor) and writes one JSON array of events per
+// email to stdout. Events are written as the parsers emit them, so bulk reports
+// never have to be held in memory as a whole. With -format the events are
+// encoded instead: line formats (cef, leef, ecs, syslog) write one line per
+// event, report formats (xarf, arf, iodef) one document per email. With
+// -dead-letter, emails no parser handled are kept for the replay command.
+// With -mmdb, the events get the ASN and location of their IP.
+func runProcess(args []string) {
+	fs := flag.NewFlagSet("process", flag.ExitOnError)
+	var output outputOptions
+	output.register(fs)
+	var limits limitOptions
+	limits.register(fs)
+	var logs logOptions
+	logs.register(fs)
+	var deadLetters deadLetterOptions
+	deadLetters.register(fs, true)
+	var enrichment enrichOptions
+	enrichment.register(fs)
+	metricsAddr := fs.String("metrics-addr", "", "serve Prometheus metrics on this address at /metrics (empty disables)")
+	_ = fs.Parse(args)
+	logs.apply()
+	limits.apply()
+	enrichment.open(context.Background())
+	if *metricsAddr != "" {
+		pipeline := newPipelineMetrics()
+		parsers.SetObserver(pipeline)
+		serveMetrics(*metricsAddr, pipeline.registry)
+	}
+
+	encoder, err := output.encoder(output.format)
+	if err != nil {
+		fatal("Invalid output format", logging.KeyError, err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	if encoder == nil {
+		deadLetters.open(out)
+	} else {
+		deadLetters.open(nil)
+	}
+
+	decoder := json.NewDecoder(os.Stdin)
+	for {
+		var input processInput
+		if err := decoder.Decode(&input); err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			out.Flush()
+			fatal("Failed to decode email", logging.KeyError, err)
+		}
+		ctx := logging.WithAttrs(context.Background(), input.logAttrs()...)
+
+		writer := newEventWriter(out, encoder)
+		count, explanation, err := deadLetters.parse(ctx, &input.SerializedEmail, enrichment.wrap(writer.Write))
+		kept := deadLetters.keep(ctx, "process", &input.SerializedEmail, count, err, explanation, input.Kafka)
+		if !kept || !deadLetters.inband {
+			if closeErr := writer.Close(); closeErr != nil {
+				fatal("Failed to write events", logging.KeyError, closeErr)
+			}
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to process email", logging.KeyError, err)
+		} else if count == 0 {
+			slog.WarnContext(ctx, "Failed to process email", logging.KeyError, "no parser matched the email")
+		}
+		if err := out.Flush(); err != nil {
+			fatal("Failed to write events", logging.KeyError, err)
+		}
+	}
+}
+
+// processInput is an email as sent by Bento, which adds the Kafka position
+// of the message for correlation
+type processInput struct {
+	email.SerializedEmail
+	Kafka *kafkaSource `json:"kafka,omitempty"`
+}
+
+// kafkaSource is where an email was consumed from
+type kafkaSource struct {
+	Topic     string            `json:"topic"`
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// logAttrs are the correlation attributes of the email's log records
+func (in *processInput) logAttrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String(logging.KeyEmail, in.Identifier)}
+	if in.Kafka == nil {
+		return attrs
+	}
+	kafka := []any{
+		slog.String("topic", in.Kafka.Topic),
+		slog.Int("partition", int(in.Kafka.Partition)),
+		slog.Int64("offset", in.Kafka.Offset),
+	}
+	if len(in.Kafka.Tags) > 0 {
+		tags := make([]any, 0, len(in.Kafka.Tags))
+		for name, value := range in.Kafka.Tags {
+			tags = append(tags, slog.String(name, value))
+		}
+		kafka = append(kafka, slog.Group("tags", tags...))
+	}
+	return append(attrs, slog.Group(logging.KeyKafka, kafka...))
+}
+
+// eventArrayWriter writes events incrementally as a single-line JSON array
+type eventArrayWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newEventArrayWriter(w *bufio.Writer) *eventArrayWriter {
+	return &eventArrayWriter{w: w}
+}
+
+// Write appends an event to the array; it is used as the registry's emit callback
+func (a *eventArrayWriter) Write(event *events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	sep := byte(',')
+	if a.count == 0 {
+		sep = '['
+	}
+	if err := a.w.WriteByte(sep); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(data); err != nil {
+		return err
+	}
+	a.count++
+	return nil
+}
+
+// Close terminates the array and the line
+func (a *eventArrayWriter) Close() error {
+	if a.count == 0 {
+		_, err := a.w.WriteString("[]\n")
+		return err
+	}
+	_, err := a.w.WriteString("]\n")
+	return err
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/deadletter"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// outcomeParsed is the replay outcome of a letter that parses now
+const outcomeParsed = "parsed"
+
+// replayResult is the outcome of one dead letter
+type replayResult struct {
+	Letter     string               `json:"letter"`
+	Identifier string               `json:"identifier,omitempty"`
+	Was        string               `json:"was"`
+	Now        string               `json:"now"`
+	Parser     string               `json:"parser,omitempty"`
+	Events     int                  `json:"events"`
+	Error      string               `json:"error,omitempty"`
+	Explain    *parsers.Explanation `json:"explain,omitempty"`
+}
+
+// runReplay re-runs dead letters through the current registry and reports
+// which of them parse now
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	format := fs.String("format", "table", "output format: table or json")
+	parserName := fs.String("parser", "", "run only this parser (package name, e.g. spamcop) instead of the registry")
+	explain := fs.Bool("explain", false, "show the parsers tried on each letter")
+	removeParsed := fs.Bool("remove-parsed", false, "delete the letters of directories that parse now")
+	var limits limitOptions
+	limits.register(fs)
+	var logs logOptions
+	logs.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s replay [flags] <dead-letter directory|letter.json|stream file>...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	logs.apply()
+	limits.apply()
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *format != "json" && *format != "table" {
+		fmt.Fprintf(os.Stderr, "Unknown format %q (available: json, table)\n", *format)
+		os.Exit(2)
+	}
+
+	var forced *parsers.ParserWrapper
+	if *parserName != "" {
+		pw, ok := parsers.ParserByName(*parserName)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown parser %q\n", *parserName)
+			os.Exit(2)
+		}
+		forced = &pw
+	}
+
+	var entries []*deadletter.Entry
+	for _, path := range fs.Args() {
+		found, err := deadletter.Load(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		entries = append(entries, found...)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var table *tabwriter.Writer
+	if *format == "table" {
+		table = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(table, "LETTER\tWAS\tNOW\tPARSER\tEVENTS\tERROR")
+	}
+
+	parsed := 0
+	results := make([]replayResult, 0, len(entries))
+	for _, entry := range entries {
+		result := replayLetter(entry, forced, *explain)
+		if result.Now == outcomeParsed {
+			parsed++
+			if *removeParsed {
+				if err := entry.Remove(); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", entry.Name, err)
+				}
+			}
+		}
+		if table != nil {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%s\n", result.Letter, orDash(result.Was), result.Now,
+				orDash(result.Parser), result.Events, orDash(result.Error))
+		}
+		results = append(results, result)
+	}
+
+	if table == nil {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode results: %v\n", err)
+			os.Exit(1)
+		}
+		out.Write(append(data, '\n'))
+	} else {
+		table.Flush()
+		for _, result := range results {
+			if result.Explain != nil {
+				writeExplanation(out, parseResult{Source: result.Letter, Explain: result.Explain})
+			}
+		}
+	}
+	out.Flush()
+	fmt.Fprintf(os.Stderr, "%d of %d letters parse now\n", parsed, len(results))
+}
+
+// replayLetter runs a letter through the registry, or the forced parser only
+func replayLetter(entry *deadletter.Entry, forced *parsers.ParserWrapper, explain bool) replayResult {
+	letter := entry.Letter
+	result := replayResult{Letter: entry.Name, Identifier: letter.Identifier, Was: letter.Outcome}
+	raw, err := entry.Raw()
+	if err == nil && len(raw) == 0 {
+		err = fmt.Errorf("letter has no message")
+	}
+	if err != nil {
+		result.Now, result.Error = deadletter.OutcomeError, err.Error()
+		return result
+	}
+	serializedEmail, err := letter.Email(raw)
+	if err != nil {
+		result.Now, result.Error = deadletter.OutcomeError, "failed to parse mail: "+err.Error()
+		return result
+	}
+
+	ctx := logging.WithAttrs(context.Background(), slog.String(logging.KeyEmail, letter.Identifier))
+	discard := func(*events.Event) error { return nil }
+	var candidates []parsers.ParserWrapper
+	if forced != nil {
+		candidates = []parsers.ParserWrapper{*forced}
+	}
+	var explanation *parsers.Explanation
+	result.Events, explanation, err = parsers.ExplainContext(ctx, serializedEmail, nil, candidates, discard)
+	result.Parser = explanation.Chosen
+	if explain {
+		result.Explain = explanation
+	}
+	result.Now = deadletter.Outcome(result.Events, err)
+	if result.Now == "" {
+		result.Now = outcomeParsed
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/abusix/inbound-parsers/encoders"
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// flushEvery is the number of streamed events after which the response is flushed
+const flushEvery = 100
+
+// jsonOverhead bounds how much larger than the email its JSON request may be
+const jsonOverhead = 2
+
+// runServe starts the HTTP service mode
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "listen address")
+	var output outputOptions
+	output.register(fs)
+	var limits limitOptions
+	limits.register(fs)
+	var logs logOptions
+	logs.register(fs)
+	var deadLetters deadLetterOptions
+	deadLetters.register(fs, false)
+	var enrichment enrichOptions
+	enrichment.register(fs)
+	_ = fs.Parse(args)
+	logs.apply()
+	limits.apply()
+	deadLetters.open(nil)
+	enrichment.open(context.Background())
+
+	if _, err := output.encoder(output.format); err != nil {
+		fatal("Invalid output format", logging.KeyError, err)
+	}
+
+	pipeline := newPipelineMetrics()
+	parsers.SetObserver(pipeline)
+
+	mux := http.NewServeMux()
+	mux.Handle("/parse", &parseHandler{output: &output, deadLetters: &deadLetters, enrichment: &enrichment})
+	mux.Handle("/metrics", pipeline.registry)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	slog.Info("Serving parsers", "addr", *addr)
+	fatal("Server failed", logging.KeyError, server.ListenAndServe())
+}
+
+// parseHandler parses a POSTed serialized email and streams the events back,
+// by default as newline-delimited JSON. The format query parameter selects
+// another output format than the -format flag. The response status is sent
+// with the first event; if the parser fails after events were streamed, the
+// error is reported in the X-Parse-Error trailer. Report formats (xarf, arf,
+// iodef) are encoded as one document once parsing is done. Requests yielding
+// no events get a JSON error body. With explain=true the events are returned
+// as one JSON document together with the parser routing (see parsers.Explain).
+// With -dead-letter, emails no parser handled are stored for the replay
+// command. With -mmdb, the events get the ASN and location of their IP.
+type parseHandler struct {
+	output      *outputOptions
+	deadLetters *deadLetterOptions
+	enrichment  *enrichOptions
+}
+
+func (h *parseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = h.output.format
+	}
+	encoder, err := h.output.encoder(format)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body := r.Body
+	if maxBytes := parsers.CurrentLimits().MaxEmailBytes; maxBytes > 0 {
+		// The JSON encoding of the email is larger than the email itself
+		body = http.MaxBytesReader(w, r.Body, int64(maxBytes)*jsonOverhead)
+	}
+	var serializedEmail email.SerializedEmail
+	if err := json.NewDecoder(body).Decode(&serializedEmail); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "email exceeds the size limit")
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "failed to decode email: "+err.Error())
+		return
+	}
+
+	if explain, _ := strconv.ParseBool(r.URL.Query().Get("explain")); explain {
+		writeExplained(w, &serializedEmail)
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	encodeLine := func(event *events.Event) ([]byte, error) { return json.Marshal(event) }
+	var batch []*events.Event
+	switch encoder := encoder.(type) {
+	case nil:
+	case encoders.LineEncoder:
+		contentType = encoder.ContentType()
+		encodeLine = encoder.EncodeEvent
+	default:
+		encodeLine = nil
+	}
+
+	flusher, _ := w.(http.Flusher)
+	started := false
+
+	count := 0
+	emit := func(event *events.Event) error {
+		if encodeLine == nil {
+			batch = append(batch, event)
+			return r.Context().Err()
+		}
+		line, err := encodeLine(event)
+		if err != nil {
+			return err
+		}
+		if !started {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("Trailer", "X-Parse-Error, X-Event-Count")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		count++
+		if flusher != nil && count%flushEvery == 0 {
+			flusher.Flush()
+		}
+		// Stop parsing when the client went away
+		return r.Context().Err()
+	}
+
+	ctx := logging.WithAttrs(r.Context(), slog.String(logging.KeyEmail, serializedEmail.Identifier))
+	emitted, explanation, err := h.deadLetters.parse(ctx, &serializedEmail, h.enrichment.wrap(emit))
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to process email", logging.KeyError, err)
+	}
+	h.deadLetters.keep(ctx, "serve", &serializedEmail, emitted, err, explanation, nil)
+	if len(batch) > 0 {
+		writeBatch(w, encoder, batch, err)
+		return
+	}
+	if !started {
+		var tooLarge *parsers.EmailTooLargeError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, err.Error())
+		} else if err != nil {
+			writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+		} else {
+			writeJSONError(w, http.StatusUnprocessableEntity, "no parser matched the email")
+		}
+		return
+	}
+
+	if err != nil {
+		w.Header().Set("X-Parse-Error", err.Error())
+	}
+	w.Header().Set("X-Event-Count", strconv.Itoa(count))
+}
+
+// explainResponse is the response of an explained parse
+type explainResponse struct {
+	Events  []*events.Event      `json:"events"`
+	Explain *parsers.Explanation `json:"explain"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// writeExplained parses an email with tracing and writes the events and
+// the explanation as one JSON document. The status is 200 even if no parser
+// matched, since the explanation is what was asked for.
+func writeExplained(w http.ResponseWriter, serializedEmail *email.SerializedEmail) {
+	response := explainResponse{Events: []*events.Event{}}
+	_, explanation, err := parsers.Explain(serializedEmail, nil, nil, func(event *events.Event) error {
+		response.Events = append(response.Events, event)
+		return nil
+	})
+	response.Explain = explanation
+	if err != nil {
+		response.Error = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Event-Count", strconv.Itoa(len(response.Events)))
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// writeBatch writes the events of an email as one document of a report format
+func writeBatch(w http.ResponseWriter, encoder encoders.Encoder, batch []*events.Event, parseErr error) {
+	data, err := encoder.Encode(batch)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, "failed to encode events: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", encoder.ContentType())
+	if parseErr != nil {
+		w.Header().Set("X-Parse-Error", parseErr.Error())
+	}
+	w.Header().Set("X-Event-Count", strconv.Itoa(len(batch)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
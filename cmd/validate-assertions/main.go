@@ -1,183 +1,159 @@
+// Command validate-assertions runs the sample mails through the Go parsers,
+// compares the events with the Python output recorded in the
+// .assertions.json files and writes the per-parser parity scoreboard.
 package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-)
-
-// PythonAssertion represents the Python-generated assertion file structure
-type PythonAssertion struct {
-	Metadata     map[string]interface{} `json:"metadata"`
-	ParserOutput ParserOutput           `json:"parser_output"`
-}
 
-// ParserOutput contains the parser results
-type ParserOutput struct {
-	Parser   string      `json:"parser"`
-	Reporter string      `json:"reporter"`
-	Rejected bool        `json:"rejected"`
-	Events   []EventData `json:"events"`
-}
-
-// EventData represents a parsed event
-type EventData struct {
-	Date          *string                `json:"date"`
-	Type          *string                `json:"type"`
-	Parser        string                 `json:"parser"`
-	ReportID      string                 `json:"report_id"`
-	ReceivedDate  string                 `json:"received_date"`
-	SendDate      string                 `json:"send_date"`
-	SenderEmail   string                 `json:"sender_email"`
-	RecipientEmail string                `json:"recipient_email"`
-	Resources     map[string]interface{} `json:"resources"`
-	EventTypes    []interface{}          `json:"event_types"`
-}
-
-// ComparisonResult tracks differences between Python and Go output
-type ComparisonResult struct {
-	File             string
-	Match            bool
-	ParserMismatch   bool
-	PythonParser     string
-	GoParser         string
-	EventCountDiff   bool
-	PythonEventCount int
-	GoEventCount     int
-	ResourceDiffs    []string
-}
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/parity"
+)
 
 func main() {
-	sampleDir := "testdata/sample_mails"
+	sampleDir := flag.String("samples", "testdata/sample_mails", "directory of the sample mails and their .assertions.json files")
+	allowlistPath := flag.String("allowlist", "testdata/parity/allowlist.json", "accepted deviations per parser")
+	markdownPath := flag.String("markdown", "PARSER_PARITY.md", "Markdown scoreboard output (empty to skip)")
+	jsonPath := flag.String("json", "", "JSON scoreboard output with all sample results (empty to skip)")
+	parserFilter := flag.String("parser", "", "only compare samples of this Python parser")
+	verbose := flag.Bool("v", false, "print the deviations of mismatching samples")
+	strict := flag.Bool("strict", false, "exit with status 1 if any sample mismatches")
+	flag.Parse()
+
+	allowlist := parity.Allowlist{}
+	if *allowlistPath != "" {
+		var err error
+		if allowlist, err = parity.LoadAllowlist(*allowlistPath); err != nil {
+			fmt.Printf("Error reading allowlist: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	// Get all Python assertion files
-	pythonFiles, err := filepath.Glob(filepath.Join(sampleDir, "*.eml.assertions.json"))
+	pythonFiles, err := filepath.Glob(filepath.Join(*sampleDir, "*.eml.assertions.json"))
 	if err != nil {
 		fmt.Printf("Error reading sample directory: %v\n", err)
 		os.Exit(1)
 	}
-
 	sort.Strings(pythonFiles)
-	fmt.Printf("Found %d Python assertion files\n\n", len(pythonFiles))
-
-	matches := 0
-	mismatches := 0
-	errors := 0
-	parserDiffs := 0
-	eventCountDiffs := 0
-
-	results := []ComparisonResult{}
+	fmt.Printf("Found %d Python assertion files\n", len(pythonFiles))
 
+	var results []*parity.Result
+	// orphaned counts Python outputs whose sample mail is gone
+	orphaned := 0
 	for idx, pythonPath := range pythonFiles {
-		if (idx+1)%100 == 0 {
-			fmt.Printf("Progress: %d/%d - Matches: %d, Mismatches: %d, Errors: %d\n",
-				idx+1, len(pythonFiles), matches, mismatches, errors)
+		if (idx+1)%500 == 0 {
+			fmt.Printf("Progress: %d/%d\n", idx+1, len(pythonFiles))
 		}
 
-		// Load Python assertion
-		pythonData, err := loadPythonAssertion(pythonPath)
+		python, err := parity.LoadPython(pythonPath)
 		if err != nil {
 			fmt.Printf("ERROR loading Python assertion %s: %v\n", filepath.Base(pythonPath), err)
-			errors++
+			continue
+		}
+		if *parserFilter != "" && python.Parser() != *parserFilter {
 			continue
 		}
 
-		// Get corresponding .eml file and run through Go parser
 		emlPath := strings.TrimSuffix(pythonPath, ".assertions.json")
-
-		result := ComparisonResult{
-			File:             filepath.Base(emlPath),
-			PythonParser:     pythonData.ParserOutput.Parser,
-			PythonEventCount: len(pythonData.ParserOutput.Events),
+		if _, err := os.Stat(emlPath); errors.Is(err, fs.ErrNotExist) {
+			orphaned++
+			continue
+		}
+		sample := strings.TrimSuffix(filepath.Base(emlPath), ".eml")
+		goEvents, err := parseSample(emlPath)
+		var result *parity.Result
+		var classifiedErr *parsers.ClassifiedError
+		switch {
+		case errors.As(err, &classifiedErr):
+			result = parity.CompareDeclined(sample, python, classifiedErr.Parser, allowlist)
+		case err != nil:
+			result = &parity.Result{Sample: sample, Parser: python.Parser(), PythonEvents: len(python.ParserOutput.Events), Error: err.Error()}
+		default:
+			result = parity.Compare(sample, python, parity.FromGo(goEvents), allowlist)
 		}
-
-		// For now, just track the Python expected values
-		// TODO: Run Go parser and compare
-		result.Match = false
-		result.ParserMismatch = true
-
 		results = append(results, result)
-		mismatches++
 
-		if result.ParserMismatch {
-			parserDiffs++
+		if *verbose && result.Status() == parity.StatusMismatch {
+			fmt.Printf("%s (%s): %d deviations\n", sample, result.Parser, result.DeviationCount)
+			for _, deviation := range result.Deviations {
+				fmt.Printf("  event %d %s: python %q, go %q\n", deviation.Event, deviation.Field, deviation.Python, deviation.Go)
+			}
 		}
 	}
 
-	fmt.Printf("\n=== Validation Results ===\n")
-	fmt.Printf("Total files: %d\n", len(pythonFiles))
-	fmt.Printf("Exact matches: %d\n", matches)
-	fmt.Printf("Mismatches: %d\n", mismatches)
-	fmt.Printf("Parser differences: %d\n", parserDiffs)
-	fmt.Printf("Event count differences: %d\n", eventCountDiffs)
-	fmt.Printf("Errors: %d\n", errors)
-
-	// Generate report of parser implementations needed
-	parserCounts := make(map[string]int)
-	for _, result := range results {
-		if result.PythonParser != "" {
-			parserCounts[result.PythonParser]++
-		}
+	board := parity.NewScoreboard(results)
+	total := board.Total
+	fmt.Printf("\n=== Parity Results ===\n")
+	fmt.Printf("Samples: %d\n", total.Samples)
+	fmt.Printf("Match: %d\n", total.Match)
+	fmt.Printf("Accepted: %d\n", total.Accepted)
+	fmt.Printf("Mismatch: %d\n", total.Mismatch)
+	fmt.Printf("Errors: %d\n", total.Errors)
+	fmt.Printf("Parity: %.1f%%\n", total.Parity())
+	if orphaned > 0 {
+		fmt.Printf("Skipped %d Python outputs without sample mail\n", orphaned)
 	}
 
-	fmt.Printf("\n=== Parser Implementation Status ===\n")
-	fmt.Printf("Unique parsers: %d\n\n", len(parserCounts))
-
-	// Sort parsers by count
-	type parserCount struct {
-		parser string
-		count  int
-	}
-	var sortedParsers []parserCount
-	for parser, count := range parserCounts {
-		sortedParsers = append(sortedParsers, parserCount{parser, count})
+	if *markdownPath != "" {
+		if err := os.WriteFile(*markdownPath, []byte(board.Markdown()), 0o644); err != nil {
+			fmt.Printf("ERROR writing scoreboard: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Scoreboard written to: %s\n", *markdownPath)
 	}
-	sort.Slice(sortedParsers, func(i, j int) bool {
-		return sortedParsers[i].count > sortedParsers[j].count
-	})
-
-	fmt.Printf("Top 20 parsers by email count:\n")
-	for i, pc := range sortedParsers {
-		if i >= 20 {
-			break
+	if *jsonPath != "" {
+		data, err := json.MarshalIndent(board, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*jsonPath, append(data, '\n'), 0o644)
 		}
-		fmt.Printf("  %3d emails: %s\n", pc.count, pc.parser)
+		if err != nil {
+			fmt.Printf("ERROR writing JSON report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("JSON report written to: %s\n", *jsonPath)
 	}
 
-	// Write detailed report
-	reportPath := ".claude/VALIDATION_REPORT.json"
-	if err := writeJSONReport(reportPath, results); err != nil {
-		fmt.Printf("ERROR writing report: %v\n", err)
-	} else {
-		fmt.Printf("\nDetailed report written to: %s\n", reportPath)
+	if *strict && total.Mismatch+total.Errors > 0 {
+		os.Exit(1)
 	}
 }
 
-func loadPythonAssertion(path string) (*PythonAssertion, error) {
-	data, err := os.ReadFile(path)
+// parseSample runs a sample mail, with its .meta.json metadata if present,
+// through the parser registry
+func parseSample(path string) ([]*events.Event, error) {
+	raw, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	var assertion PythonAssertion
-	if err := json.Unmarshal(data, &assertion); err != nil {
-		return nil, err
+	metadata := make(map[string]interface{})
+	if meta, err := os.ReadFile(path + ".meta.json"); err == nil {
+		if err := json.Unmarshal(meta, &metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
 	}
-
-	return &assertion, nil
-}
-
-func writeJSONReport(path string, results []ComparisonResult) error {
-	f, err := os.Create(path)
+	serializedEmail, err := email.Parse(raw)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer f.Close()
 
-	encoder := json.NewEncoder(f)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(results)
+	var evs []*events.Event
+	_, err = parsers.ParseEmailStream(serializedEmail, metadata, func(event *events.Event) error {
+		evs = append(evs, event)
+		return nil
+	})
+	// Events emitted before a parser failure are still compared
+	if err != nil && len(evs) == 0 {
+		return nil, err
+	}
+	return evs, nil
 }
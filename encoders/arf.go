@@ -0,0 +1,119 @@
+package encoders
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// ARF encodes events as RFC 5965 feedback reports, one mail per event. The
+// format returns a message, so only events carrying the original message as
+// events.Sample can be encoded.
+type ARF struct {
+	Reporter Reporter
+	// Now is the clock used for Message-IDs and missing event dates
+	Now func() time.Time
+}
+
+func NewARF(reporter Reporter) *ARF {
+	return &ARF{Reporter: reporter, Now: time.Now}
+}
+
+// feedbackTypes maps event types to RFC 5965 feedback types; all others are "other"
+var feedbackTypes = map[string]string{
+	"spam":     "abuse",
+	"phishing": "fraud",
+	"fraud":    "fraud",
+	"malware":  "virus",
+}
+
+func (a *ARF) ContentType() string {
+	return "message/rfc822"
+}
+
+// Encode returns one mail for a single event and an mbox for a batch
+func (a *ARF) Encode(evs []*events.Event) ([]byte, error) {
+	now := a.Now()
+	var mails [][]byte
+	for i, event := range evs {
+		m, err := a.encodeEvent(event, now, i)
+		if err != nil {
+			return nil, err
+		}
+		mails = append(mails, m)
+	}
+	if len(mails) == 0 {
+		return nil, fmt.Errorf("no events to encode")
+	}
+	return mbox(mails, now), nil
+}
+
+func (a *ARF) encodeEvent(event *events.Event, now time.Time, index int) ([]byte, error) {
+	sample, ok := findDetail[*events.Sample](event)
+	if !ok {
+		return nil, fmt.Errorf("ARF needs the original message as sample")
+	}
+	original, err := samplePayload(sample)
+	if err != nil {
+		return nil, err
+	}
+
+	typeName := eventTypeName(event)
+	feedbackType, ok := feedbackTypes[typeName]
+	if !ok {
+		feedbackType = "other"
+	}
+	date := eventDate(event, now)
+
+	var report strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			report.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+	field("Feedback-Type", feedbackType)
+	field("User-Agent", UserAgent)
+	field("Version", "1")
+	field("Arrival-Date", date.Format(time.RFC1123Z))
+	field("Source-IP", event.IP)
+	if event.Port != 0 {
+		field("Source-Port", fmt.Sprint(event.Port))
+	}
+	if event.URL != "" {
+		field("Reported-URI", event.URL)
+	}
+	field("Reported-Domain", event.Domain)
+	field("Reporting-MTA", "dns; "+mailDomain(a.Reporter.Email))
+
+	// Samples stored as headers only are text/rfc822-headers (RFC 5965 section 2 (d))
+	originalType := "message/rfc822"
+	if strings.EqualFold(sample.ContentType, "text/rfc822-headers") {
+		originalType = sample.ContentType
+	}
+
+	source := eventSource(event)
+	m := &mail{
+		reporter:    a.Reporter,
+		date:        now,
+		id:          mailID(now, index),
+		subject:     fmt.Sprintf("Abuse report for %s", source),
+		contentType: "multipart/report; report-type=feedback-report",
+		parts: []mailPart{{
+			header: textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}},
+			body:   []byte(fmt.Sprintf("This is an abuse report (%s) for a message received from %s on %s.\r\n", typeName, source, date.Format(time.RFC1123Z))),
+		}, {
+			header: textproto.MIMEHeader{"Content-Type": {"message/feedback-report"}},
+			body:   []byte(report.String()),
+		}, {
+			header: textproto.MIMEHeader{
+				"Content-Type":        {originalType},
+				"Content-Disposition": {"inline"},
+			},
+			body: original,
+		}},
+	}
+	return m.bytes()
+}
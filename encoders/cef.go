@@ -0,0 +1,99 @@
+package encoders
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// CEF encodes events as ArcSight Common Event Format lines, one per event.
+// Fields without a CEF key (ASN, location, parser, malware) go to the custom
+// string extensions cs1-cs5 with their labels.
+type CEF struct{}
+
+func NewCEF() *CEF {
+	return &CEF{}
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+func (c *CEF) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// Encode returns one CEF line per event
+func (c *CEF) Encode(evs []*events.Event) ([]byte, error) {
+	return encodeLines(c, evs)
+}
+
+// EncodeEvent returns the CEF line of an event without line terminator
+func (c *CEF) EncodeEvent(event *events.Event) ([]byte, error) {
+	e := newSIEMEvent(event)
+
+	var ext []string
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtensionEscaper.Replace(value))
+		}
+	}
+	addPort := func(key string, port int) {
+		if port != 0 {
+			add(key, strconv.Itoa(port))
+		}
+	}
+
+	if ip := net.ParseIP(e.ip); ip != nil && ip.To4() == nil {
+		add("c6a2", e.ip)
+		add("c6a2Label", "Source IPv6 Address")
+	} else {
+		add("src", e.ip)
+	}
+	addPort("spt", e.port)
+	add("request", e.url)
+	add("shost", e.domain)
+	if ip := net.ParseIP(e.targetIP); ip != nil && ip.To4() == nil {
+		add("c6a3", e.targetIP)
+		add("c6a3Label", "Destination IPv6 Address")
+	} else {
+		add("dst", e.targetIP)
+	}
+	addPort("dpt", e.targetPort)
+	add("proto", strings.ToUpper(e.protocol))
+	if e.date != nil {
+		add("rt", strconv.FormatInt(e.date.UnixMilli(), 10))
+	}
+	add("cat", e.typeName)
+	add("externalId", e.reportID)
+	custom := func(n int, label, value string) {
+		if value != "" {
+			add(fmt.Sprintf("cs%d", n), value)
+			add(fmt.Sprintf("cs%dLabel", n), label)
+		}
+	}
+	custom(1, "asn", e.asn)
+	custom(2, "asName", e.asName)
+	custom(3, "country", e.country)
+	custom(4, "parser", e.parser)
+	custom(5, "city", e.city)
+	custom(6, "malware", e.malware)
+
+	header := []string{
+		"CEF:0",
+		productVendor,
+		UserAgent,
+		productVersion,
+		e.typeName,
+		fmt.Sprintf("%s from %s", e.typeName, eventSource(event)),
+		strconv.Itoa(e.severityLevel()),
+	}
+	for i := range header[1:] {
+		header[i+1] = cefHeaderEscaper.Replace(header[i+1])
+	}
+	return []byte(strings.Join(header, "|") + "|" + strings.Join(ext, " ")), nil
+}
@@ -0,0 +1,183 @@
+package encoders
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// ECS encodes events as Elastic Common Schema documents, one JSON document
+// per line
+type ECS struct {
+	// Now is the clock used for events without date
+	Now func() time.Time
+}
+
+func NewECS() *ECS {
+	return &ECS{Now: time.Now}
+}
+
+// ecsVersion is the ECS version the documents follow
+const ecsVersion = "8.11.0"
+
+// ecsCategories maps event types to ECS event.category values; all others are "threat"
+var ecsCategories = map[string][]string{
+	"spam":                {"email"},
+	"malware":             {"malware"},
+	"malware_hosting":     {"malware"},
+	"bot":                 {"malware"},
+	"login_attack":        {"authentication"},
+	"auth_failure":        {"authentication"},
+	"compromised_account": {"authentication"},
+	"phishing":            {"web"},
+	"web_hack":            {"web", "intrusion_detection"},
+	"web_crawler":         {"web"},
+	"port_scan":           {"network", "intrusion_detection"},
+	"ddos":                {"network", "intrusion_detection"},
+}
+
+type ecsDocument struct {
+	Timestamp   string          `json:"@timestamp"`
+	Event       ecsEvent        `json:"event"`
+	Source      *ecsEndpoint    `json:"source,omitempty"`
+	Destination *ecsEndpoint    `json:"destination,omitempty"`
+	URL         *ecsURL         `json:"url,omitempty"`
+	Network     *ecsNetwork     `json:"network,omitempty"`
+	Threat      *ecsThreat      `json:"threat,omitempty"`
+	ECS         ecsVersionField `json:"ecs"`
+}
+
+type ecsEvent struct {
+	Kind     string   `json:"kind"`
+	Category []string `json:"category"`
+	Type     []string `json:"type"`
+	Action   string   `json:"action"`
+	Dataset  string   `json:"dataset"`
+	Provider string   `json:"provider,omitempty"`
+	ID       string   `json:"id,omitempty"`
+	Severity int      `json:"severity,omitempty"`
+}
+
+type ecsEndpoint struct {
+	Address string  `json:"address,omitempty"`
+	IP      string  `json:"ip,omitempty"`
+	Port    int     `json:"port,omitempty"`
+	Domain  string  `json:"domain,omitempty"`
+	AS      *ecsAS  `json:"as,omitempty"`
+	Geo     *ecsGeo `json:"geo,omitempty"`
+}
+
+type ecsAS struct {
+	Number       int                `json:"number,omitempty"`
+	Organization *ecsASOrganization `json:"organization,omitempty"`
+}
+
+type ecsASOrganization struct {
+	Name string `json:"name"`
+}
+
+type ecsGeo struct {
+	CountryISOCode string `json:"country_iso_code,omitempty"`
+	CountryName    string `json:"country_name,omitempty"`
+	CityName       string `json:"city_name,omitempty"`
+}
+
+type ecsURL struct {
+	Full   string `json:"full,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+type ecsNetwork struct {
+	Transport string `json:"transport"`
+}
+
+type ecsThreat struct {
+	Software ecsSoftware `json:"software"`
+}
+
+type ecsSoftware struct {
+	Name string `json:"name"`
+}
+
+type ecsVersionField struct {
+	Version string `json:"version"`
+}
+
+func (x *ECS) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// Encode returns one ECS document per line
+func (x *ECS) Encode(evs []*events.Event) ([]byte, error) {
+	return encodeLines(x, evs)
+}
+
+// EncodeEvent returns the ECS document of an event without line terminator
+func (x *ECS) EncodeEvent(event *events.Event) ([]byte, error) {
+	e := newSIEMEvent(event)
+
+	category, ok := ecsCategories[e.typeName]
+	if !ok {
+		category = []string{"threat"}
+	}
+	doc := ecsDocument{
+		Timestamp: eventDate(event, x.Now()).Format(time.RFC3339Nano),
+		Event: ecsEvent{
+			Kind:     "alert",
+			Category: category,
+			Type:     []string{"indicator"},
+			Action:   e.typeName,
+			Dataset:  "inbound_parsers.events",
+			Provider: e.parser,
+			ID:       e.reportID,
+		},
+		ECS: ecsVersionField{Version: ecsVersion},
+	}
+	if e.severity != "" {
+		doc.Event.Severity = e.severityLevel()
+	}
+
+	source := &ecsEndpoint{Address: e.address, IP: e.ip, Port: e.port}
+	if e.url == "" {
+		source.Domain = e.domain
+	}
+	if e.asNumber != 0 || e.asName != "" {
+		source.AS = &ecsAS{Number: e.asNumber}
+		if e.asName != "" {
+			source.AS.Organization = &ecsASOrganization{Name: e.asName}
+		}
+	}
+	if e.country != "" || e.city != "" {
+		source.Geo = &ecsGeo{CityName: e.city}
+		if len(e.country) == 2 {
+			source.Geo.CountryISOCode = e.country
+		} else {
+			source.Geo.CountryName = e.country
+		}
+	}
+	if *source != (ecsEndpoint{}) {
+		doc.Source = source
+	}
+	if e.targetIP != "" || e.targetPort != 0 {
+		doc.Destination = &ecsEndpoint{IP: e.targetIP, Port: e.targetPort}
+	}
+	if e.url != "" {
+		doc.URL = &ecsURL{Full: e.url, Domain: e.domain}
+	}
+	if e.protocol != "" {
+		doc.Network = &ecsNetwork{Transport: e.protocol}
+	}
+	if e.malware != "" {
+		doc.Threat = &ecsThreat{Software: ecsSoftware{Name: e.malware}}
+	}
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	// URLs are kept readable; the documents are not embedded in HTML
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
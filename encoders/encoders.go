@@ -0,0 +1,244 @@
+// Package encoders serializes events into the report formats we forward to
+// downstream customers and CERTs. They are the counterparts of the format
+// parsers: an encoded event parses back into an equivalent event.
+package encoders
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// Encoder serializes a batch of events into one document
+type Encoder interface {
+	Encode(evs []*events.Event) ([]byte, error)
+	// ContentType is the media type of the encoded document
+	ContentType() string
+}
+
+// LineEncoder encodes each event as one line, so output can be streamed
+// while the parsers emit events; Encode joins the lines of a batch
+type LineEncoder interface {
+	Encoder
+	EncodeEvent(event *events.Event) ([]byte, error)
+}
+
+// Reporter identifies the sender of the encoded reports
+type Reporter struct {
+	Name  string
+	Email string
+	// To is the recipient of report mails
+	To string
+}
+
+// UserAgent is announced in the reports
+const UserAgent = "inbound-parsers"
+
+// constructors are the encoders selectable by name
+var constructors = map[string]func(Reporter) Encoder{
+	"xarf":   func(r Reporter) Encoder { return NewXARF(r) },
+	"arf":    func(r Reporter) Encoder { return NewARF(r) },
+	"iodef":  func(r Reporter) Encoder { return NewIODEF(r) },
+	"cef":    func(r Reporter) Encoder { return NewCEF() },
+	"leef":   func(r Reporter) Encoder { return NewLEEF() },
+	"ecs":    func(r Reporter) Encoder { return NewECS() },
+	"syslog": func(r Reporter) Encoder { return NewSyslog() },
+}
+
+// New returns the encoder of a format name
+func New(name string, reporter Reporter) (Encoder, error) {
+	constructor, ok := constructors[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return constructor(reporter), nil
+}
+
+// Names returns the format names accepted by New
+func Names() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// encodeLines encodes a batch with a line encoder, one line per event
+func encodeLines(encoder LineEncoder, evs []*events.Event) ([]byte, error) {
+	var out bytes.Buffer
+	for _, event := range evs {
+		line, err := encoder.EncodeEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// eventSeverity returns the severity (low, medium, high) reported with the
+// case information of an event, or ""
+func eventSeverity(event *events.Event) string {
+	if caseInfo, ok := findDetail[*events.ExternalCaseInformation](event); ok {
+		return strings.ToLower(caseInfo.Severity)
+	}
+	return ""
+}
+
+// asnNumber returns the AS number of "AS64496" or "64496", or 0
+func asnNumber(asn string) int {
+	asn = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(asn)), "AS")
+	number, err := strconv.Atoi(asn)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// eventTypeName returns the name of the first event type
+func eventTypeName(event *events.Event) string {
+	if len(event.EventTypes) == 0 || event.EventTypes[0] == nil {
+		return "unknown"
+	}
+	return event.EventTypes[0].GetName()
+}
+
+// eventSource returns what an event is about: its IP, else its URL, else its domain
+func eventSource(event *events.Event) string {
+	switch {
+	case event.IP != "":
+		return event.IP
+	case event.URL != "":
+		return event.URL
+	}
+	return event.Domain
+}
+
+// eventDate returns the event date, falling back to now
+func eventDate(event *events.Event, now time.Time) time.Time {
+	if event.EventDate != nil {
+		return event.EventDate.UTC()
+	}
+	return now.UTC()
+}
+
+// findDetail returns the first event detail of type T
+func findDetail[T events.EventDetail](event *events.Event) (T, bool) {
+	for _, detail := range event.EventDetails {
+		if typed, ok := detail.(T); ok {
+			return typed, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// mailPart is a body part of an encoded mail
+type mailPart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// mail is an encoded report mail
+type mail struct {
+	reporter Reporter
+	date     time.Time
+	// id makes Message-ID and boundary unique within a batch
+	id          string
+	subject     string
+	headers     [][2]string
+	contentType string
+	parts       []mailPart
+}
+
+// bytes renders the mail with a multipart body; contentType gets the boundary parameter
+func (m *mail) bytes() ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary("=_" + m.id); err != nil {
+		return nil, err
+	}
+	for _, part := range m.parts {
+		w, err := writer.CreatePart(part.header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	header := func(name, value string) {
+		out.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", formatAddress(m.reporter.Name, m.reporter.Email))
+	if m.reporter.To != "" {
+		header("To", m.reporter.To)
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", m.subject))
+	header("Date", m.date.Format(time.RFC1123Z))
+	header("Message-ID", "<"+m.id+"@"+mailDomain(m.reporter.Email)+">")
+	header("MIME-Version", "1.0")
+	for _, h := range m.headers {
+		header(h[0], h[1])
+	}
+	header("Content-Type", fmt.Sprintf("%s; boundary=%q", m.contentType, writer.Boundary()))
+	out.WriteString("\r\n")
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+func formatAddress(name, address string) string {
+	if name == "" {
+		return address
+	}
+	return mime.QEncoding.Encode("utf-8", name) + " <" + address + ">"
+}
+
+func mailDomain(address string) string {
+	if _, domain, ok := strings.Cut(address, "@"); ok && domain != "" {
+		return domain
+	}
+	return "localhost"
+}
+
+// mailID returns a Message-ID local part unique per batch position
+func mailID(now time.Time, index int) string {
+	return fmt.Sprintf("%d.%d.%s", now.Unix(), index+1, UserAgent)
+}
+
+// mbox concatenates mails in mbox format; a single mail is returned as is
+func mbox(mails [][]byte, now time.Time) []byte {
+	if len(mails) == 1 {
+		return mails[0]
+	}
+	var out bytes.Buffer
+	for _, m := range mails {
+		out.WriteString("From MAILER-DAEMON " + now.UTC().Format(time.ANSIC) + "\n")
+		for _, line := range strings.SplitAfter(string(m), "\n") {
+			if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+				out.WriteString(">")
+			}
+			out.WriteString(line)
+		}
+		if !bytes.HasSuffix(m, []byte("\n")) {
+			out.WriteString("\n")
+		}
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,191 @@
+package encoders
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/iodef"
+	"github.com/abusix/inbound-parsers/parsers/marf"
+	"github.com/abusix/inbound-parsers/parsers/xarf"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+var testReporter = Reporter{Name: "Example Abuse Desk", Email: "abuse@reporter.example", To: "abuse@isp.example"}
+
+func testNow() time.Time {
+	return time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)
+}
+
+func testEvent(ip string, eventType events.EventType) *events.Event {
+	date := time.Date(2025, 10, 18, 10, 30, 0, 0, time.UTC)
+	event := events.NewEvent("test")
+	event.IP = ip
+	event.EventDate = &date
+	event.EventTypes = []events.EventType{eventType}
+	return event
+}
+
+const sampleMessage = "From: spammer@spam.example\r\nTo: user@isp.example\r\nSubject: Cheap pills\r\n\r\nVisit http://spam.example/buy now\r\n"
+
+func TestXARF_RoundTrip(t *testing.T) {
+	event := testEvent("192.0.2.10", events.NewLoginAttack("", ""))
+	event.Port = 22
+	event.AddEventDetail(&events.Target{IP: "198.51.100.1"})
+	event.AddEventDetail(&events.Sample{ContentType: "text/plain", Encoding: "base64", Description: "log", Payload: base64.StdEncoding.EncodeToString([]byte("sshd: Failed password for root"))})
+
+	encoder := NewXARF(testReporter)
+	encoder.Now = testNow
+	data, err := encoder.Encode([]*events.Event{event})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	serializedEmail, err := email.Parse(data)
+	if err != nil {
+		t.Fatalf("Encoded mail does not parse: %v", err)
+	}
+	result, err := xarf.NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("xarf parser rejected encoded mail: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(result))
+	}
+	parsed := result[0]
+	if parsed.IP != "192.0.2.10" || parsed.Port != 22 || parsed.EventTypes[0].GetName() != "login_attack" {
+		t.Errorf("Unexpected event: %+v", parsed)
+	}
+	if parsed.EventDate == nil || !parsed.EventDate.Equal(*event.EventDate) {
+		t.Errorf("Expected event date %v, got %v", event.EventDate, parsed.EventDate)
+	}
+	if parsed.Headers["destination"] != "198.51.100.1" || parsed.Headers["reported-from"] != "abuse@reporter.example" {
+		t.Errorf("Unexpected report fields: %v", parsed.Headers)
+	}
+}
+
+func TestXARF_BatchIsMbox(t *testing.T) {
+	encoder := NewXARF(testReporter)
+	encoder.Now = testNow
+	data, err := encoder.Encode([]*events.Event{
+		testEvent("192.0.2.10", events.NewSpam()),
+		testEvent("192.0.2.11", events.NewMalware("mirai")),
+	})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if count := strings.Count(string(data), "\nFrom MAILER-DAEMON ") + 1; !strings.HasPrefix(string(data), "From MAILER-DAEMON ") || count != 2 {
+		t.Errorf("Expected 2 mbox messages, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "Malware: mirai") {
+		t.Errorf("Expected malware name in report")
+	}
+}
+
+func TestARF_RoundTrip(t *testing.T) {
+	event := testEvent("192.0.2.20", events.NewSpam())
+	event.AddEventDetail(&events.Sample{ContentType: "message/rfc822", Encoding: "base64", Description: "Complete original email", Payload: base64.StdEncoding.EncodeToString([]byte(sampleMessage))})
+
+	encoder := NewARF(testReporter)
+	encoder.Now = testNow
+	data, err := encoder.Encode([]*events.Event{event})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	serializedEmail, err := email.Parse(data)
+	if err != nil {
+		t.Fatalf("Encoded mail does not parse: %v", err)
+	}
+	result, err := marf.NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("marf parser rejected encoded mail: %v", err)
+	}
+	parsed := result[0]
+	if parsed.IP != "192.0.2.20" || parsed.EventTypes[0].GetName() != "spam" {
+		t.Errorf("Unexpected event: %+v", parsed)
+	}
+	if parsed.URL != "http://spam.example/buy" {
+		t.Errorf("Expected URL from the original message, got %q", parsed.URL)
+	}
+	if parsed.EventDate == nil || !parsed.EventDate.Equal(*event.EventDate) {
+		t.Errorf("Expected arrival date %v, got %v", event.EventDate, parsed.EventDate)
+	}
+}
+
+func TestARF_RequiresSample(t *testing.T) {
+	if _, err := NewARF(testReporter).Encode([]*events.Event{testEvent("192.0.2.20", events.NewSpam())}); err == nil {
+		t.Error("Expected error for event without original message")
+	}
+}
+
+func TestIODEF_RoundTrip(t *testing.T) {
+	scan := testEvent("192.0.2.30", events.NewPortScan())
+	scan.Port = 445
+	scan.AddEventDetail(&events.TransportProtocol{Protocol: "tcp"})
+	scan.AddEventDetail(&events.ExternalCaseInformation{CaseID: "CERT-2025-0042", Status: "reporting", Severity: "high"})
+
+	bot := testEvent("2001:db8::30", events.NewBot(""))
+	phish := testEvent("", events.NewPhishing())
+	phish.URL = "http://phish.example/login"
+
+	encoder := NewIODEF(testReporter)
+	encoder.Now = testNow
+	data, err := encoder.Encode([]*events.Event{scan, bot, phish})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	result, err := iodef.NewParser().Parse(&email.SerializedEmail{
+		Headers: map[string][]string{"content-type": {"application/xml"}},
+		Body:    string(data),
+	})
+	if err != nil {
+		t.Fatalf("iodef parser rejected encoded document: %v\n%s", err, data)
+	}
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(result))
+	}
+
+	if result[0].IP != "192.0.2.30" || result[0].Port != 445 || result[0].EventTypes[0].GetName() != "port_scan" {
+		t.Errorf("Unexpected scan event: %+v", result[0])
+	}
+	var caseInfo *events.ExternalCaseInformation
+	var protocol *events.TransportProtocol
+	for _, detail := range result[0].EventDetails {
+		switch d := detail.(type) {
+		case *events.ExternalCaseInformation:
+			caseInfo = d
+		case *events.TransportProtocol:
+			protocol = d
+		}
+	}
+	if caseInfo == nil || caseInfo.CaseID != "CERT-2025-0042" || caseInfo.Severity != "high" {
+		t.Errorf("Unexpected case information: %+v", caseInfo)
+	}
+	if protocol == nil || protocol.Protocol != "tcp" {
+		t.Errorf("Expected tcp, got %+v", protocol)
+	}
+	if result[0].EventDate == nil || !result[0].EventDate.Equal(*scan.EventDate) {
+		t.Errorf("Expected detect time %v, got %v", scan.EventDate, result[0].EventDate)
+	}
+	if result[1].IP != "2001:db8::30" || result[1].EventTypes[0].GetName() != "bot" {
+		t.Errorf("Unexpected bot event: %+v", result[1])
+	}
+	if result[2].URL != "http://phish.example/login" || result[2].EventTypes[0].GetName() != "phishing" {
+		t.Errorf("Unexpected phishing event: %+v", result[2])
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		if _, err := New(name, testReporter); err != nil {
+			t.Errorf("New(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := New("pdf", testReporter); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,239 @@
+package encoders
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// IODEF encodes a batch of events as one IODEF 2 (RFC 7970) document with
+// one incident per event
+type IODEF struct {
+	Reporter Reporter
+	// Now is the clock used for GenerationTime and incident ids
+	Now func() time.Time
+}
+
+func NewIODEF(reporter Reporter) *IODEF {
+	return &IODEF{Reporter: reporter, Now: time.Now}
+}
+
+// systemImpacts maps event types to the SystemImpact types of RFC 7970; all
+// others are sent as ext-value with the event type name as ext-type
+var systemImpacts = map[string]string{
+	"ddos":                "availability-service",
+	"compromised_server":  "takeover-system",
+	"compromised_account": "takeover-account",
+	"port_scan":           "recon",
+	"phishing":            "social-engineering",
+	"rogue_dns":           "traffic-redirection",
+}
+
+// iodefPurposes are the Incident@purpose values; the iodef parser keeps the
+// purpose as case status
+var iodefPurposes = map[string]bool{"traceback": true, "mitigation": true, "reporting": true, "watch": true, "other": true}
+
+// ipProtocolNumbers maps transport protocols to IANA protocol numbers
+var ipProtocolNumbers = map[string]string{
+	"icmp": "1",
+	"tcp":  "6",
+	"udp":  "17",
+}
+
+type iodefDocument struct {
+	XMLName   xml.Name        `xml:"IODEF-Document"`
+	Version   string          `xml:"version,attr"`
+	Lang      string          `xml:"xml:lang,attr"`
+	Xmlns     string          `xml:"xmlns,attr"`
+	Incidents []iodefIncident `xml:"Incident"`
+}
+
+type iodefIncident struct {
+	Purpose        string          `xml:"purpose,attr"`
+	IncidentID     iodefIncidentID `xml:"IncidentID"`
+	DetectTime     string          `xml:"DetectTime,omitempty"`
+	GenerationTime string          `xml:"GenerationTime"`
+	Description    string          `xml:"Description,omitempty"`
+	Assessment     iodefAssessment `xml:"Assessment"`
+	Contact        iodefContact    `xml:"Contact"`
+	EventData      iodefEventData  `xml:"EventData"`
+}
+
+type iodefIncidentID struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type iodefAssessment struct {
+	SystemImpact iodefImpact `xml:"SystemImpact"`
+}
+
+type iodefImpact struct {
+	Type     string `xml:"type,attr"`
+	ExtType  string `xml:"ext-type,attr,omitempty"`
+	Severity string `xml:"severity,attr,omitempty"`
+}
+
+type iodefContact struct {
+	Role        string `xml:"role,attr"`
+	Type        string `xml:"type,attr"`
+	ContactName string `xml:"ContactName,omitempty"`
+	EmailTo     string `xml:"Email>EmailTo,omitempty"`
+}
+
+type iodefEventData struct {
+	Flow iodefFlow `xml:"Flow"`
+}
+
+type iodefFlow struct {
+	Systems []iodefSystem `xml:"System"`
+}
+
+type iodefSystem struct {
+	Category string        `xml:"category,attr"`
+	Node     iodefNode     `xml:"Node"`
+	Service  *iodefService `xml:"Service,omitempty"`
+}
+
+type iodefNode struct {
+	DomainData *iodefDomainData `xml:"DomainData,omitempty"`
+	Address    []iodefAddress   `xml:"Address,omitempty"`
+}
+
+type iodefDomainData struct {
+	Name string `xml:"Name"`
+}
+
+type iodefAddress struct {
+	Category string `xml:"category,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type iodefService struct {
+	IPProtocol string `xml:"ip-protocol,attr"`
+	Port       string `xml:"Port,omitempty"`
+}
+
+func (i *IODEF) ContentType() string {
+	return "application/xml"
+}
+
+// Encode returns the IODEF document of a batch
+func (i *IODEF) Encode(evs []*events.Event) ([]byte, error) {
+	if len(evs) == 0 {
+		return nil, fmt.Errorf("no events to encode")
+	}
+	now := i.Now().UTC()
+
+	doc := iodefDocument{
+		Version: "2.00",
+		Lang:    "en",
+		Xmlns:   "urn:ietf:params:xml:ns:iodef-2.0",
+	}
+	for index, event := range evs {
+		incident, err := i.incident(event, now, index)
+		if err != nil {
+			return nil, err
+		}
+		doc.Incidents = append(doc.Incidents, incident)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func (i *IODEF) incident(event *events.Event, now time.Time, index int) (iodefIncident, error) {
+	source := iodefSystem{Category: "source"}
+	switch {
+	case event.IP != "":
+		category := "ipv4-addr"
+		if ip := net.ParseIP(event.IP); ip != nil && ip.To4() == nil {
+			category = "ipv6-addr"
+		}
+		source.Node.Address = append(source.Node.Address, iodefAddress{Category: category, Value: event.IP})
+	case event.URL != "":
+		source.Node.Address = append(source.Node.Address, iodefAddress{Category: "site-uri", Value: event.URL})
+	case event.Domain != "":
+		source.Node.DomainData = &iodefDomainData{Name: event.Domain}
+	default:
+		return iodefIncident{}, fmt.Errorf("event has no IP, URL or domain")
+	}
+	source.Service = eventService(event, event.Port)
+
+	typeName := eventTypeName(event)
+	impact := iodefImpact{Type: "ext-value", ExtType: typeName}
+	if impactType, ok := systemImpacts[typeName]; ok {
+		impact = iodefImpact{Type: impactType}
+	}
+
+	incidentID := fmt.Sprintf("%d-%d", now.Unix(), index+1)
+	purpose := "reporting"
+	if caseInfo, ok := findDetail[*events.ExternalCaseInformation](event); ok && caseInfo.CaseID != "" {
+		incidentID = caseInfo.CaseID
+		impact.Severity = strings.ToLower(caseInfo.Severity)
+		if iodefPurposes[caseInfo.Status] {
+			purpose = caseInfo.Status
+		}
+	} else if event.ReportID != "" {
+		incidentID = event.ReportID
+	}
+
+	incident := iodefIncident{
+		Purpose:        purpose,
+		IncidentID:     iodefIncidentID{Name: mailDomain(i.Reporter.Email), Value: incidentID},
+		GenerationTime: now.Format(time.RFC3339),
+		Description:    fmt.Sprintf("%s from %s", typeName, eventSource(event)),
+		Assessment:     iodefAssessment{SystemImpact: impact},
+		Contact: iodefContact{
+			Role:        "creator",
+			Type:        "organization",
+			ContactName: i.Reporter.Name,
+			EmailTo:     i.Reporter.Email,
+		},
+		EventData: iodefEventData{Flow: iodefFlow{Systems: []iodefSystem{source}}},
+	}
+	if event.EventDate != nil {
+		incident.DetectTime = event.EventDate.UTC().Format(time.RFC3339)
+	}
+
+	if target, ok := findDetail[*events.Target](event); ok && target.IP != "" {
+		system := iodefSystem{Category: "target"}
+		system.Node.Address = []iodefAddress{{Category: "ipv4-addr", Value: target.IP}}
+		if ip := net.ParseIP(target.IP); ip != nil && ip.To4() == nil {
+			system.Node.Address[0].Category = "ipv6-addr"
+		}
+		port, _ := strconv.Atoi(target.Port)
+		system.Service = eventService(event, port)
+		incident.EventData.Flow.Systems = append(incident.EventData.Flow.Systems, system)
+	}
+	return incident, nil
+}
+
+// eventService returns the Service of a system from the event's transport
+// protocol and a port; nil if neither is known
+func eventService(event *events.Event, port int) *iodefService {
+	protocol := ""
+	if transport, ok := findDetail[*events.TransportProtocol](event); ok {
+		protocol = ipProtocolNumbers[strings.ToLower(transport.Protocol)]
+	}
+	if protocol == "" && port == 0 {
+		return nil
+	}
+	if protocol == "" {
+		// ip-protocol is required; 6 is the common case for ported services
+		protocol = "6"
+	}
+	service := &iodefService{IPProtocol: protocol}
+	if port != 0 {
+		service.Port = strconv.Itoa(port)
+	}
+	return service
+}
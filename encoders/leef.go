@@ -0,0 +1,74 @@
+package encoders
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// LEEF encodes events as IBM QRadar LEEF 2.0 lines with tab delimited
+// attributes, one line per event
+type LEEF struct{}
+
+func NewLEEF() *LEEF {
+	return &LEEF{}
+}
+
+// leefTimeFormat is sent as devTimeFormat (Java SimpleDateFormat)
+const leefTimeFormat = "MMM dd yyyy HH:mm:ss z"
+
+var leefValueEscaper = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+func (l *LEEF) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// Encode returns one LEEF line per event
+func (l *LEEF) Encode(evs []*events.Event) ([]byte, error) {
+	return encodeLines(l, evs)
+}
+
+// EncodeEvent returns the LEEF line of an event without line terminator
+func (l *LEEF) EncodeEvent(event *events.Event) ([]byte, error) {
+	e := newSIEMEvent(event)
+
+	var attrs []string
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, key+"="+leefValueEscaper.Replace(value))
+		}
+	}
+	addPort := func(key string, port int) {
+		if port != 0 {
+			add(key, strconv.Itoa(port))
+		}
+	}
+
+	add("cat", e.typeName)
+	add("sev", strconv.Itoa(e.severityLevel()))
+	if e.date != nil {
+		add("devTime", e.date.UTC().Format("Jan 02 2006 15:04:05 MST"))
+		add("devTimeFormat", leefTimeFormat)
+	}
+	add("src", e.ip)
+	addPort("srcPort", e.port)
+	add("dst", e.targetIP)
+	addPort("dstPort", e.targetPort)
+	add("proto", e.protocol)
+	add("url", e.url)
+	add("domain", e.domain)
+	add("asn", e.asn)
+	add("asName", e.asName)
+	add("srcCountry", e.country)
+	add("srcCity", e.city)
+	add("malware", e.malware)
+	add("parser", e.parser)
+	add("reportId", e.reportID)
+
+	header := []string{"LEEF:2.0", productVendor, UserAgent, productVersion, e.typeName, "x09"}
+	for i := range header[1:] {
+		header[i+1] = cefHeaderEscaper.Replace(header[i+1])
+	}
+	return []byte(strings.Join(header, "|") + "|" + strings.Join(attrs, "\t")), nil
+}
@@ -0,0 +1,116 @@
+package encoders
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// productVendor and productVersion identify us in CEF and LEEF headers
+const (
+	productVendor  = "Abusix"
+	productVersion = "1.0"
+)
+
+// siemEvent holds the event fields the SIEM line formats (CEF, LEEF, ECS,
+// syslog) map to their schemas
+type siemEvent struct {
+	typeName string
+	date     *time.Time
+	// address is the event IP as reported, ip the same if it is a valid IP;
+	// the schemas type their IP fields, some parsers don't
+	address  string
+	ip       string
+	port     int
+	url      string
+	domain   string
+	reportID string
+	parser   string
+	malware  string
+	// severity is the case severity (low, medium, high) or ""
+	severity string
+
+	targetIP   string
+	targetPort int
+	protocol   string
+
+	asn      string
+	asName   string
+	country  string
+	city     string
+	asNumber int
+}
+
+func newSIEMEvent(event *events.Event) siemEvent {
+	e := siemEvent{
+		typeName: eventTypeName(event),
+		date:     event.EventDate,
+		address:  event.IP,
+		port:     event.Port,
+		url:      event.URL,
+		domain:   event.Domain,
+		reportID: event.ReportID,
+		parser:   event.Parser,
+		severity: eventSeverity(event),
+	}
+	if net.ParseIP(event.IP) != nil {
+		e.ip = event.IP
+	}
+	if len(event.EventTypes) > 0 {
+		switch eventType := event.EventTypes[0].(type) {
+		case *events.Malware:
+			e.malware = eventType.Infection
+		case *events.Bot:
+			e.malware = eventType.BotType
+		}
+	}
+	if target, ok := findDetail[*events.Target](event); ok {
+		if net.ParseIP(target.IP) != nil {
+			e.targetIP = target.IP
+		}
+		e.targetPort, _ = strconv.Atoi(target.Port)
+	}
+	if transport, ok := findDetail[*events.TransportProtocol](event); ok {
+		e.protocol = strings.ToLower(transport.Protocol)
+	}
+	// Enrichment adds its values as further details, see package enrich
+	for _, detail := range event.EventDetails {
+		switch detail := detail.(type) {
+		case *events.ASN:
+			if e.asn == "" {
+				e.asn = detail.ASN
+				e.asNumber = asnNumber(detail.ASN)
+			}
+			if e.asName == "" {
+				e.asName = detail.ASName
+			}
+		case *events.Location:
+			if e.country == "" {
+				e.country = detail.Country
+			}
+			if e.city == "" {
+				e.city = detail.City
+			}
+		}
+	}
+	return e
+}
+
+// severityLevel returns the severity on the 0-10 scale of CEF and LEEF; 5
+// when the reporter gave none
+func (e siemEvent) severityLevel() int {
+	switch e.severity {
+	case "low":
+		return 3
+	case "medium":
+		return 6
+	case "high":
+		return 8
+	case "critical":
+		return 10
+	}
+	return 5
+}
@@ -0,0 +1,92 @@
+package encoders
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// siemEvents covers IPv4 and IPv6 sources, URLs, targets, ASN and location
+// details, and values that need escaping in every format
+func siemEvents() []*events.Event {
+	scan := testEvent("192.0.2.30", events.NewPortScan())
+	scan.Parser = "shadowserver"
+	scan.Port = 445
+	scan.ReportID = "CERT-2025-0042"
+	scan.AddEventDetail(&events.TransportProtocol{Protocol: "tcp"})
+	scan.AddEventDetail(&events.Target{IP: "198.51.100.1", Port: "445"})
+	scan.AddEventDetail(&events.ASN{ASN: "AS64496", ASName: "Example \"Transit\" | Net=Works"})
+	scan.AddEventDetail(&events.Location{Country: "DE", City: "Berlin"})
+	scan.AddEventDetail(&events.ExternalCaseInformation{CaseID: "CERT-2025-0042", Severity: "High"})
+
+	bot := testEvent("2001:db8::30", events.NewBot("mirai"))
+	bot.Parser = "spamhaus"
+	bot.AddEventDetail(&events.Location{Country: "Netherlands"})
+
+	phish := testEvent("", events.NewPhishing())
+	phish.Parser = "netcraft"
+	phish.URL = "http://phish.example/login?a=1&b=]"
+	phish.Domain = "phish.example"
+	phish.EventDate = nil
+	return []*events.Event{scan, bot, phish}
+}
+
+func TestSIEMEncoders_Golden(t *testing.T) {
+	syslog := NewSyslog()
+	syslog.Hostname = "parser-1"
+	syslog.Now = testNow
+	ecs := NewECS()
+	ecs.Now = testNow
+
+	for name, encoder := range map[string]LineEncoder{
+		"cef":    NewCEF(),
+		"leef":   NewLEEF(),
+		"ecs":    ecs,
+		"syslog": syslog,
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := encoder.Encode(siemEvents())
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, data, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Missing golden file (run with -update): %v", err)
+			}
+			if !bytes.Equal(data, want) {
+				t.Errorf("Output differs from %s:\n got: %s\nwant: %s", golden, data, want)
+			}
+		})
+	}
+}
+
+func TestECS_ValidJSON(t *testing.T) {
+	encoder := NewECS()
+	encoder.Now = testNow
+	for _, event := range siemEvents() {
+		line, err := encoder.EncodeEvent(event)
+		if err != nil {
+			t.Fatalf("EncodeEvent failed: %v", err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(line, &doc); err != nil {
+			t.Fatalf("Invalid JSON %s: %v", line, err)
+		}
+		if doc["@timestamp"] == "" || doc["event"] == nil {
+			t.Errorf("Missing required ECS fields: %s", line)
+		}
+	}
+}
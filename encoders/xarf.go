@@ -0,0 +1,180 @@
+package encoders
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"gopkg.in/yaml.v3"
+)
+
+// XARF encodes events as X-ARF v0.2 PLAIN mails, one mail per event
+type XARF struct {
+	Reporter Reporter
+	// Now is the clock used for Message-IDs and missing event dates
+	Now func() time.Time
+}
+
+func NewXARF(reporter Reporter) *XARF {
+	return &XARF{Reporter: reporter, Now: time.Now}
+}
+
+// xarfTypes maps event types to the X-ARF category and report type the
+// xarf parser maps back; other types are sent as category "info"
+var xarfTypes = map[string][2]string{
+	"spam":          {"abuse", "spam"},
+	"login_attack":  {"abuse", "login-attack"},
+	"port_scan":     {"abuse", "port-probe"},
+	"web_crawler":   {"abuse", "harvesting"},
+	"web_hack":      {"abuse", "hack-attack"},
+	"ddos":          {"abuse", "ddos"},
+	"dns_blocklist": {"abuse", "dnsbl"},
+	"phishing":      {"fraud", "phishing"},
+	"fraud":         {"fraud", "fraud"},
+	"malware":       {"malware", "malware"},
+	"bot":           {"malware", "bot-infection"},
+}
+
+// xarfReport is the report.txt YAML document in field order
+type xarfReport struct {
+	ReportedFrom string `yaml:"Reported-From"`
+	Category     string `yaml:"Category"`
+	ReportType   string `yaml:"Report-Type"`
+	Version      string `yaml:"Version"`
+	UserAgent    string `yaml:"User-Agent"`
+	ReportID     string `yaml:"Report-ID,omitempty"`
+	Date         string `yaml:"Date"`
+	SourceType   string `yaml:"Source-Type"`
+	Source       string `yaml:"Source"`
+	Port         int    `yaml:"Port,omitempty"`
+	Destination  string `yaml:"Destination,omitempty"`
+	Malware      string `yaml:"Malware,omitempty"`
+	BotName      string `yaml:"Bot-Name,omitempty"`
+	Attachment   string `yaml:"Attachment"`
+}
+
+func (x *XARF) ContentType() string {
+	return "message/rfc822"
+}
+
+// Encode returns one mail for a single event and an mbox for a batch
+func (x *XARF) Encode(evs []*events.Event) ([]byte, error) {
+	now := x.Now()
+	var mails [][]byte
+	for i, event := range evs {
+		m, err := x.encodeEvent(event, now, i)
+		if err != nil {
+			return nil, err
+		}
+		mails = append(mails, m)
+	}
+	if len(mails) == 0 {
+		return nil, fmt.Errorf("no events to encode")
+	}
+	return mbox(mails, now), nil
+}
+
+func (x *XARF) encodeEvent(event *events.Event, now time.Time, index int) ([]byte, error) {
+	source := eventSource(event)
+	if source == "" {
+		return nil, fmt.Errorf("event has no IP, URL or domain")
+	}
+	date := eventDate(event, now)
+	typeName := eventTypeName(event)
+
+	report := xarfReport{
+		ReportedFrom: x.Reporter.Email,
+		Category:     "info",
+		ReportType:   strings.ReplaceAll(typeName, "_", "-"),
+		Version:      "0.2",
+		UserAgent:    UserAgent,
+		ReportID:     event.ReportID,
+		Date:         date.Format(time.RFC1123Z),
+		Source:       source,
+		Port:         event.Port,
+		Attachment:   "none",
+	}
+	if mapped, ok := xarfTypes[typeName]; ok {
+		report.Category, report.ReportType = mapped[0], mapped[1]
+	}
+	switch ip := net.ParseIP(source); {
+	case ip != nil && ip.To4() != nil:
+		report.SourceType = "ipv4"
+	case ip != nil:
+		report.SourceType = "ipv6"
+	default:
+		report.SourceType = "uri"
+	}
+	if target, ok := findDetail[*events.Target](event); ok {
+		report.Destination = target.IP
+	}
+	if len(event.EventTypes) > 0 {
+		switch eventType := event.EventTypes[0].(type) {
+		case *events.Malware:
+			report.Malware = eventType.Infection
+		case *events.Bot:
+			report.BotName = eventType.BotType
+		}
+	}
+
+	var evidence *mailPart
+	if sample, ok := findDetail[*events.Sample](event); ok {
+		payload, err := samplePayload(sample)
+		if err != nil {
+			return nil, err
+		}
+		report.Attachment = sample.ContentType
+		evidence = &mailPart{
+			header: textproto.MIMEHeader{
+				"Content-Type":        {sample.ContentType},
+				"Content-Disposition": {`attachment; filename="evidence"`},
+			},
+			body: payload,
+		}
+	}
+
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	parts := []mailPart{{
+		header: textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}},
+		body:   []byte(fmt.Sprintf("This is an X-ARF report about %s (%s), observed at %s.\r\n", source, typeName, date.Format(time.RFC1123Z))),
+	}, {
+		header: textproto.MIMEHeader{
+			"Content-Type":        {`text/plain; charset=utf-8; name="report.txt"`},
+			"Content-Disposition": {`attachment; filename="report.txt"`},
+		},
+		body: data,
+	}}
+	if evidence != nil {
+		parts = append(parts, *evidence)
+	}
+
+	m := &mail{
+		reporter:    x.Reporter,
+		date:        now,
+		id:          mailID(now, index),
+		subject:     fmt.Sprintf("abuse report about %s - %s", source, date.Format("2006-01-02T15:04:05Z")),
+		headers:     [][2]string{{"X-XARF", "PLAIN"}, {"Auto-Submitted", "auto-generated"}},
+		contentType: "multipart/mixed",
+		parts:       parts,
+	}
+	return m.bytes()
+}
+
+// samplePayload decodes the payload of an events.Sample
+func samplePayload(sample *events.Sample) ([]byte, error) {
+	if !strings.EqualFold(sample.Encoding, "base64") {
+		return []byte(sample.Payload), nil
+	}
+	payload, err := base64.StdEncoding.DecodeString(sample.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sample payload: %w", err)
+	}
+	return payload, nil
+}
@@ -241,6 +241,9 @@ func (p *Password) GetType() string {
 type ISP struct {
 	ISPName string `json:"isp_name,omitempty"`
 	Country string `json:"country,omitempty"`
+	// Source is empty for reporter-supplied details and names the lookup
+	// that added them otherwise, e.g. "mmdb"
+	Source string `json:"source,omitempty"`
 }
 
 func (i *ISP) GetType() string {
@@ -251,6 +254,8 @@ func (i *ISP) GetType() string {
 type ASN struct {
 	ASN    string `json:"asn,omitempty"`
 	ASName string `json:"as_name,omitempty"`
+	// Source is empty for reporter-supplied details, see ISP
+	Source string `json:"source,omitempty"`
 }
 
 func (a *ASN) GetType() string {
@@ -261,6 +266,8 @@ func (a *ASN) GetType() string {
 type Location struct {
 	Country string `json:"country,omitempty"`
 	City    string `json:"city,omitempty"`
+	// Source is empty for reporter-supplied details, see ISP
+	Source string `json:"source,omitempty"`
 }
 
 func (l *Location) GetType() string {
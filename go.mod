@@ -3,9 +3,10 @@ module github.com/abusix/inbound-parsers
 go 1.25.0
 
 require (
-	github.com/PuerkitoBio/goquery v1.10.3 // indirect
-	github.com/andybalholm/cascadia v1.3.3 // indirect
-	golang.org/x/net v0.46.0 // indirect
-	golang.org/x/text v0.30.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	github.com/PuerkitoBio/goquery v1.10.3
+	golang.org/x/net v0.46.0
+	golang.org/x/text v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require github.com/andybalholm/cascadia v1.3.3 // indirect
@@ -153,8 +153,13 @@ func parseCSV(attachment string) ([]*events.Event, error) {
 			delete(row, "src_ip")
 		}
 
-		// Add remaining fields as event details
-		for key, value := range row {
+		// Add remaining fields as event details, in column order
+		for _, key := range headers {
+			value, ok := row[key]
+			if !ok {
+				continue
+			}
+			delete(row, key)
 			value = strings.TrimSpace(value)
 			if value != "" {
 				event.AddEventDetailSimple(key, value)
@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse parses emails from noreply@abusix.org
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.FromDomain(serializedEmail, "abusix.org") {
+		return nil, common.NewParserError("not from abusix.org")
+	}
+
 	// Get the last attachment (CSV file)
 	if len(serializedEmail.Parts) == 0 {
 		return nil, common.NewParserError("no attachments found")
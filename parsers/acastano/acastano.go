@@ -19,6 +19,10 @@ var (
 
 // Parse parses emails from @acastano.fr
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.FromDomain(serializedEmail, "acastano.fr") {
+		return nil, common.NewParserError("not from acastano.fr")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
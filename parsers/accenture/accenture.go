@@ -113,6 +113,10 @@ func parseIPAttack(body string, event *events.Event, dateFallback string) ([]*ev
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "accenture.com") {
+		return nil, common.NewParserError("not from accenture.com")
+	}
+
 	event := events.NewEvent("accenture")
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
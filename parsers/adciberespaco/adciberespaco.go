@@ -19,6 +19,10 @@ var (
 
 // Parse parses emails from @adciberespaco
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "adciberespaco.pt") {
+		return nil, common.NewParserError("not from adciberespaco.pt")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -20,6 +20,10 @@ var (
 
 // Parse parses emails from abuse-out@agouros.de
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.FromDomain(serializedEmail, "agouros.de") {
+		return nil, common.NewParserError("not from agouros.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
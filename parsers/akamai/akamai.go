@@ -37,6 +37,19 @@ var keyMap = []struct {
 	{[]string{"ddos"}, func() events.EventType { return events.NewDDoS() }},
 }
 
+// sentByAkamai reports whether an email is from akamai.com, also when a
+// mailing list relayed it under its own From address
+func sentByAkamai(serializedEmail *email.SerializedEmail) bool {
+	for _, header := range []string{"from", "x-original-from", "reply-to"} {
+		for _, value := range serializedEmail.Headers[header] {
+			if strings.Contains(strings.ToLower(value), "@akamai.com") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func parseAbuseAkamai(subject, dateStr, body string) ([]*events.Event, error) {
 	event := events.NewEvent("akamai")
 	event.EventDate = email.ParseDate(dateStr)
@@ -190,6 +203,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	if fromHeader, ok := serializedEmail.Headers["from"]; ok && len(fromHeader) > 0 {
 		fromAddr = fromHeader[0]
 	}
+	if !sentByAkamai(serializedEmail) {
+		return nil, common.NewParserError("not from akamai.com")
+	}
 
 	// Check if this is from abuse@akamai.com
 	if strings.Contains(fromAddr, "abuse@akamai.com") {
@@ -110,6 +110,10 @@ func parseAmasha(body, subject, dateFallback string, serializedEmail *email.Seri
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "amasha.de") {
+		return nil, common.NewParserError("not from amasha.de")
+	}
+
 	body, _ := common.GetBody(serializedEmail, true)
 	subject, _ := common.GetSubject(serializedEmail, false)
 	subjectLower := strings.ToLower(subject)
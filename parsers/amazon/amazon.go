@@ -95,6 +95,10 @@ func parseDDoS(body string) ([]*events.Event, error) {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "amazon.com") {
+		return nil, common.NewParserError("not from amazon.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
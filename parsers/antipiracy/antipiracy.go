@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.FromDomain(serializedEmail, "microsoft-antipiracy.com") {
+		return nil, common.NewParserError("not from microsoft-antipiracy.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
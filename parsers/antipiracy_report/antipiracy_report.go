@@ -13,6 +13,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.FromDomain(serializedEmail, "antipiracy.report") {
+		return nil, common.NewParserError("not from antipiracy.report")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
@@ -24,8 +28,8 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		event.EventDate = email.ParseDate(dateHeader[0])
 	}
 
-	// Extract IP from subject
-	event.IP = subject
+	// Extract IP from subject: "... takedown notice - IP 192.0.2.1"
+	event.IP = common.ExtractOneIP(subject)
 
 	// Extract URL from body after specific marker
 	url := common.FindStringWithoutMarkers(body, "The following links are hosted in the IP address", "")
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "antipiracyprotection.com", "antipiracyprotection.info") {
+		return nil, common.NewParserError("not from antipiracyprotection.com or antipiracyprotection.info")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -36,8 +40,11 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// Check if this is a Disney report
 	if strings.Contains(bodyLower, "disney") {
-		copyrightedWork = common.GetBlockAfterWithStop(bodyLower, "copyrighted work(s) infringed upon:", "")[0]
-		copyrightedWork = strings.TrimSpace(copyrightedWork)
+		block := common.GetBlockAfterWithStop(bodyLower, "copyrighted work(s) infringed upon:", "")
+		if len(block) == 0 {
+			return nil, common.NewParserError("no infringed work found")
+		}
+		copyrightedWork = strings.TrimSpace(block[0])
 
 		eventTemplate.EventTypes = []events.EventType{
 			events.NewCopyright(copyrightedWork, "Disney Enterprises, Inc.", ""),
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "aol.com") {
+		return nil, common.NewParserError("not from aol.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
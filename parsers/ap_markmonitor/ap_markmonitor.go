@@ -298,6 +298,10 @@ func parseChineseInfringement(body, date, subject string) ([]*events.Event, erro
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "markmonitor.com", "ap.opsecsecurity.com", "ap-live.opsecsecurity.com") {
+		return nil, common.NewParserError("not from markmonitor.com, ap.opsecsecurity.com or ap-live.opsecsecurity.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 	subjectLower := strings.ToLower(subject)
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if from, _ := common.GetFrom(serializedEmail, false); from != "apiccopyright@gmail.com" {
+		return nil, common.NewParserError("not from apiccopyright@gmail.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -119,6 +119,10 @@ func parseMalicious(body string, eventDate *time.Time) ([]*events.Event, error)
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "arkadruk.pl") {
+		return nil, common.NewParserError("not from arkadruk.pl")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -16,26 +16,12 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-// stripHTML removes HTML tags from a string (simple implementation matching BeautifulSoup.text behavior)
-func stripHTML(html string) string {
-	// Remove HTML tags
-	re := regexp.MustCompile(`<[^>]*>`)
-	text := re.ReplaceAllString(html, " ")
-
-	// Clean up whitespace
-	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
-	return strings.TrimSpace(text)
-}
-
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	body, err := common.GetBody(serializedEmail, false)
+	bodyText, err := common.GetTextBody(serializedEmail, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get body: %w", err)
 	}
 
-	// Strip HTML tags to get plain text (matching BeautifulSoup.text behavior)
-	bodyText := stripHTML(body)
-
 	// Extract IP, date, and target using regex
 	// Pattern: "ip address (.*) at (.*) on our client (.*)\."
 	re := regexp.MustCompile(`(?i)ip address (.*?) at (.*?) on our client (.*?)\.`)
@@ -24,6 +24,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "att.net") {
+		return nil, common.NewParserError("not from att.net")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 
 	event := events.NewEvent("att")
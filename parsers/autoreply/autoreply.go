@@ -0,0 +1,198 @@
+// Package autoreply ignores auto-responses (RFC 3834) and the acknowledgements
+// and status updates of helpdesk systems, so they need no per-sender rules
+package autoreply
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/parsers/dsn"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// helpdesk is the signature of a ticket system
+type helpdesk struct {
+	name string
+	// headers match if present, with any value containing the substring ("" matches any value)
+	headers map[string]string
+	// markers match the body
+	markers []string
+	// ticketPatterns extract the ticket id from the subject or, failing that, the body
+	ticketPatterns []*regexp.Regexp
+}
+
+var helpdesks = []helpdesk{
+	{
+		name:    "zendesk",
+		headers: map[string]string{"x-mailer": "zendesk", "message-id": "zendesk", "x-zendesk-from-account-id": ""},
+		markers: []string{"##- please type your reply above this line -##"},
+		ticketPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\[(?:request|ticket) #(\d+)\]`),
+			regexp.MustCompile(`(?i)\b(?:request|ticket) #(\d+)`),
+		},
+	},
+	{
+		name:    "freshdesk",
+		headers: map[string]string{"message-id": "freshdesk", "x-freshdesk-ticket-id": "", "x-fd-email": ""},
+		ticketPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\[#(\d+)\]`),
+			regexp.MustCompile(`(?i)\bticket #(\d+)`),
+		},
+	},
+	{
+		name:    "otrs",
+		headers: map[string]string{"x-mailer": "otrs", "x-otrs-loop": "", "x-otrs-ticketnumber": ""},
+		ticketPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\[?(?:ticket|tn)#\s?:?\s?(\d{6,})\]?`),
+		},
+	},
+	{
+		name:    "rt",
+		headers: map[string]string{"rt-ticket": "", "x-rt-ticket": "", "managed-by": "request tracker", "x-rt-loop-prevention": ""},
+		ticketPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\[[^\]#]+ #(\d+)\]`),
+		},
+	},
+	{
+		name:    "jira",
+		headers: map[string]string{"x-jira-fingerprint": "", "message-id": "jira"},
+		markers: []string{"reply above this line."},
+		ticketPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\b([A-Z][A-Z0-9]+-\d+)\b`),
+		},
+	},
+}
+
+var (
+	// autoReplySubjects are the subjects of out-of-office and auto-responders
+	autoReplySubjects = regexp.MustCompile(`(?i)^\s*(?:automatic reply|auto(?:matic)?[- ]?(?:reply|response)|autoreply|out of (?:the )?office|abwesenheitsnotiz|automatische antwort|réponse automatique|respuesta automática|risposta automatica|automatisch antwoord|resposta automática)\b`)
+	// ticketStatusPhrases are acknowledgements and status updates of ticket systems.
+	// Reporters send reports through the same systems ("This ticket was created
+	// on your behalf"), so only phrases addressing our own request count.
+	ticketStatusPhrases = []string{
+		"automatically generated in response", "has been assigned an id", "your request has been received",
+		"your message has been received", "we have received your", "we received your", "your ticket has been created",
+		"your request has been created", "your ticket has been resolved", "your ticket has been closed",
+		"your request has been resolved", "your request has been closed", "your request has been solved",
+		"status changed", "status has been changed", "comments added", "proposed solution", "your request was updated",
+		"your ticket has been updated",
+	}
+	genericTicketPattern = regexp.MustCompile(`(?i)\[(?:ticket|case|request|incident)?\s*#\s*([A-Za-z0-9][\w-]*)\]|\b(?:ticket|case|request) (?:id|number|no\.?)?\s*[:#]\s*([A-Za-z0-9][\w-]*\d)`)
+)
+
+// Parse returns an IgnoreError for auto-responses and helpdesk acknowledgements
+// and a ParserError for everything else, so the other parsers continue.
+// Helpdesks mark every outgoing message as auto-reply, including the reports
+// CERTs send with them, so helpdesk messages also need an acknowledgement phrase.
+// Bounces are marked as auto-replies too; they are left to the dsn parser.
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if dsn.ParseReport(serializedEmail) != nil {
+		return nil, common.NewParserError("delivery status notification")
+	}
+
+	subject, _ := common.GetSubject(serializedEmail, false)
+	body, _ := common.GetBody(serializedEmail, false)
+
+	desk := detectHelpdesk(serializedEmail.Headers, body)
+	if desk == nil {
+		if reason := autoReplyReason(serializedEmail.Headers, subject); reason != "" {
+			return nil, common.NewTicketIgnoreError(reason, ticketID(nil, subject, body))
+		}
+		return nil, common.NewParserError("not an auto-reply")
+	}
+
+	lower := strings.ToLower(subject + "\n" + body)
+	for _, phrase := range ticketStatusPhrases {
+		if strings.Contains(lower, phrase) {
+			return nil, common.NewTicketIgnoreError(desk.name+" ticket notification", ticketID(desk, subject, body))
+		}
+	}
+	return nil, common.NewParserError("not an auto-reply")
+}
+
+// autoReplyReason returns why the headers or subject mark a message as
+// auto-response. Auto-Submitted: auto-generated is not enough: automated
+// abuse reports are generated, but they are not replies.
+func autoReplyReason(headers map[string][]string, subject string) string {
+	if value := strings.ToLower(header(headers, "auto-submitted")); strings.HasPrefix(value, "auto-replied") {
+		return "auto-submitted: " + value
+	}
+	for _, name := range []string{"x-autoreply", "x-autorespond", "x-auto-reply"} {
+		if value := strings.ToLower(header(headers, name)); value != "" && value != "no" && value != "false" {
+			return name + " header"
+		}
+	}
+	if strings.EqualFold(header(headers, "precedence"), "auto_reply") {
+		return "precedence: auto_reply"
+	}
+	if autoReplySubjects.MatchString(subject) {
+		if suppress := header(headers, "x-auto-response-suppress"); suppress != "" {
+			return "auto-reply subject with x-auto-response-suppress: " + suppress
+		}
+		return "auto-reply subject"
+	}
+	return ""
+}
+
+// detectHelpdesk returns the ticket system that sent a message, if any
+func detectHelpdesk(headers map[string][]string, body string) *helpdesk {
+	lowerBody := strings.ToLower(body)
+	for i := range helpdesks {
+		desk := &helpdesks[i]
+		for name, substring := range desk.headers {
+			if values, ok := headers[name]; ok && len(values) > 0 && strings.Contains(strings.ToLower(values[0]), substring) {
+				return desk
+			}
+		}
+		for _, marker := range desk.markers {
+			if strings.Contains(lowerBody, marker) {
+				return desk
+			}
+		}
+	}
+	return nil
+}
+
+// ticketID extracts the ticket id with the helpdesk's patterns, falling back
+// to generic "[#123]" / "Ticket #123" references
+func ticketID(desk *helpdesk, subject, body string) string {
+	var patterns []*regexp.Regexp
+	if desk != nil {
+		patterns = append(patterns, desk.ticketPatterns...)
+	}
+	patterns = append(patterns, genericTicketPattern)
+
+	for _, text := range []string{subject, body} {
+		for _, pattern := range patterns {
+			if match := pattern.FindStringSubmatch(text); match != nil {
+				for _, group := range match[1:] {
+					if group != "" {
+						return group
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func header(headers map[string][]string, name string) string {
+	if values := headers[name]; len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityPreprocessor
+}
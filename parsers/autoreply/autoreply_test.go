@@ -0,0 +1,115 @@
+package autoreply
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+func TestParser_Classification(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		headers  map[string][]string
+		body     string
+		reason   string
+		ticketID string
+	}{
+		{
+			name:    "out of office",
+			headers: map[string][]string{"subject": {"Automatic reply: Abuse report"}, "x-auto-response-suppress": {"All"}},
+			reason:  "auto-reply subject with x-auto-response-suppress: All",
+		},
+		{
+			name:     "rfc 3834",
+			headers:  map[string][]string{"subject": {"Re: Abuse report [#4711]"}, "auto-submitted": {"auto-replied"}},
+			reason:   "auto-submitted: auto-replied",
+			ticketID: "4711",
+		},
+		{
+			name:     "rt autoreply",
+			headers:  map[string][]string{"subject": {"[support.example #4711] Abuse report"}, "auto-submitted": {"auto-replied"}, "x-rt-ticket": {"support.example #4711"}},
+			body:     "This message has been automatically generated in response to the creation of a trouble ticket.",
+			reason:   "rt ticket notification",
+			ticketID: "4711",
+		},
+		{
+			name:     "zendesk acknowledgement",
+			headers:  map[string][]string{"subject": {"[Request #123456] Abuse report"}, "message-id": {"<ABC_123@zendesk.com>"}},
+			body:     "##- Please type your reply above this line -##\nYour request has been received and is being reviewed.",
+			reason:   "zendesk ticket notification",
+			ticketID: "123456",
+		},
+		{
+			name:     "otrs status update",
+			headers:  map[string][]string{"subject": {"[Ticket#2025101810000012] Abuse report"}, "x-mailer": {"OTRS Mail Service"}},
+			body:     "Your ticket has been closed.",
+			reason:   "otrs ticket notification",
+			ticketID: "2025101810000012",
+		},
+		{
+			name:     "jira service management",
+			headers:  map[string][]string{"subject": {"ABUSE-231 Abuse report"}, "x-jira-fingerprint": {"abc"}},
+			body:     "Reply above this line.\n\nYour request was updated.",
+			reason:   "jira ticket notification",
+			ticketID: "ABUSE-231",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewParser().Parse(&email.SerializedEmail{Headers: tc.headers, Body: tc.body})
+			var ignoreErr *common.IgnoreError
+			if !errors.As(err, &ignoreErr) {
+				t.Fatalf("Expected IgnoreError, got %v", err)
+			}
+			if ignoreErr.Reason != tc.reason || ignoreErr.TicketID != tc.ticketID {
+				t.Errorf("Expected %q/%q, got %q/%q", tc.reason, tc.ticketID, ignoreErr.Reason, ignoreErr.TicketID)
+			}
+		})
+	}
+}
+
+func TestParser_ReportsPass(t *testing.T) {
+	for _, serializedEmail := range []*email.SerializedEmail{
+		{
+			Headers: map[string][]string{
+				"subject":        {"[ticket #123] Abuse report for 192.0.2.1"},
+				"auto-submitted": {"auto-generated"},
+				"precedence":     {"bulk"},
+			},
+			Body: "We have received reports about 192.0.2.1.",
+		},
+		{
+			// CERTs send reports from RT, which marks them as auto-replied
+			Headers: map[string][]string{
+				"subject":        {"[CERT #119850] SPAM from 192.0.2.1"},
+				"auto-submitted": {"auto-replied"},
+				"x-rt-ticket":    {"CERT #119850"},
+			},
+			Body: "We received spam from 192.0.2.1, please take appropriate action.",
+		},
+		{
+			// Exim marks its bounces as auto-replied
+			Headers: map[string][]string{
+				"from":           {"Mail Delivery System <Mailer-Daemon@mx.customer.example>"},
+				"subject":        {"Mail delivery failed: returning message to sender"},
+				"auto-submitted": {"auto-replied"},
+			},
+			Body: `This message was created automatically by mail delivery software.
+
+A message that you sent could not be delivered to one or more of its
+recipients. This is a permanent error. The following address(es) failed:
+
+  abuse@customer.example
+    host mx.customer.example [198.51.100.4]
+    SMTP error from remote mail server after RCPT TO:<abuse@customer.example>:
+    550 5.1.1 mailbox unavailable`,
+		},
+	} {
+		_, err := NewParser().Parse(serializedEmail)
+		var ignoreErr *common.IgnoreError
+		if errors.As(err, &ignoreErr) {
+			t.Errorf("Expected report not to be ignored, got %v", err)
+		}
+	}
+}
@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "axghouse.com") {
+		return nil, common.NewParserError("not from axghouse.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
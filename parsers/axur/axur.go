@@ -243,6 +243,10 @@ func (p *Parser) parseFakeProfile(body string, eventTemplate *events.Event) ([]*
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "axur.com") {
+		return nil, common.NewParserError("not from axur.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
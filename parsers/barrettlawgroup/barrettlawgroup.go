@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "barrettlawgroup.com") {
+		return nil, common.NewParserError("not from barrettlawgroup.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
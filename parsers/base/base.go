@@ -6,7 +6,10 @@ import (
 	"github.com/abusix/inbound-parsers/pkg/email"
 )
 
-// Parser is the interface that all parsers must implement
+// Parser is the interface that all parsers must implement. Parsers must not
+// modify the email they are given: the registry passes the same email to
+// every parser it tries, including ones still running after a timeout. A
+// parser that rewrites an email works on a copy.
 type Parser interface {
 	Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error)
 	GetPriority() int
@@ -20,7 +23,7 @@ const (
 	PriorityPreprocessor = 1
 
 	// PriorityFormat (01-06) - Standard format parsers
-	// Examples: marf, xarf, feedback_loop, gold_parser, simple_url_report
+	// Examples: marf, xarf, feedback_loop, simple_url_report
 	PriorityFormat = 10
 
 	// PriorityVendor (default) - Vendor-specific parsers (alphabetically)
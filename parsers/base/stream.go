@@ -0,0 +1,43 @@
+package base
+
+import (
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// EmitFunc receives the events of a streaming parser one at a time.
+// The parser blocks until EmitFunc returns, which gives the consumer
+// backpressure; a non-nil error aborts the parse and is returned by ParseStream.
+type EmitFunc func(event *events.Event) error
+
+// StreamingParser is implemented by parsers of bulk reports (large CSV feeds,
+// zipped aggregate reports) that can emit events incrementally instead of
+// materialising the full slice. Streaming parsers still implement Parser so
+// callers that want a slice keep working; use CollectStream to implement Parse.
+type StreamingParser interface {
+	Parser
+	ParseStream(serializedEmail *email.SerializedEmail, emit EmitFunc) error
+}
+
+// CollectStream runs a streaming parse and collects the emitted events into a slice
+func CollectStream(parser StreamingParser, serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	var result []*events.Event
+	err := parser.ParseStream(serializedEmail, func(event *events.Event) error {
+		result = append(result, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EmitAll emits a materialised slice of events, stopping at the first emit error
+func EmitAll(eventList []*events.Event, emit EmitFunc) error {
+	for _, event := range eventList {
+		if err := emit(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "bellsouth.net") {
+		return nil, common.NewParserError("not from bellsouth.net")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 
 	event := events.NewEvent("bellsouth")
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "beygoo.io") {
+		return nil, common.NewParserError("not from beygoo.io")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
 
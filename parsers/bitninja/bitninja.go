@@ -20,9 +20,30 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
+// mentionsBitNinja reports whether an email is a BitNinja report. Reports
+// are often forwarded by the hosting provider of the reporting server, so
+// they are recognized by their sender headers, subject or content.
+func mentionsBitNinja(serializedEmail *email.SerializedEmail, textLower string) bool {
+	if strings.Contains(textLower, "bitninja") {
+		return true
+	}
+	for _, header := range []string{"from", "reply-to", "x-original-sender", "subject"} {
+		for _, value := range serializedEmail.Headers[header] {
+			if strings.Contains(strings.ToLower(value), "bitninja") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
+	htmlBody, _ := common.FindFirstAttachmentWithMimeType(serializedEmail, "html")
+	if !mentionsBitNinja(serializedEmail, bodyLower+strings.ToLower(htmlBody)) {
+		return nil, common.NewParserError("not a BitNinja report")
+	}
 
 	event := events.NewEvent("bitninja")
 
@@ -31,8 +52,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// If IP not found in plain body, try HTML attachment
 	if event.IP == "" {
-		htmlBody, err := common.FindFirstAttachmentWithMimeType(serializedEmail, "html")
-		if err == nil {
+		if htmlBody != "" {
 			// Strip HTML tags to get plain text (simplified version of BeautifulSoup)
 			bodyLower = stripHTMLTags(strings.ToLower(htmlBody))
 			event.IP = common.ExtractOneIP(bodyLower)
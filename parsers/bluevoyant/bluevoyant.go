@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "bluevoyant.com") {
+		return nil, common.NewParserError("not from bluevoyant.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 
 	event := events.NewEvent("bluevoyant")
@@ -18,6 +18,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "bofa.com") {
+		return nil, common.NewParserError("not from bofa.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -142,17 +146,15 @@ func (p *Parser) parseDDoS(body string, serializedEmail *email.SerializedEmail)
 
 // parseExploit parses exploit events from HTML table in email body
 func (p *Parser) parseExploit(body string, serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	// Extract CSV data from HTML table
-	csvRows, err := common.ExtractHTMLTableAsCSV(body)
+	// Extract rows from HTML table
+	table, err := common.ExtractHTMLTable(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract HTML table: %w", err)
 	}
 
-	// Parse CSV rows
-	csvData := strings.Join(csvRows, "\n")
-	rows, err := common.ParseCSVString(csvData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse exploit CSV: %w", err)
+	rows := table.Records()
+	if rows == nil {
+		return nil, fmt.Errorf("failed to parse exploit table: no header row")
 	}
 
 	var result []*events.Event
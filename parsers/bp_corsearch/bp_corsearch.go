@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "corsearch.com") {
+		return nil, common.NewParserError("not from corsearch.com")
+	}
+
 	// Get body and replace &nbsp; with spaces (matching Python behavior)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
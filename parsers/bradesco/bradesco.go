@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "bradesco.com.br") {
+		return nil, common.NewParserError("not from bradesco.com.br")
+	}
+
 	// Get body with throws=True to match Python behavior
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -30,7 +34,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	startIndex += 1 // Move past the newline
 	endIndex := strings.Index(body[startIndex:], "\n")
 	if endIndex == -1 {
-		endIndex = len(body[startIndex:])
+		endIndex = len(body)
 	} else {
 		endIndex += startIndex
 	}
@@ -45,7 +49,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	startIndex += 1 // Move past the newline
 	endIndex = strings.Index(body[startIndex:], "\n")
 	if endIndex == -1 {
-		endIndex = len(body[startIndex:])
+		endIndex = len(body)
 	} else {
 		endIndex += startIndex
 	}
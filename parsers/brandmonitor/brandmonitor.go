@@ -26,6 +26,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "brandmonitor.com.br") {
+		return nil, common.NewParserError("not from brandmonitor.com.br")
+	}
+
 	// Get body and subject
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "brandshield.com") {
+		return nil, common.NewParserError("not from brandshield.com")
+	}
+
 	// Get body with throws=true to match Python behavior
 	bodyRaw, err := common.GetBody(serializedEmail, true)
 	if err != nil {
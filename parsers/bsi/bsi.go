@@ -1,12 +1,12 @@
 package bsi
 
 import (
-	"encoding/csv"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
 	"github.com/abusix/inbound-parsers/parsers/common"
 	"github.com/abusix/inbound-parsers/pkg/email"
 )
@@ -36,24 +36,39 @@ func newEvent(parser string) *events.Event {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	return base.CollectStream(p, serializedEmail)
+}
+
+// ParseStream emits CSV based reports row by row, CERT-Bund feeds can have
+// hundreds of thousands of rows
+func (p *Parser) ParseStream(serializedEmail *email.SerializedEmail, emit base.EmitFunc) error {
+	if !common.SentFrom(serializedEmail, "bund.de", "cert-bund.de") {
+		return common.NewParserError("not from bund.de or cert-bund.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Remove carriage returns from body
 	body = common.RemoveCarriageReturn(body)
 
-	// Parse based on subject line
-	return p.parseNew(serializedEmail, body, subject)
+	// Parse based on subject line; CSV reports are emitted while parsing,
+	// everything else is returned as a slice
+	results, err := p.parseNew(serializedEmail, body, subject, emit)
+	if err != nil {
+		return err
+	}
+	return base.EmitAll(results, emit)
 }
 
-func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject string) ([]*events.Event, error) {
+func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject string, emit base.EmitFunc) ([]*events.Event, error) {
 	lower := strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(subject, "\n", ""), "\r", ""))
 
 	// Try to get CSV attachment or inline CSV
@@ -71,7 +86,7 @@ func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject
 			return p.parseSSHBruteForceFromLog(body, serializedEmail)
 		}
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewLoginAttack("", ""), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewLoginAttack("", ""), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewLoginAttack("", ""))
 
@@ -79,7 +94,7 @@ func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject
 	case strings.Contains(lower, "schadprogramm-infektion"),
 		strings.Contains(lower, "infizierte systeme") && strings.Contains(body, "Schadsoftware"),
 		strings.Contains(lower, "meldung von inifzierten systemen"):
-		return p.parseMalware(serializedEmail, body, lower)
+		return p.parseMalware(serializedEmail, body, lower, emit)
 
 	// Spam
 	case strings.Contains(lower, "spam"):
@@ -110,44 +125,44 @@ func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject
 	// Open services (multiple cases)
 	case strings.Contains(lower, "offene dns-resolver"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("dns"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("dns"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("dns"))
 
 	case strings.Contains(lower, "offene redis-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("redis"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("redis"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("redis"))
 
 	case strings.Contains(lower, "offene memcached-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("memcached"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("memcached"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("memcached"))
 
 	case strings.Contains(lower, "ntp-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("ntp"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("ntp"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("ntp"))
 
 	case strings.Contains(lower, "mongodb-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("mongodb"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("mongodb"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("mongodb"))
 
 	case strings.Contains(lower, "elasticsearch-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewOpen("elasticsearch"), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewOpen("elasticsearch"), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewOpen("elasticsearch"))
 
 	// DDoS
 	case strings.Contains(lower, "ddos-angriffe"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewDDoS(), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewDDoS(), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewDDoS())
 
@@ -160,7 +175,7 @@ func (p *Parser) parseNew(serializedEmail *email.SerializedEmail, body, subject
 
 	case strings.Contains(lower, "kompromittierte microsoft-exchange-server"):
 		if csvReader != nil {
-			return p.parseFromCSVReader(csvReader, events.NewCompromisedMicrosoftExchange(), serializedEmail)
+			return nil, p.parseFromCSVReader(csvReader, events.NewCompromisedMicrosoftExchange(), serializedEmail, emit)
 		}
 		return p.parseSimple(serializedEmail, body, events.NewCompromisedMicrosoftExchange())
 
@@ -237,20 +252,8 @@ func (p *Parser) parseSimple(serializedEmail *email.SerializedEmail, body string
 	return results, nil
 }
 
-// parseFromCSVReader parses events from a CSV reader
-func (p *Parser) parseFromCSVReader(reader *csv.Reader, eventType events.EventType, serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV: %w", err)
-	}
-
-	if len(records) == 0 {
-		return nil, fmt.Errorf("CSV has no records")
-	}
-
-	headers := records[0]
-	var results []*events.Event
-
+// parseFromCSVReader emits one event per CSV row
+func (p *Parser) parseFromCSVReader(reader *common.CSVReader, eventType events.EventType, serializedEmail *email.SerializedEmail, emit base.EmitFunc) error {
 	dateHeader := ""
 	if serializedEmail.Headers != nil {
 		if dates, ok := serializedEmail.Headers["date"]; ok && len(dates) > 0 {
@@ -258,15 +261,7 @@ func (p *Parser) parseFromCSVReader(reader *csv.Reader, eventType events.EventTy
 		}
 	}
 
-	for i := 1; i < len(records); i++ {
-		record := records[i]
-		entry := make(map[string]string)
-		for j, value := range record {
-			if j < len(headers) {
-				entry[headers[j]] = value
-			}
-		}
-
+	err := reader.ForEach(func(entry map[string]string) error {
 		event := newEvent("bsi")
 		event.EventTypes = []events.EventType{eventType}
 
@@ -326,24 +321,30 @@ func (p *Parser) parseFromCSVReader(reader *csv.Reader, eventType events.EventTy
 			}
 		}
 
-		results = append(results, event)
+		return emit(event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read CSV: %w", err)
 	}
 
-	return results, nil
+	return nil
 }
 
-// getCSVReader attempts to extract CSV data from attachment or email body
-func (p *Parser) getCSVReader(serializedEmail *email.SerializedEmail, body string) (*csv.Reader, error) {
+// getCSVReader attempts to extract CSV data from attachment or email body.
+// Header names are kept as sent since the field lookups below use BSI's spelling.
+func (p *Parser) getCSVReader(serializedEmail *email.SerializedEmail, body string) (*common.CSVReader, error) {
+	options := &common.CSVOptions{RawHeaders: true}
+
 	// Try to find CSV attachment
 	csvAttachment, err := p.getCSVAttachment(serializedEmail)
 	if err == nil && csvAttachment != "" {
-		return csv.NewReader(strings.NewReader(csvAttachment)), nil
+		return common.NewCSVReaderFromString(csvAttachment, options)
 	}
 
 	// Try to extract CSV from body
 	csvContent, err := p.extractCSVFromBody(body)
 	if err == nil && csvContent != "" {
-		return csv.NewReader(strings.NewReader(csvContent)), nil
+		return common.NewCSVReaderFromString(csvContent, options)
 	}
 
 	return nil, fmt.Errorf("no CSV data found")
@@ -376,18 +377,8 @@ func (p *Parser) extractCSVFromBody(body string) (string, error) {
 				if csvEnd == -1 {
 					csvEnd = len(startingAt)
 				}
-				csvContent := strings.TrimSpace(startingAt[:csvEnd])
-
-				// Add quotes if needed
-				if !strings.Contains(csvContent, "\"") && strings.Contains(csvContent, ";") {
-					lines := strings.Split(csvContent, "\n")
-					for i, line := range lines {
-						lines[i] = "\"" + strings.ReplaceAll(line, ";", "\",\"") + "\""
-					}
-					csvContent = strings.Join(lines, "\n")
-				}
-
-				return csvContent, nil
+				// The delimiter (often ';') is sniffed by the CSV reader
+				return strings.TrimSpace(startingAt[:csvEnd]), nil
 			}
 		}
 
@@ -482,31 +473,14 @@ func (p *Parser) parseDDoSReflection(serializedEmail *email.SerializedEmail, bod
 		return nil, fmt.Errorf("DDoS reflection marker not found")
 	}
 
-	csvContent := body[startIdx:]
-	reader := csv.NewReader(strings.NewReader(csvContent))
-	reader.Comma = ';'
-
-	records, err := reader.ReadAll()
+	reader, err := common.NewCSVReaderFromString(body[startIdx:], &common.CSVOptions{Delimiter: ';', RawHeaders: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DDoS CSV: %w", err)
 	}
 
-	if len(records) < 2 {
-		return nil, fmt.Errorf("DDoS CSV has insufficient data")
-	}
-
-	headers := records[0]
 	var results []*events.Event
 
-	for i := 1; i < len(records); i++ {
-		record := records[i]
-		entry := make(map[string]string)
-		for j, val := range record {
-			if j < len(headers) {
-				entry[headers[j]] = val
-			}
-		}
-
+	err = reader.ForEach(func(entry map[string]string) error {
 		event := newEvent("bsi")
 		event.EventTypes = []events.EventType{events.NewDDoS()}
 
@@ -534,6 +508,14 @@ func (p *Parser) parseDDoSReflection(serializedEmail *email.SerializedEmail, bod
 		}
 
 		results = append(results, event)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DDoS CSV: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("DDoS CSV has insufficient data")
 	}
 
 	return results, nil
@@ -678,7 +660,7 @@ func (p *Parser) parseSSHBruteForceFromLog(body string, serializedEmail *email.S
 	return results, nil
 }
 
-func (p *Parser) parseMalware(serializedEmail *email.SerializedEmail, body, lower string) ([]*events.Event, error) {
+func (p *Parser) parseMalware(serializedEmail *email.SerializedEmail, body, lower string, emit base.EmitFunc) ([]*events.Event, error) {
 	// Extract malware name from subject
 	malwareName := common.FindStringWithoutMarkers(lower, "hinweis auf ", "-")
 	if malwareName == "" {
@@ -693,35 +675,17 @@ func (p *Parser) parseMalware(serializedEmail *email.SerializedEmail, body, lowe
 	// Try CSV parsing first
 	csvReader, err := p.getCSVReader(serializedEmail, body)
 	if err == nil {
-		return p.parseMalwareFromCSV(csvReader, malwareName, serializedEmail)
+		return nil, p.parseMalwareFromCSV(csvReader, malwareName, serializedEmail, emit)
 	}
 
 	// Fallback to simple parsing
 	return p.parseSimple(serializedEmail, body, events.NewMalware(malwareName))
 }
 
-func (p *Parser) parseMalwareFromCSV(reader *csv.Reader, malwareName string, serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read malware CSV: %w", err)
-	}
-
-	if len(records) < 2 {
-		return nil, fmt.Errorf("insufficient malware CSV data")
-	}
-
-	headers := records[0]
-	var results []*events.Event
-
-	for i := 1; i < len(records); i++ {
-		record := records[i]
-		entry := make(map[string]string)
-		for j, val := range record {
-			if j < len(headers) {
-				entry[headers[j]] = val
-			}
-		}
+func (p *Parser) parseMalwareFromCSV(reader *common.CSVReader, malwareName string, serializedEmail *email.SerializedEmail, emit base.EmitFunc) error {
+	emitted := 0
 
+	err := reader.ForEach(func(entry map[string]string) error {
 		event := newEvent("bsi")
 
 		// Use malware name from CSV if available, otherwise from subject
@@ -755,10 +719,18 @@ func (p *Parser) parseMalwareFromCSV(reader *csv.Reader, malwareName string, ser
 			event.AddEventDetailSimple("c2_host", c2Host)
 		}
 
-		results = append(results, event)
+		emitted++
+		return emit(event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read malware CSV: %w", err)
 	}
 
-	return results, nil
+	if emitted == 0 {
+		return fmt.Errorf("insufficient malware CSV data")
+	}
+
+	return nil
 }
 
 func (p *Parser) parseSpam(serializedEmail *email.SerializedEmail, body, lower string) ([]*events.Event, error) {
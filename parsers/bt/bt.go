@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "bt.com") {
+		return nil, common.NewParserError("not from bt.com")
+	}
+
 	// Check if email has parts (Python: if len(serialized_email['parts']))
 	if len(serializedEmail.Parts) == 0 {
 		return nil, nil
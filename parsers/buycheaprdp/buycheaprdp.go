@@ -28,6 +28,10 @@ func findStringWithoutMarkersToNewline(text, marker string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "buycheaprdp.com", "obhost.org") {
+		return nil, common.NewParserError("not from buycheaprdp.com or obhost.org")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cammodelprotect.com") {
+		return nil, common.NewParserError("not from cammodelprotect.com")
+	}
+
 	// Get body (throws=true to match Python behavior)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -78,12 +78,15 @@ func getParserData(body string) (string, [][]string, error) {
 		return "", nil, fmt.Errorf("end of entries not found in ccirc")
 	}
 
-	// Extract entries string
-	var entriesString string
+	// Extract entries string; empty if the header line is the last line
 	if isSimpleLinebreak {
-		entriesString = body[startOfEntries+1 : endOfEntries]
+		startOfEntries++
 	} else {
-		entriesString = body[startOfEntries+2 : endOfEntries]
+		startOfEntries += 2
+	}
+	var entriesString string
+	if startOfEntries < endOfEntries {
+		entriesString = body[startOfEntries:endOfEntries]
 	}
 
 	// Parse CSV
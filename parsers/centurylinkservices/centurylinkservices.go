@@ -246,6 +246,10 @@ func parseBody(body, subjectLower string) ([]*events.Event, error) {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "centurylinkservices.net") {
+		return nil, common.NewParserError("not from centurylinkservices.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
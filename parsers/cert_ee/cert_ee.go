@@ -10,6 +10,7 @@ import (
 	"github.com/abusix/inbound-parsers/pkg/email"
 	"github.com/abusix/inbound-parsers/events"
 	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/parsers/intelmq"
 )
 
 type Parser struct{}
@@ -19,6 +20,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert.ee") {
+		return nil, common.NewParserError("not from cert.ee")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -205,7 +210,7 @@ func (p *Parser) parseCSV(serializedEmail *email.SerializedEmail) ([]*events.Eve
 			eventType = events.NewCompromisedServer()
 		} else if strings.Contains(identifier, "accessible-http") {
 			eventType = events.NewCompromisedWebsite("")
-		} else {
+		} else if eventType = intelmq.EventType(intelmq.Record(entry)); eventType == nil {
 			return nil, common.NewNewTypeError(identifier)
 		}
 
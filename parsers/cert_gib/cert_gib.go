@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert-gib.com") {
+		return nil, common.NewParserError("not from cert-gib.com")
+	}
+
 	// Get body with throws=True (matching Python)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
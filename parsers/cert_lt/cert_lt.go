@@ -58,6 +58,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// Extract URL
 	url := common.GetNonEmptyLineAfter(body, "Please remove it from public access:")
+	if url == "" {
+		return nil, common.NewParserError("no URL to remove")
+	}
 	if len(url) > 1 && url[1] == '_' {
 		// Replace first underscore with 't'
 		url = string(url[0]) + "t" + url[2:]
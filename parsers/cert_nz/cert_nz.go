@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "govt.nz") {
+		return nil, common.NewParserError("not from govt.nz")
+	}
+
 	// Get body and subject (throws=true)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -13,6 +13,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "rcts.pt") {
+		return nil, common.NewParserError("not from rcts.pt")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 
 	event := events.NewEvent("cert_rcts")
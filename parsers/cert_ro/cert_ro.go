@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert.ro") {
+		return nil, common.NewParserError("not from cert.ro")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -26,6 +26,10 @@ func New(se email.SerializedEmail, fa, fn, ct string) *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert.gov.ua") {
+		return nil, common.NewParserError("not from cert.gov.ua")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 
 	"github.com/abusix/inbound-parsers/events"
 	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/parsers/intelmq"
 	"github.com/abusix/inbound-parsers/pkg/email"
 )
 
@@ -20,6 +21,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert.at") {
+		return nil, common.NewParserError("not from cert.at")
+	}
+
 	// First, try to find CSV attachment in parts
 	if len(serializedEmail.Parts) > 0 {
 		csvData, found := p.findCSVAttachment(serializedEmail.Parts)
@@ -213,6 +218,8 @@ func (p *Parser) createEventsFromCSV(data string, serializedEmail *email.Seriali
 		} else if strings.HasPrefix(classification, "open-") || strings.HasPrefix(classification, "accessible-") {
 			service := common.MapServiceStrings(classification)
 			event.EventTypes = []events.EventType{events.NewOpen(service)}
+		} else if eventType := intelmq.EventType(intelmq.Record(rowMap)); eventType != nil {
+			event.EventTypes = []events.EventType{eventType}
 		}
 
 		// Set IP and port
@@ -0,0 +1,26 @@
+package certat
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+func TestParse_IntelMQTaxonomyFallback(t *testing.T) {
+	// A brute-force row matches none of the cert.at specific rules
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"reports@cert.at"}},
+		Parts: []email.EmailPart{{
+			Headers: map[string][]string{"content-disposition": {`attachment; filename="events.csv"`}},
+			Body:    "time.source,source.ip,classification.taxonomy,classification.type\n2025-10-18T12:00:00+00:00,192.0.2.1,intrusion-attempts,brute-force\n",
+		}},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 1 || len(result[0].EventTypes) != 1 || result[0].EventTypes[0].GetName() != "login_attack" {
+		t.Errorf("Expected a login attack from the IntelMQ taxonomy, got %+v", result)
+	}
+}
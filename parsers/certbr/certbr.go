@@ -4,7 +4,6 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
-	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -27,6 +26,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cert.br") {
+		return nil, common.NewParserError("not from cert.br")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -112,7 +115,7 @@ func parsePortmap(subject string, serializedEmail *email.SerializedEmail) ([]*ev
 			}
 			defer rc.Close()
 
-			content, err := io.ReadAll(rc)
+			content, err := common.ReadDecoded(rc)
 			if err != nil {
 				return nil, common.NewParserError("could not read file from ZIP")
 			}
@@ -164,7 +167,7 @@ func parsePortmap(subject string, serializedEmail *email.SerializedEmail) ([]*ev
 			event.Port = *port
 		}
 		event.AddEventDetail(&events.ASN{ASN: strings.TrimSpace(asn)})
-		event.EventDate = parseMagicDateTime(dateTime)
+		event.EventDate = common.ParseDate(dateTime)
 
 		mainTypeLower := strings.ToLower(mainType)
 		subjectLower := strings.ToLower(subject)
@@ -210,7 +213,7 @@ func getSpecificDate(line string, mailYear int) *time.Time {
 	match := datePattern.FindStringSubmatch(line)
 	if match != nil && len(match) > 1 {
 		dateString := match[1]
-		return parseMagicDateTime(dateString)
+		return common.ParseDate(dateString)
 	}
 
 	// Try second pattern (MMM DD HH:mm:ss)
@@ -222,14 +225,14 @@ func getSpecificDate(line string, mailYear int) *time.Time {
 		year := strconv.Itoa(mailYear)
 
 		dateStr := fmt.Sprintf("%s %s %s %s", month, day, year, timeStr)
-		t := parseMagicDateTime(dateStr)
+		t := common.ParseDate(dateStr)
 		if t != nil {
 			// Check if date is in future
 			if t.After(time.Now()) {
 				// Try previous year
 				year = strconv.Itoa(mailYear - 1)
 				dateStr = fmt.Sprintf("%s %s %s %s", month, day, year, timeStr)
-				return parseMagicDateTime(dateStr)
+				return common.ParseDate(dateStr)
 			}
 		}
 		return t
@@ -260,7 +263,7 @@ func getEventsForRawBody(bodyRaw string) ([]*events.Event, error) {
 		dateStr := fields[0]
 		ipStr := fields[3]
 
-		date := parseMagicDateTime(dateStr)
+		date := common.ParseDate(dateStr)
 		ip := common.IsIP(ipStr)
 
 		if ip != "" && date != nil {
@@ -413,7 +416,7 @@ func getEvents(serializedEmail *email.SerializedEmail, body string) ([]*events.E
 		event := events.NewEvent("certbr")
 		event.EventTypes = []events.EventType{events.NewRogueDNS()}
 		event.IP = common.ExtractOneIP(subject)
-		event.EventDate = parseMagicDateTime(timestamp)
+		event.EventDate = common.ParseDate(timestamp)
 		eventsList = append(eventsList, event)
 
 		// Parse all log lines for phishing events
@@ -428,7 +431,7 @@ func getEvents(serializedEmail *email.SerializedEmail, body string) ([]*events.E
 			phishingIP := lineParts[4]
 
 			phishEvent := events.NewEvent("certbr")
-			phishEvent.EventDate = parseMagicDateTime(timestamp)
+			phishEvent.EventDate = common.ParseDate(timestamp)
 			phishingEventType := events.NewPhishing()
 			// Store official URL in a custom field - Go doesn't have this field in Phishing
 			// We'll add it as an event detail instead
@@ -482,46 +485,6 @@ func getEvents(serializedEmail *email.SerializedEmail, body string) ([]*events.E
 	}
 }
 
-// parseMagicDateTime attempts to parse datetime in various formats
-func parseMagicDateTime(dateStr string) *time.Time {
-	if dateStr == "" {
-		return nil
-	}
-
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common formats to try
-	formats := []string{
-		time.RFC3339,
-		time.RFC1123Z,
-		time.RFC1123,
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05.999999999",
-		"2006-01-02T15:04:05.999999999",
-		"Jan 2 2006 15:04:05",
-		"Jan 02 2006 15:04:05",
-		"2 Jan 2006 15:04:05",
-		"02 Jan 2006 15:04:05",
-		"Mon Jan 2 15:04:05 2006",
-		"Mon Jan 02 15:04:05 2006",
-		"2006-01-02",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t
-		}
-	}
-
-	// Try email.ParseDate for RFC 5322 formats
-	if t := email.ParseDate(dateStr); t != nil {
-		return t
-	}
-
-	return nil
-}
-
 // GetPriority returns the parser priority (lower numbers run first)
 func (p *Parser) GetPriority() int {
 	return 100 // Default vendor parser priority
@@ -18,6 +18,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "chaturbate.com") {
+		return nil, common.NewParserError("not from chaturbate.com")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
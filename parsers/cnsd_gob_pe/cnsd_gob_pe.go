@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cnsd.gob.pe") {
+		return nil, common.NewParserError("not from cnsd.gob.pe")
+	}
+
 	// Get email body (throws=True in Python)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
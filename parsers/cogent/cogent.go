@@ -4,7 +4,6 @@ import (
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
-	"io"
 	"regexp"
 	"strings"
 
@@ -27,6 +26,10 @@ func NewParser() *Parser {
 // 2. Simple phishing reports
 // 3. IP reclamation reports
 func (p *Parser) Parse(serializedEmail *pkgemail.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cogentco.com") {
+		return nil, common.NewParserError("not from cogentco.com")
+	}
+
 	subject, _ := common.GetSubject(serializedEmail, false)
 
 	// Try to find XML part (IODEF reports)
@@ -194,7 +197,7 @@ func parseIODEFReport(xmlPart *pkgemail.EmailPart, serializedEmail *pkgemail.Ser
 		}
 		defer reader.Close()
 
-		decompressed, err := io.ReadAll(reader)
+		decompressed, err := common.ReadDecoded(reader)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decompress XML: %w", err)
 		}
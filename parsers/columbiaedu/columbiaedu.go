@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "columbia.edu", "yahoo.com") {
+		return nil, common.NewParserError("not from columbia.edu or yahoo.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
 		return nil, common.NewParserError("no email body found")
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "comcast.net") {
+		return nil, common.NewParserError("not from comcast.net")
+	}
+
 	// Get subject
 	subject, err := common.GetSubject(serializedEmail, false)
 	if err != nil || subject == "" {
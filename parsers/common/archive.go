@@ -4,13 +4,51 @@ package common
 import (
 	"archive/zip"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"sync/atomic"
 
 	"github.com/abusix/inbound-parsers/pkg/email"
 )
 
+// DefaultMaxDecodedBytes is the default of SetMaxDecodedBytes
+const DefaultMaxDecodedBytes = 100 << 20
+
+// ErrDecodedTooLarge is returned when a decompressed attachment exceeds the
+// limit set with SetMaxDecodedBytes
+var ErrDecodedTooLarge = errors.New("decompressed attachment exceeds the size limit")
+
+var maxDecodedBytes atomic.Int64
+
+func init() {
+	maxDecodedBytes.Store(DefaultMaxDecodedBytes)
+}
+
+// SetMaxDecodedBytes limits the bytes read from one compressed attachment, so
+// a zip or gzip bomb fails instead of exhausting memory; 0 disables the limit
+func SetMaxDecodedBytes(limit int64) {
+	maxDecodedBytes.Store(limit)
+}
+
+// ReadDecoded reads a decompressing reader like io.ReadAll, failing with
+// ErrDecodedTooLarge beyond the SetMaxDecodedBytes limit
+func ReadDecoded(r io.Reader) ([]byte, error) {
+	limit := maxDecodedBytes.Load()
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, ErrDecodedTooLarge
+	}
+	return content, nil
+}
+
 // HandleZipPart extracts and returns the first file from a ZIP attachment
 func HandleZipPart(body interface{}) (string, error) {
 	var zipData []byte
@@ -41,7 +79,7 @@ func HandleZipPart(body interface{}) (string, error) {
 	}
 	defer rc.Close()
 
-	content, err := io.ReadAll(rc)
+	content, err := ReadDecoded(rc)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file from ZIP: %w", err)
 	}
@@ -49,49 +87,55 @@ func HandleZipPart(body interface{}) (string, error) {
 	return string(content), nil
 }
 
-// ExtractCSVFromEmail extracts CSV content from email parts
+// ExtractCSVFromEmail extracts CSV content from the first CSV or zipped CSV attachment.
+// Spaces in the header line are replaced with underscores; the data rows are returned unchanged.
 func ExtractCSVFromEmail(serializedEmail *email.SerializedEmail) (string, error) {
-	if len(serializedEmail.Parts) < 2 {
-		return "", NewParserError("CSV attachment not found")
-	}
-
-	part := serializedEmail.Parts[1]
-
-	// Check content type in headers
-	var contentType string
-	if part.Headers != nil {
-		if ct, ok := part.Headers["content-type"]; ok && len(ct) > 0 {
-			contentType = strings.ToLower(ct[0])
+	for _, part := range serializedEmail.Parts {
+		// Check content type in headers
+		var contentType string
+		if part.Headers != nil {
+			if ct, ok := part.Headers["content-type"]; ok && len(ct) > 0 {
+				contentType = strings.ToLower(ct[0])
+			}
 		}
-	}
-	// Fallback to part.ContentType
-	if contentType == "" {
-		contentType = strings.ToLower(part.ContentType)
-	}
-
-	var csvFile string
-	var err error
-
-	if strings.Contains(contentType, "zip") {
-		csvFile, err = HandleZipPart(part.Body)
-		if err != nil {
-			return "", err
+		// Fallback to part.ContentType
+		if contentType == "" {
+			contentType = strings.ToLower(part.ContentType)
 		}
-	} else if strings.Contains(contentType, "csv") {
-		switch body := part.Body.(type) {
-		case string:
-			csvFile = body
-		case []byte:
-			csvFile = string(body)
-		default:
-			return "", NewParserError("unexpected CSV body type")
+
+		var csvFile string
+		var err error
+
+		if strings.Contains(contentType, "zip") {
+			csvFile, err = HandleZipPart(part.Body)
+			if err != nil {
+				return "", err
+			}
+		} else if strings.Contains(contentType, "csv") {
+			switch body := part.Body.(type) {
+			case string:
+				csvFile = body
+			case []byte:
+				csvFile = string(body)
+			default:
+				return "", NewParserError("unexpected CSV body type")
+			}
+		} else {
+			continue
 		}
-	} else {
-		return "", NewParserError("CSV attachment not found")
+
+		return underscoreCSVHeader(csvFile), nil
 	}
 
-	// Replace spaces with underscores
-	csvFile = strings.ReplaceAll(csvFile, " ", "_")
+	return "", NewParserError("CSV attachment not found")
+}
 
-	return csvFile, nil
+// underscoreCSVHeader replaces spaces with underscores in the first line of a CSV document
+func underscoreCSVHeader(csvFile string) string {
+	csvFile = strings.TrimPrefix(csvFile, "\ufeff")
+	headerEnd := strings.Index(csvFile, "\n")
+	if headerEnd == -1 {
+		return strings.ReplaceAll(csvFile, " ", "_")
+	}
+	return strings.ReplaceAll(csvFile[:headerEnd], " ", "_") + csvFile[headerEnd:]
 }
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+)
+
+func TestReadDecoded_Limit(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write(make([]byte, 1<<20))
+	_ = writer.Close()
+
+	SetMaxDecodedBytes(1 << 10)
+	defer SetMaxDecodedBytes(DefaultMaxDecodedBytes)
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadDecoded(reader); !errors.Is(err, ErrDecodedTooLarge) {
+		t.Errorf("Expected ErrDecodedTooLarge, got %v", err)
+	}
+
+	SetMaxDecodedBytes(2 << 20)
+	reader, _ = gzip.NewReader(bytes.NewReader(compressed.Bytes()))
+	if content, err := ReadDecoded(reader); err != nil || len(content) != 1<<20 {
+		t.Errorf("Expected 1 MiB, got %d bytes, %v", len(content), err)
+	}
+}
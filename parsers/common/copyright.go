@@ -0,0 +1,505 @@
+// Package common provides a shared copyright notice model for ACNS and DMCA notices
+package common
+
+import (
+	"encoding/xml"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// CopyrightNotice is a copyright infringement notice extracted from ACNS XML or
+// a plain-text DMCA notice. Vendor parsers annotate it (owner, protocol) and
+// turn it into events with Events.
+type CopyrightNotice struct {
+	// Format is "acns" or "dmca"
+	Format string
+
+	CaseID   string
+	Status   string
+	Severity string
+
+	Complainant          string
+	ComplainantEmail     string
+	ServiceProvider      string
+	ServiceProviderEmail string
+
+	// Owner and Protocol apply to all items that do not carry their own
+	Owner    string
+	Protocol string
+
+	IP     string
+	Port   int
+	Host   string
+	Date   *time.Time
+	PeerID string
+	Client string
+
+	Items []CopyrightItem
+
+	// InfringingURLs and OriginalURLs are listed by plain-text notices
+	InfringingURLs []string
+	OriginalURLs   []string
+	// Statement512 is set when the notice contains the 17 U.S.C. 512(c)(3)
+	// good faith and penalty of perjury statements
+	Statement512 bool
+}
+
+// CopyrightItem is one infringing work of a notice
+type CopyrightItem struct {
+	Title     string
+	FileName  string
+	FileSize  string
+	Hash      string
+	HashType  string
+	InfoHash  string
+	URL       string
+	TimeStamp string
+}
+
+// acnsInfringement is the ACNS 2.x XML structure
+type acnsInfringement struct {
+	Case struct {
+		ID       string `xml:"ID"`
+		Status   string `xml:"Status"`
+		Severity string `xml:"Severity"`
+	} `xml:"Case"`
+	Complainant *struct {
+		Entity string `xml:"Entity"`
+		Owner  string `xml:"Owner"`
+		Email  string `xml:"Email"`
+	} `xml:"Complainant"`
+	ServiceProvider *struct {
+		Entity string `xml:"Entity"`
+		Email  string `xml:"Email"`
+	} `xml:"Service_Provider"`
+	Source struct {
+		TimeStamp string `xml:"TimeStamp"`
+		IPAddress string `xml:"IP_Address"`
+		Port      string `xml:"Port"`
+		DNSName   string `xml:"DNS_Name"`
+		Type      string `xml:"Type"`
+		SubType   struct {
+			Protocol string `xml:"Protocol,attr"`
+		} `xml:"SubType"`
+		PeerID string `xml:"Peer_ID"`
+		Client string `xml:"Client"`
+	} `xml:"Source"`
+	Content struct {
+		Item        []acnsItem `xml:"Item"`
+		ContentItem []acnsItem `xml:"Content_Item"`
+	} `xml:"Content"`
+}
+
+type acnsItem struct {
+	TimeStamp string `xml:"TimeStamp"`
+	Title     string `xml:"Title"`
+	FileName  string `xml:"FileName"`
+	FileSize  string `xml:"FileSize"`
+	URL       string `xml:"URL"`
+	Hashes    []struct {
+		Type  string `xml:"Type,attr"`
+		TypeL string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"Hash"`
+}
+
+var (
+	acnsPattern         = regexp.MustCompile(`(?s)<\?xml[\s\S]*?<[iI]nfringement[\s\S]*?</[iI]nfringement>`)
+	xmlAmpersandPattern = regexp.MustCompile(`&(#[0-9]+;|#x[0-9a-fA-F]+;|[a-zA-Z]+;)?`)
+	noticeURLPattern    = regexp.MustCompile(`(?i)\b(?:https?|ftp|magnet):[^\s<>"']+`)
+)
+
+// FindCopyrightNotices returns the ACNS notices of an email, falling back to
+// a plain-text DMCA notice in the body
+func FindCopyrightNotices(serializedEmail *email.SerializedEmail) ([]*CopyrightNotice, error) {
+	if notices, err := FindACNSNotices(serializedEmail); err == nil {
+		return notices, nil
+	}
+
+	body, _ := GetBody(serializedEmail, false)
+	notice, err := ExtractDMCANotice(body)
+	if err != nil {
+		return nil, err
+	}
+	return []*CopyrightNotice{notice}, nil
+}
+
+// FindACNSNotices returns the ACNS notices of the body, or of the first part containing any
+func FindACNSNotices(serializedEmail *email.SerializedEmail) ([]*CopyrightNotice, error) {
+	body, _ := GetBody(serializedEmail, false)
+	notices, err := ExtractACNSNotices(body)
+	if err == nil {
+		return notices, nil
+	}
+
+	for _, part := range serializedEmail.Parts {
+		var partBody string
+		switch b := part.Body.(type) {
+		case string:
+			partBody = b
+		case []byte:
+			partBody = string(b)
+		}
+		if partNotices, partErr := ExtractACNSNotices(partBody); partErr == nil {
+			return partNotices, nil
+		}
+	}
+	return nil, err
+}
+
+// ExtractACNSNotices parses all ACNS XML documents in text. Quoted (">")
+// documents, stray <br> tags and unescaped ampersands are tolerated.
+func ExtractACNSNotices(text string) ([]*CopyrightNotice, error) {
+	matches := acnsPattern.FindAllString(text, -1)
+	if len(matches) == 0 {
+		return nil, NewParserError("no ACNS XML found")
+	}
+
+	var notices []*CopyrightNotice
+	for _, match := range matches {
+		content := strings.ReplaceAll(match, "<br>", "")
+		if isQuotedText(content) {
+			content = unquoteText(content)
+		}
+		content = xmlAmpersandPattern.ReplaceAllStringFunc(content, func(entity string) string {
+			if entity == "&" {
+				return "&amp;"
+			}
+			return entity
+		})
+
+		var infringement acnsInfringement
+		if err := xml.Unmarshal([]byte(content), &infringement); err != nil {
+			continue
+		}
+		notices = append(notices, acnsNotice(&infringement))
+	}
+
+	if len(notices) == 0 {
+		return nil, NewParserError("failed to parse ACNS XML")
+	}
+	return notices, nil
+}
+
+func acnsNotice(inf *acnsInfringement) *CopyrightNotice {
+	notice := &CopyrightNotice{
+		Format:   "acns",
+		CaseID:   inf.Case.ID,
+		Status:   inf.Case.Status,
+		Severity: inf.Case.Severity,
+		Protocol: inf.Source.Type,
+		Host:     strings.TrimSpace(inf.Source.DNSName),
+		Date:     ParseDate(inf.Source.TimeStamp),
+		PeerID:   strings.TrimSpace(inf.Source.PeerID),
+		Client:   strings.TrimSpace(inf.Source.Client),
+	}
+
+	address := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(inf.Source.IPAddress), "http://"), "https://")
+	if ip := net.ParseIP(address); ip != nil {
+		notice.IP = ip.String()
+	}
+	if port, err := strconv.Atoi(strings.TrimSpace(inf.Source.Port)); err == nil {
+		notice.Port = port
+	}
+
+	if inf.Complainant != nil {
+		notice.Complainant = inf.Complainant.Entity
+		notice.ComplainantEmail = inf.Complainant.Email
+		notice.Owner = strings.TrimSpace(inf.Complainant.Owner)
+	}
+	if inf.ServiceProvider != nil {
+		notice.ServiceProvider = inf.ServiceProvider.Entity
+		notice.ServiceProviderEmail = strings.NewReplacer("\n", "", "\r", "").Replace(inf.ServiceProvider.Email)
+	}
+
+	items := inf.Content.Item
+	if len(items) == 0 {
+		items = inf.Content.ContentItem
+	}
+	bittorrent := strings.Contains(strings.ToLower(inf.Source.Type+inf.Source.SubType.Protocol), "bittorrent")
+	for _, item := range items {
+		copyrightItem := CopyrightItem{
+			Title:     item.Title,
+			FileName:  item.FileName,
+			FileSize:  item.FileSize,
+			URL:       strings.TrimSpace(item.URL),
+			TimeStamp: item.TimeStamp,
+		}
+		for _, hash := range item.Hashes {
+			hashType := strings.ToLower(hash.Type + hash.TypeL)
+			value := strings.TrimSpace(hash.Value)
+			if value == "" {
+				continue
+			}
+			// BitTorrent notices carry the torrent info hash as SHA1
+			if strings.Contains(hashType, "infohash") || strings.Contains(hashType, "btih") || (bittorrent && hashType == "sha1") {
+				copyrightItem.InfoHash = value
+			}
+			if copyrightItem.Hash == "" {
+				copyrightItem.Hash = value
+				copyrightItem.HashType = hashType
+			}
+		}
+		notice.Items = append(notice.Items, copyrightItem)
+	}
+	return notice
+}
+
+// Markers of plain-text DMCA notices, matched case-insensitively at line start
+var (
+	dmcaTitleMarkers      = []string{"copyrighted work:", "infringed work:", "original work:", "work title:", "title:", "work:"}
+	dmcaOwnerMarkers      = []string{"copyright owner:", "rights owner:", "rights holder:", "rightsholder:", "copyright holder:"}
+	dmcaIPMarkers         = []string{"ip address:", "ip:"}
+	dmcaPortMarkers       = []string{"port:"}
+	dmcaProtocolMarkers   = []string{"protocol:"}
+	dmcaCaseMarkers       = []string{"notice id:", "case id:", "reference number:", "reference:", "ticket id:"}
+	dmcaDateMarkers       = []string{"timestamp:", "date/time:", "infringement date:", "date of infringement:", "last found (utc):", "last found:"}
+	dmcaInfringingHeaders = []string{"infringing url", "infringing material", "infringing content", "location of infringing", "reported url"}
+	dmcaOriginalHeaders   = []string{"original url", "original work url", "original material", "authorized url", "original location", "location of the original"}
+)
+
+// ExtractDMCANotice extracts a plain-text DMCA notice. Infringing and original
+// URLs are taken from their labelled blocks; without labels every URL of the
+// text counts as infringing.
+func ExtractDMCANotice(text string) (*CopyrightNotice, error) {
+	text = strings.ReplaceAll(text, "\r", "")
+	lower := strings.ToLower(text)
+
+	notice := &CopyrightNotice{
+		Format:       "dmca",
+		Statement512: strings.Contains(lower, "good faith belief") && strings.Contains(lower, "penalty of perjury"),
+	}
+
+	lines := strings.Split(text, "\n")
+	notice.Items = noticeTitles(lines)
+	notice.Owner = labelledValue(lines, dmcaOwnerMarkers)
+	notice.Protocol = labelledValue(lines, dmcaProtocolMarkers)
+	notice.CaseID = labelledValue(lines, dmcaCaseMarkers)
+	notice.Date = ParseDate(labelledValue(lines, dmcaDateMarkers))
+	if ip := net.ParseIP(labelledValue(lines, dmcaIPMarkers)); ip != nil {
+		notice.IP = ip.String()
+	}
+	if port, err := strconv.Atoi(labelledValue(lines, dmcaPortMarkers)); err == nil {
+		notice.Port = port
+	}
+
+	notice.OriginalURLs = labelledURLs(lines, dmcaOriginalHeaders)
+	notice.InfringingURLs = labelledURLs(lines, dmcaInfringingHeaders)
+	if len(notice.InfringingURLs) == 0 && notice.IP == "" {
+		original := make(map[string]bool)
+		for _, url := range notice.OriginalURLs {
+			original[url] = true
+		}
+		for _, url := range uniqueURLs(noticeURLPattern.FindAllString(text, -1)) {
+			if !original[url] {
+				notice.InfringingURLs = append(notice.InfringingURLs, url)
+			}
+		}
+	}
+
+	if len(notice.InfringingURLs) == 0 && notice.IP == "" {
+		return nil, NewParserError("no infringing URL or IP found in copyright notice")
+	}
+	return notice, nil
+}
+
+// noticeTitles returns an item per title line; titles may span a block of lines
+func noticeTitles(lines []string) []CopyrightItem {
+	var items []CopyrightItem
+	for i, line := range lines {
+		value, ok := cutMarker(line, dmcaTitleMarkers)
+		if !ok {
+			continue
+		}
+		if value != "" {
+			items = append(items, CopyrightItem{Title: value})
+			continue
+		}
+		// "Title:" on its own line followed by one title per line
+		for _, next := range lines[i+1:] {
+			next = strings.TrimSpace(next)
+			if next == "" || strings.HasSuffix(next, ":") {
+				break
+			}
+			items = append(items, CopyrightItem{Title: strings.TrimLeft(next, "-*• ")})
+		}
+	}
+	return items
+}
+
+// labelledValue returns the value after the first marker found at a line start
+func labelledValue(lines []string, markers []string) string {
+	for _, line := range lines {
+		if value, ok := cutMarker(line, markers); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// labelledURLs returns the URLs on and below lines containing one of the headers,
+// up to the next empty line
+func labelledURLs(lines []string, headers []string) []string {
+	var urls []string
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		matched := false
+		for _, header := range headers {
+			if strings.Contains(lower, header) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		urls = append(urls, noticeURLPattern.FindAllString(line, -1)...)
+		started := false
+		for _, next := range lines[i+1:] {
+			if strings.TrimSpace(next) == "" {
+				if started {
+					break
+				}
+				continue
+			}
+			found := noticeURLPattern.FindAllString(next, -1)
+			if len(found) == 0 {
+				break
+			}
+			started = true
+			urls = append(urls, found...)
+		}
+	}
+	return uniqueURLs(urls)
+}
+
+func cutMarker(line string, markers []string) (string, bool) {
+	trimmed := strings.TrimSpace(strings.TrimLeft(line, "-*• "))
+	lower := strings.ToLower(trimmed)
+	for _, marker := range markers {
+		if strings.HasPrefix(lower, marker) {
+			return strings.TrimSpace(trimmed[len(marker):]), true
+		}
+	}
+	return "", false
+}
+
+func uniqueURLs(urls []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, url := range urls {
+		url = strings.TrimRight(url, ".,;)>]")
+		if !seen[url] {
+			seen[url] = true
+			result = append(result, url)
+		}
+	}
+	return result
+}
+
+// isQuotedText reports whether at least 90% of the lines are quoted with '>'
+func isQuotedText(text string) bool {
+	lines := strings.Split(text, "\n")
+	quoted := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, ">") {
+			quoted++
+		}
+	}
+	return float64(quoted)/float64(len(lines)) >= 0.9
+}
+
+// unquoteText strips the quote character of every line but the first
+func unquoteText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if len(lines[i]) > 0 {
+			lines[i] = lines[i][1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Events creates one copyright event per item (ACNS) or per infringing URL
+// (DMCA), carrying the case, complainant, torrent and file details
+func (n *CopyrightNotice) Events(parserName string) ([]*events.Event, error) {
+	newEvent := func(item CopyrightItem) *events.Event {
+		event := events.NewEvent(parserName)
+		event.IP = n.IP
+		event.Port = n.Port
+		event.EventDate = n.Date
+		event.EventTypes = []events.EventType{events.NewCopyright(item.Title, n.Owner, n.Protocol)}
+
+		if n.Protocol != "" || n.PeerID != "" || n.Client != "" {
+			event.AddEventDetail(&events.Torrent{Protocol: n.Protocol, PeerID: n.PeerID, Client: n.Client})
+		}
+		if n.Format == "acns" || n.CaseID != "" {
+			event.AddEventDetail(&events.ExternalCaseInformation{CaseID: n.CaseID, Status: n.Status, Severity: n.Severity})
+		}
+		if n.Complainant != "" || n.ComplainantEmail != "" {
+			event.AddEventDetail(&events.Organisation{Name: "reporter", Organisation: n.Complainant, ContactEmail: n.ComplainantEmail})
+		}
+		if n.ServiceProvider != "" || n.ServiceProviderEmail != "" {
+			event.AddEventDetail(&events.OnBehalfOf{ComplainantEmail: n.ServiceProviderEmail, ComplainantContact: n.ServiceProvider})
+		}
+		return event
+	}
+
+	var eventsList []*events.Event
+	for _, item := range n.Items {
+		if item.URL == "" && n.IP == "" && len(n.InfringingURLs) > 0 {
+			// Plain-text notices: the titles apply to the listed URLs below
+			continue
+		}
+		event := newEvent(item)
+		if item.URL != "" {
+			event.URL = item.URL
+		} else if n.IP == "" {
+			return nil, NewParserError("no IP or URL found")
+		}
+
+		fileHash := item.Hash
+		if item.InfoHash != "" {
+			fileHash = item.InfoHash
+		}
+		if n.Format == "acns" || item.FileName != "" || item.FileSize != "" || fileHash != "" {
+			event.AddEventDetail(&events.File{FileName: item.FileName, FileSize: item.FileSize, FileHash: fileHash})
+		}
+		if item.TimeStamp != "" {
+			evidence := &events.Evidence{}
+			evidence.AddEvidence(events.UrlStore{Description: "time_stamp", URL: item.TimeStamp})
+			event.AddEventDetail(evidence)
+		}
+		eventsList = append(eventsList, event)
+	}
+
+	if len(eventsList) == 0 {
+		work := ""
+		if len(n.Items) > 0 {
+			work = n.Items[0].Title
+		}
+		for _, url := range n.InfringingURLs {
+			event := newEvent(CopyrightItem{Title: work})
+			event.URL = url
+			if len(n.OriginalURLs) > 0 {
+				event.EventTypes[0].(*events.Copyright).OfficialURL = n.OriginalURLs[0]
+			}
+			eventsList = append(eventsList, event)
+		}
+		if len(eventsList) == 0 && n.IP != "" {
+			eventsList = append(eventsList, newEvent(CopyrightItem{Title: work}))
+		}
+	}
+
+	if len(eventsList) == 0 {
+		return nil, NewParserError("copyright notice contains no items")
+	}
+	return eventsList, nil
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+const acnsSample = `Dear Service Provider,
+
+- ----Start ACNS XML
+<?xml version="1.0" encoding="UTF-8"?>
+<Infringement xmlns="http://www.acns.net/ACNS">
+  <Case><ID>A1B2C3</ID><Status>Open</Status><Severity>Normal</Severity></Case>
+  <Complainant><Entity>Studio & Partners</Entity><Owner>Example Studios</Owner><Email>antipiracy@example.com</Email></Complainant>
+  <Service_Provider><Entity>Example ISP</Entity><Email>abuse@isp.example</Email></Service_Provider>
+  <Source>
+    <TimeStamp>2025-10-18T12:00:00Z</TimeStamp>
+    <IP_Address>192.0.2.44</IP_Address>
+    <Port>51413</Port>
+    <Type>BitTorrent</Type>
+    <SubType BaseType="P2P" Protocol="BITTORRENT"/>
+    <Peer_ID>-TR2940-abcdef</Peer_ID>
+  </Source>
+  <Content>
+    <Item>
+      <Title>Example Movie</Title>
+      <FileName>Example.Movie.2025.mkv</FileName>
+      <FileSize>1500000000</FileSize>
+      <Hash Type="SHA1">0123456789abcdef0123456789abcdef01234567</Hash>
+    </Item>
+    <Item>
+      <Title>Example Movie Extras</Title>
+      <FileName>Extras.mkv</FileName>
+    </Item>
+  </Content>
+</Infringement>
+- ----End ACNS XML`
+
+func TestExtractACNSNotices_MultipleItems(t *testing.T) {
+	notices, err := ExtractACNSNotices(acnsSample)
+	if err != nil {
+		t.Fatalf("ExtractACNSNotices failed: %v", err)
+	}
+	if len(notices) != 1 {
+		t.Fatalf("Expected 1 notice, got %d", len(notices))
+	}
+
+	notice := notices[0]
+	if notice.IP != "192.0.2.44" || notice.Port != 51413 || notice.CaseID != "A1B2C3" {
+		t.Errorf("Unexpected notice source: %+v", notice)
+	}
+	if notice.Complainant != "Studio & Partners" || notice.Owner != "Example Studios" || notice.PeerID != "-TR2940-abcdef" {
+		t.Errorf("Unexpected notice parties: %+v", notice)
+	}
+	if len(notice.Items) != 2 || notice.Items[0].InfoHash != "0123456789abcdef0123456789abcdef01234567" {
+		t.Fatalf("Unexpected items: %+v", notice.Items)
+	}
+
+	result, err := notice.Events("acns")
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+	copyright := result[1].EventTypes[0].(*events.Copyright)
+	if copyright.CopyrightedWork != "Example Movie Extras" || copyright.CopyrightOwner != "Example Studios" {
+		t.Errorf("Unexpected copyright type: %+v", copyright)
+	}
+	if result[0].EventDate == nil || result[0].EventDate.Hour() != 12 {
+		t.Errorf("Expected ISO timestamp to be parsed, got %v", result[0].EventDate)
+	}
+}
+
+func TestExtractDMCANotice(t *testing.T) {
+	text := `DMCA Notice of Copyright Infringement
+
+Copyright owner: Example Records
+Title: Example Album
+
+Original URL: https://records.example/album
+
+Infringing URLs:
+https://files.example/dl/album.zip
+https://files.example/dl/album-mirror.zip
+
+I have a good faith belief that use of the material is not authorized.
+Under penalty of perjury, I am authorized to act on behalf of the owner.`
+
+	notice, err := ExtractDMCANotice(text)
+	if err != nil {
+		t.Fatalf("ExtractDMCANotice failed: %v", err)
+	}
+	if !notice.Statement512 || notice.Owner != "Example Records" {
+		t.Errorf("Unexpected notice: %+v", notice)
+	}
+	if len(notice.InfringingURLs) != 2 || len(notice.OriginalURLs) != 1 {
+		t.Fatalf("Unexpected URLs: %v / %v", notice.InfringingURLs, notice.OriginalURLs)
+	}
+
+	result, err := notice.Events("dmca_vendor")
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	if len(result) != 2 || result[1].URL != "https://files.example/dl/album-mirror.zip" {
+		t.Fatalf("Unexpected events: %+v", result)
+	}
+	copyright := result[0].EventTypes[0].(*events.Copyright)
+	if copyright.CopyrightedWork != "Example Album" || copyright.OfficialURL != "https://records.example/album" {
+		t.Errorf("Unexpected copyright type: %+v", copyright)
+	}
+
+	if _, err := ExtractDMCANotice("no urls here"); err == nil {
+		t.Error("Expected error for notice without infringing material")
+	}
+}
@@ -0,0 +1,434 @@
+// Package common provides a dialect-sniffing streaming CSV reader
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// csvDelimiters are the delimiters considered when sniffing, in order of preference on ties
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+// csvSniffLines is the number of leading non-comment lines inspected to detect the dialect
+const csvSniffLines = 20
+
+// csvSniffBytes is the number of leading bytes inspected to detect the encoding
+const csvSniffBytes = 4096
+
+// csvMaxHeaderRunes is the length above which a field is taken for free text
+// rather than a header name
+const csvMaxHeaderRunes = 64
+
+// CSVOptions configures a CSVReader. The zero value sniffs everything.
+type CSVOptions struct {
+	// Delimiter forces the field delimiter, 0 sniffs it from the data
+	Delimiter rune
+	// CommentPrefixes are line prefixes skipped before the header and between rows.
+	// Defaults to "#" when nil.
+	CommentPrefixes []string
+	// RawHeaders keeps header names as they appear (only trimmed) instead of normalising them
+	RawHeaders bool
+	// HeaderContains skips preamble lines until a line containing this string is found
+	HeaderContains string
+}
+
+// CSVReader streams CSV rows as maps keyed by header name.
+// It detects and strips BOMs, decodes UTF-16 and Latin-1 input, sniffs the
+// delimiter, skips comment and preamble lines, tolerates rows with too few or
+// too many fields and makes duplicate header names unique.
+type CSVReader struct {
+	reader    *csv.Reader
+	header    []string
+	rawHeader []string
+	comments  []string
+	delimiter rune
+	line      int
+	skipped   int
+}
+
+// NewCSVReader creates a CSVReader, consuming input up to and including the header line
+func NewCSVReader(r io.Reader, opts *CSVOptions) (*CSVReader, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	comments := opts.CommentPrefixes
+	if comments == nil {
+		comments = []string{"#"}
+	}
+
+	decoded, err := decodeCSVInput(r)
+	if err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReader(decoded)
+
+	// Collect sniffing candidates, dropping blank and comment lines before the header
+	var sample []string
+	skipped := 0
+	for len(sample) < csvSniffLines {
+		line, readErr := buffered.ReadString('\n')
+		if line != "" {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case len(sample) == 0 && (trimmed == "" || hasAnyPrefix(trimmed, comments)):
+				skipped++
+			case len(sample) == 0 && opts.HeaderContains != "" && !strings.Contains(line, opts.HeaderContains):
+				skipped++
+			default:
+				sample = append(sample, strings.TrimRight(line, "\r\n"))
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	if len(sample) == 0 {
+		return nil, NewParserError("no CSV data found")
+	}
+
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		delimiter = SniffCSVDelimiter(sample)
+	}
+
+	// Preamble lines (free text before the table) do not contain the delimiter
+	// as often as the rows do. A header can have fewer fields than ragged rows,
+	// so a short line is only skipped if it does not look like a header either.
+	if opts.HeaderContains == "" {
+		expected := modalFieldCount(sample, delimiter)
+		for len(sample) > 1 {
+			n := countDelimiters(sample[0], delimiter)
+			if n >= expected || (n > 0 && looksLikeCSVHeader(sample[0], delimiter)) {
+				break
+			}
+			sample = sample[1:]
+			skipped++
+		}
+	}
+
+	rest := io.MultiReader(strings.NewReader(strings.Join(sample, "\n")+"\n"), buffered)
+	reader := csv.NewReader(rest)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = delimiter != '\t'
+	reader.ReuseRecord = true
+	if len(comments) == 1 && utf8.RuneCountInString(comments[0]) == 1 {
+		reader.Comment, _ = utf8.DecodeRuneInString(comments[0])
+	}
+
+	rawHeader, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	cr := &CSVReader{
+		reader:    reader,
+		rawHeader: make([]string, len(rawHeader)),
+		comments:  comments,
+		delimiter: delimiter,
+		line:      skipped + 1,
+		skipped:   skipped,
+	}
+	names := make([]string, len(rawHeader))
+	for i, name := range rawHeader {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		cr.rawHeader[i] = name
+		if !opts.RawHeaders {
+			name = NormalizeCSVHeader(name)
+		}
+		if name == "" {
+			name = "column_" + strconv.Itoa(i+1)
+		}
+		names[i] = name
+	}
+	cr.header = uniqueHeaderNames(names)
+
+	return cr, nil
+}
+
+// NewCSVReaderFromString creates a CSVReader over an in-memory CSV document
+func NewCSVReaderFromString(data string, opts *CSVOptions) (*CSVReader, error) {
+	return NewCSVReader(strings.NewReader(data), opts)
+}
+
+// Header returns the (normalised, unique) header names used as row keys
+func (r *CSVReader) Header() []string {
+	return r.header
+}
+
+// RawHeader returns the header names as they appear in the input
+func (r *CSVReader) RawHeader() []string {
+	return r.rawHeader
+}
+
+// Delimiter returns the field delimiter in use
+func (r *CSVReader) Delimiter() rune {
+	return r.delimiter
+}
+
+// Line returns the input line number of the last row returned by Read
+func (r *CSVReader) Line() int {
+	return r.line
+}
+
+// Read returns the next row keyed by header name, or io.EOF when the input is exhausted.
+// Missing trailing fields are returned as empty strings and surplus fields are dropped.
+func (r *CSVReader) Read() (map[string]string, error) {
+	for {
+		record, err := r.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.reader.FieldPos(0)
+		r.line = line + r.skipped
+
+		if isBlankRecord(record) || hasAnyPrefix(strings.TrimSpace(record[0]), r.comments) {
+			continue
+		}
+
+		row := make(map[string]string, len(r.header))
+		for i, key := range r.header {
+			if i < len(record) {
+				row[key] = strings.TrimSpace(record[i])
+			} else {
+				row[key] = ""
+			}
+		}
+		return row, nil
+	}
+}
+
+// ForEach calls fn for every remaining row, stopping at the first error returned by fn
+func (r *CSVReader) ForEach(fn func(row map[string]string) error) error {
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadAll reads all remaining rows. Prefer Read or ForEach for large feeds.
+func (r *CSVReader) ReadAll() ([]map[string]string, error) {
+	var rows []map[string]string
+	err := r.ForEach(func(row map[string]string) error {
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+var headerSeparatorPattern = regexp.MustCompile(`[\s\-]+`)
+
+// NormalizeCSVHeader turns a header name into a lower-case, underscore-separated key,
+// e.g. "Source IP" -> "source_ip", "Last-Seen (UTC)" -> "last_seen_(utc)".
+// Dots are kept so dotted taxonomies such as "source.ip" survive.
+func NormalizeCSVHeader(name string) string {
+	name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	name = strings.Trim(name, `"'`)
+	name = strings.ToLower(name)
+	name = headerSeparatorPattern.ReplaceAllString(name, "_")
+	return strings.Trim(name, "_")
+}
+
+// SniffCSVDelimiter picks the delimiter that splits the sample lines into the most
+// consistent number of fields, preferring ',' on ties
+func SniffCSVDelimiter(lines []string) rune {
+	best := ','
+	bestScore := 0
+	for _, delimiter := range csvDelimiters {
+		counts := make(map[int]int)
+		for _, line := range lines {
+			if n := countDelimiters(line, delimiter); n > 0 {
+				counts[n]++
+			}
+		}
+		// Score: number of lines agreeing on the modal count, weighted by that count
+		for n, agreeing := range counts {
+			if score := agreeing*1000 + n; score > bestScore {
+				bestScore = score
+				best = delimiter
+			}
+		}
+	}
+	return best
+}
+
+// modalFieldCount returns the most common non-zero delimiter count among lines
+func modalFieldCount(lines []string, delimiter rune) int {
+	counts := make(map[int]int)
+	for _, line := range lines {
+		if n := countDelimiters(line, delimiter); n > 0 {
+			counts[n]++
+		}
+	}
+	mode, modeCount := 0, 0
+	for n, c := range counts {
+		if c > modeCount || (c == modeCount && n > mode) {
+			mode, modeCount = n, c
+		}
+	}
+	return mode
+}
+
+// looksLikeCSVHeader reports whether a line splits into short, non-empty
+// names rather than into the clauses of a sentence
+func looksLikeCSVHeader(line string, delimiter rune) bool {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	fields, err := reader.Read()
+	if err != nil {
+		return false
+	}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" || utf8.RuneCountInString(field) > csvMaxHeaderRunes || strings.ContainsAny(field[len(field)-1:], ".:!?") {
+			return false
+		}
+	}
+	return true
+}
+
+// countDelimiters counts delimiters outside of double-quoted sections
+func countDelimiters(line string, delimiter rune) int {
+	count := 0
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == delimiter && !quoted:
+			count++
+		}
+	}
+	return count
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeCSVInput strips byte order marks and converts UTF-16 and Latin-1 input to UTF-8
+func decodeCSVInput(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReaderSize(r, csvSniffBytes)
+	head, err := buffered.Peek(csvSniffBytes)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+		_, _ = buffered.Discard(3)
+		return buffered, nil
+	case bytes.HasPrefix(head, []byte{0xFF, 0xFE}), bytes.HasPrefix(head, []byte{0xFE, 0xFF}):
+		decoder := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+		return transform.NewReader(buffered, decoder), nil
+	}
+
+	if endianness, ok := sniffUTF16(head); ok {
+		decoder := unicode.UTF16(endianness, unicode.IgnoreBOM).NewDecoder()
+		return transform.NewReader(buffered, decoder), nil
+	}
+
+	if !utf8.Valid(trimIncompleteRune(head)) {
+		return transform.NewReader(buffered, charmap.Windows1252.NewDecoder()), nil
+	}
+
+	// The head is UTF-8, but Latin-1 values may still follow it
+	return transform.NewReader(buffered, windows1252Fallback{}), nil
+}
+
+// windows1252Fallback passes valid UTF-8 through and decodes the bytes that
+// are not valid UTF-8 as Windows-1252
+type windows1252Fallback struct{ transform.NopResetter }
+
+func (windows1252Fallback) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 {
+			if !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			r = charmap.Windows1252.DecodeByte(src[nSrc])
+		}
+		if nDst+utf8.RuneLen(r) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += utf8.EncodeRune(dst[nDst:], r)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// sniffUTF16 detects BOM-less UTF-16 by the NUL bytes ASCII text leaves in every other byte
+func sniffUTF16(head []byte) (unicode.Endianness, bool) {
+	if len(head) < 4 {
+		return unicode.LittleEndian, false
+	}
+	evenZero, oddZero := 0, 0
+	pairs := len(head) / 2
+	for i := 0; i+1 < len(head); i += 2 {
+		if head[i] == 0 {
+			evenZero++
+		}
+		if head[i+1] == 0 {
+			oddZero++
+		}
+	}
+	switch {
+	case oddZero*10 > pairs*7 && evenZero*10 < pairs:
+		return unicode.LittleEndian, true
+	case evenZero*10 > pairs*7 && oddZero*10 < pairs:
+		return unicode.BigEndian, true
+	}
+	return unicode.LittleEndian, false
+}
+
+// trimIncompleteRune drops a multi-byte sequence cut off at the end of a sniffing buffer
+func trimIncompleteRune(b []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return b[:len(b)-i]
+			}
+			break
+		}
+	}
+	return b
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestCSVReader_SniffsDialectAndSkipsPreamble(t *testing.T) {
+	data := "\ufeff# generated by CERT feed\n" +
+		"Report for your network\n" +
+		"\n" +
+		"Source IP;Port;Source IP;Last Seen (UTC)\n" +
+		"1.2.3.4;22;5.6.7.8;2025-10-18 12:00:00\n" +
+		"# comment between rows\n" +
+		"9.9.9.9;\n"
+
+	reader, err := NewCSVReaderFromString(data, nil)
+	if err != nil {
+		t.Fatalf("NewCSVReaderFromString failed: %v", err)
+	}
+
+	if reader.Delimiter() != ';' {
+		t.Errorf("Expected ';' delimiter, got %q", reader.Delimiter())
+	}
+	expectedHeader := []string{"source_ip", "port", "source_ip_2", "last_seen_(utc)"}
+	if !reflect.DeepEqual(reader.Header(), expectedHeader) {
+		t.Errorf("Unexpected header: %q", reader.Header())
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0]["source_ip_2"] != "5.6.7.8" || rows[0]["port"] != "22" {
+		t.Errorf("Unexpected first row: %v", rows[0])
+	}
+	if rows[1]["source_ip"] != "9.9.9.9" || rows[1]["last_seen_(utc)"] != "" {
+		t.Errorf("Unexpected ragged row: %v", rows[1])
+	}
+}
+
+func TestCSVReader_DecodesUTF16AndLatin1(t *testing.T) {
+	plain := "ip\tort\n1.2.3.4\tMünchen\n"
+
+	utf16, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(plain)
+	if err != nil {
+		t.Fatalf("Encoding UTF-16 failed: %v", err)
+	}
+	latin1, err := charmap.ISO8859_1.NewEncoder().String(plain)
+	if err != nil {
+		t.Fatalf("Encoding Latin-1 failed: %v", err)
+	}
+
+	for name, data := range map[string]string{"utf16": utf16, "latin1": latin1} {
+		reader, err := NewCSVReader(strings.NewReader(data), nil)
+		if err != nil {
+			t.Fatalf("%s: NewCSVReader failed: %v", name, err)
+		}
+		row, err := reader.Read()
+		if err != nil {
+			t.Fatalf("%s: Read failed: %v", name, err)
+		}
+		if row["ort"] != "München" {
+			t.Errorf("%s: Expected decoded value, got %q", name, row["ort"])
+		}
+		if _, err := reader.Read(); err != io.EOF {
+			t.Errorf("%s: Expected EOF, got %v", name, err)
+		}
+	}
+}
+
+func TestCSVReader_KeepsHeaderOfRaggedRows(t *testing.T) {
+	// The rows carry an unnamed trailing field the header does not list
+	data := "Dear abuse team, please find the events below.\n" +
+		"ip,port,time\n" +
+		"1.2.3.4,22,2025-10-18 12:00:00,ssh\n" +
+		"5.6.7.8,23,2025-10-18 12:05:00,telnet\n"
+
+	reader, err := NewCSVReaderFromString(data, nil)
+	if err != nil {
+		t.Fatalf("NewCSVReaderFromString failed: %v", err)
+	}
+	if !reflect.DeepEqual(reader.Header(), []string{"ip", "port", "time"}) {
+		t.Errorf("Unexpected header: %q", reader.Header())
+	}
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["ip"] != "1.2.3.4" || rows[1]["port"] != "23" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+}
+
+func TestCSVReader_DecodesLatin1AfterSniffedHead(t *testing.T) {
+	// Only the last row, past the sniffed bytes, is not UTF-8
+	data := "ip\tort\n" +
+		strings.Repeat("1.2.3.4\tBerlin\n", csvSniffBytes/len("1.2.3.4\tBerlin\n")+1) +
+		"5.6.7.8\tM\xfcnchen\n"
+
+	reader, err := NewCSVReader(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("NewCSVReader failed: %v", err)
+	}
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if last := rows[len(rows)-1]; last["ort"] != "München" {
+		t.Errorf("Expected decoded value, got %q", last["ort"])
+	}
+}
+
+// ParseCSVString reads the data as it is; parsers wanting the sniffing
+// and cleanup of CSVReader use it explicitly
+func TestParseCSVString_Verbatim(t *testing.T) {
+	rows, err := ParseCSVString("Targeted IP,Note\n# 1.2.3.4,\"a, b\"\n5.6.7.8, padded \n")
+	if err != nil {
+		t.Fatalf("ParseCSVString failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["Targeted IP"] != "# 1.2.3.4" || rows[0]["Note"] != "a, b" || rows[1]["Note"] != " padded " {
+		t.Errorf("Unexpected rows: %q", rows)
+	}
+}
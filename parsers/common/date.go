@@ -0,0 +1,447 @@
+// Package common provides a locale-aware date/time parser
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DateOrder disambiguates all-numeric dates such as 03/04/2025
+type DateOrder int
+
+const (
+	// DateOrderAuto infers the order from values above 12 and falls back to
+	// day-first for dotted dates (18.10.2025) and month-first otherwise
+	DateOrderAuto DateOrder = iota
+	// DateOrderMDY reads ambiguous numeric dates month first (US)
+	DateOrderMDY
+	// DateOrderDMY reads ambiguous numeric dates day first (EU)
+	DateOrderDMY
+)
+
+// DateOptions configures ParseDateTime. The zero value is usable.
+type DateOptions struct {
+	// Order is the hint for ambiguous numeric dates
+	Order DateOrder
+	// Location is applied to dates without any zone information, defaults to UTC
+	Location *time.Location
+}
+
+// ParsedDate is the result of ParseDateTime
+type ParsedDate struct {
+	// Time is always in UTC
+	Time time.Time
+	// Layout is the Go layout that matched the normalised input, or
+	// unix/unix_ms/unix_us for epoch timestamps
+	Layout string
+}
+
+// monthNames maps lowercase month names and abbreviations in English, German,
+// French, Spanish, Portuguese, Italian, Dutch, Polish and Russian to English
+// abbreviations. Russian and Polish genitive forms are included since dates
+// are written with them ("18 октября 2025").
+var monthNames = map[string]string{}
+
+var monthNamesByNumber = [12][]string{
+	{"jan", "january", "januar", "jänner", "janvier", "janv", "enero", "ene", "janeiro", "gennaio", "gen", "januari", "styczeń", "stycznia", "sty", "январь", "января", "янв"},
+	{"feb", "february", "februar", "février", "fevrier", "févr", "fevr", "febrero", "fevereiro", "fev", "febbraio", "februari", "luty", "lutego", "lut", "февраль", "февраля", "фев"},
+	{"mar", "march", "märz", "maerz", "mär", "mars", "marzo", "março", "marco", "maart", "mrt", "marzec", "marca", "март", "марта"},
+	{"apr", "april", "avril", "avr", "abril", "abr", "aprile", "kwiecień", "kwietnia", "kwi", "апрель", "апреля"},
+	{"may", "mai", "mayo", "maio", "maggio", "mag", "mei", "maj", "maja", "май", "мая"},
+	{"jun", "june", "juni", "juin", "junio", "junho", "giugno", "giu", "czerwiec", "czerwca", "cze", "июнь", "июня", "июн"},
+	{"jul", "july", "juli", "juillet", "juil", "julio", "julho", "luglio", "lug", "lipiec", "lipca", "lip", "июль", "июля", "июл"},
+	{"aug", "august", "août", "aout", "agosto", "ago", "augustus", "sierpień", "sierpnia", "sie", "август", "августа", "авг"},
+	{"sep", "sept", "september", "septembre", "septiembre", "setiembre", "setembro", "set", "settembre", "wrzesień", "września", "wrz", "сентябрь", "сентября", "сен", "сент"},
+	{"oct", "october", "oktober", "okt", "octobre", "octubre", "outubro", "out", "ottobre", "ott", "październik", "października", "paź", "октябрь", "октября", "окт"},
+	{"nov", "november", "novembre", "noviembre", "novembro", "listopad", "listopada", "lis", "ноябрь", "ноября", "ноя"},
+	{"dec", "december", "dezember", "dez", "décembre", "decembre", "déc", "diciembre", "dic", "dezembro", "dicembre", "grudzień", "grudnia", "gru", "декабрь", "декабря", "дек"},
+}
+
+// weekdayNames are dropped during normalisation, the date itself is authoritative
+var weekdayNames = map[string]bool{}
+
+var weekdayNameList = []string{
+	"mon", "tue", "tues", "wed", "thu", "thur", "thurs", "fri", "sat", "sun",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"montag", "dienstag", "mittwoch", "donnerstag", "freitag", "samstag", "sonnabend", "sonntag",
+	"mo", "di", "mi", "do", "fr", "sa", "so",
+	"lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi", "dimanche",
+	"lunes", "martes", "miércoles", "miercoles", "jueves", "viernes", "sábado", "sabado", "domingo",
+	"segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira",
+	"lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "domenica",
+	"maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag", "zondag",
+	"poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota", "niedziela",
+	"понедельник", "вторник", "среда", "четверг", "пятница", "суббота", "воскресенье",
+}
+
+// dateFillers are connecting words such as "18 de octubre de 2025 à 12:00"
+var dateFillers = map[string]bool{
+	"de": true, "del": true, "à": true, "a": true, "um": true, "at": true, "om": true,
+	"alle": true, "o": true, "в": true, "г": true, "uhr": true, "le": true, "il": true,
+	"el": true, "den": true, "on": true,
+}
+
+// zoneOffsets maps timezone abbreviations to offsets in minutes east of UTC.
+// Ambiguous abbreviations (IST, BST in non-UK usage) use the most common meaning.
+var zoneOffsets = map[string]int{
+	"ut": 0, "utc": 0, "gmt": 0, "z": 0, "wet": 0,
+	"bst": 60, "west": 60, "cet": 60, "mez": 60, "wat": 60,
+	"cest": 120, "mesz": 120, "eet": 120, "cat": 120, "sast": 120,
+	"eest": 180, "msk": 180, "eat": 180,
+	"ist": 330, "pkt": 300,
+	"ict": 420, "wib": 420,
+	"hkt": 480, "sgt": 480, "awst": 480, "pht": 480,
+	"jst": 540, "kst": 540,
+	"acst": 570, "acdt": 630,
+	"aest": 600, "aedt": 660,
+	"nzst": 720, "nzdt": 780,
+	"nst": -210, "ndt": -150,
+	"brt": -180, "art": -180, "adt": -180,
+	"ast": -240, "edt": -240,
+	"est": -300, "cdt": -300,
+	"cst": -360, "mdt": -360,
+	"mst": -420, "pdt": -420,
+	"pst": -480, "akdt": -480,
+	"akst": -540, "hdt": -540,
+	"hst": -600,
+}
+
+var (
+	epochPattern         = regexp.MustCompile(`^(\d{10}(\.\d+)?|\d{13}|\d{16})$`)
+	numericOffsetPattern = regexp.MustCompile(`^[+-]\d{2}:?\d{2}$`)
+	zoneWithOffset       = regexp.MustCompile(`^(utc|gmt|ut)([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	ordinalDayPattern    = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th|er|e|º|ª)?\.?$`)
+	numericDatePattern   = regexp.MustCompile(`^(\d{1,2})([/.-])(\d{1,2})[/.-](\d{2,4})\b`)
+	fractionCommaPattern = regexp.MustCompile(`(\d{2}:\d{2}:\d{2}),(\d)`)
+	// clfDatePattern is the date of the Common Log Format (18/Oct/2025:12:34:56 +0000)
+	clfDatePattern = regexp.MustCompile(`^(\d{1,2})/(\pL+)/(\d{4}):`)
+	// leadingTimePattern is a time written before a numeric date (12:34:56 18.10.2025)
+	leadingTimePattern = regexp.MustCompile(`^(\d{1,2}:\d{2}(?::\d{2}(?:\.\d+)?)?)\s+(\d{1,2}[/.-]\d{1,2}[/.-]\d{2,4}|\d{4}-\d{2}-\d{2})\b`)
+)
+
+func init() {
+	for i, names := range monthNamesByNumber {
+		abbr := time.Month(i + 1).String()[:3]
+		for _, name := range names {
+			monthNames[name] = abbr
+		}
+	}
+	for _, name := range weekdayNameList {
+		weekdayNames[name] = true
+	}
+	// RFC 5322 obsolete military zones carry no reliable information and are
+	// treated as -0000 (section 4.3)
+	for c := 'a'; c <= 'z'; c++ {
+		if c != 'j' {
+			zoneOffsets[string(c)] = 0
+		}
+	}
+}
+
+// Layout building blocks, combined once per DateOrder
+var (
+	namedDateLayouts = []string{
+		"2006-01-02", "2006/01/02", "2006.01.02", "20060102", "200601021504", "20060102150405",
+		"2 Jan 2006", "Jan 2 2006", "2-Jan-2006", "2006-Jan-02", "2 Jan 06", "Jan 2 06",
+	}
+	mdyDateLayouts = []string{"1/2/2006", "1.2.2006", "1-2-2006", "1/2/06", "1.2.06", "1-2-06"}
+	dmyDateLayouts = []string{"2/1/2006", "2.1.2006", "2-1-2006", "2/1/06", "2.1.06", "2-1-06"}
+	timeLayouts    = []string{
+		"", " 15:04", " 15:04:05", " 15:04:05.999999999", " 3:04 PM", " 3:04:05 PM",
+		"T15:04", "T15:04:05", "T15:04:05.999999999", "T150405", " 15.04", " 15.04.05",
+	}
+	zoneLayouts = []string{"", " -0700", " -07:00", "Z07:00", "-0700", "Z07", " Z07"}
+	// ANSIC-style layouts put the year after the time
+	yearLastLayouts = []string{
+		"Jan 2 15:04:05 2006", "Jan 2 15:04:05 -0700 2006", "Jan 2 15:04:05.999999999 2006",
+	}
+)
+
+// dateLayoutsByShape holds the layouts of each order by the shape of the
+// dates they parse (see dateShape), in the order they are tried. Trying only
+// the layouts of the input's shape spares the hundreds of failing attempts
+// per date.
+var dateLayoutsByShape = map[DateOrder]map[string][]string{
+	DateOrderMDY: indexLayouts(buildDateLayouts(mdyDateLayouts)),
+	DateOrderDMY: indexLayouts(buildDateLayouts(dmyDateLayouts)),
+}
+
+// shapeSamples are formatted with every layout to find its shapes: with and
+// without fractional seconds, and with a Z or a numeric zone
+var shapeSamples = []time.Time{
+	time.Date(2025, 10, 18, 12, 34, 56, 0, time.UTC),
+	time.Date(2025, 10, 18, 12, 34, 56, 123000000, time.FixedZone("", 2*3600)),
+}
+
+func indexLayouts(layouts []string) map[string][]string {
+	index := make(map[string][]string)
+	for _, layout := range layouts {
+		// Seconds are parsed with a fraction even if the layout has none
+		variants := []string{layout}
+		if strings.Contains(layout, "05") && !strings.Contains(layout, "05.9") {
+			variants = append(variants, strings.Replace(layout, "05", "05.000", 1), strings.Replace(layout, "05", "05,000", 1))
+		}
+		seen := make(map[string]bool)
+		for _, variant := range variants {
+			for _, sample := range shapeSamples {
+				shape := dateShape(sample.Format(variant))
+				if !seen[shape] {
+					seen[shape] = true
+					index[shape] = append(index[shape], layout)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// dateShape reduces a date to its form: runs of digits become 9, runs of
+// letters a, and the signs of zones are not told apart from dashes
+func dateShape(value string) string {
+	var shape []byte
+	var last byte
+	for _, r := range value {
+		var c byte
+		switch {
+		case r >= '0' && r <= '9':
+			c = '9'
+		case unicode.IsLetter(r):
+			c = 'a'
+		case r == '+':
+			c = '-'
+		case r < utf8.RuneSelf:
+			c = byte(r)
+		default:
+			c = '?'
+		}
+		if c == last && (c == '9' || c == 'a') {
+			continue
+		}
+		shape = append(shape, c)
+		last = c
+	}
+	return string(shape)
+}
+
+func buildDateLayouts(numeric []string) []string {
+	var layouts []string
+	for _, date := range append(append([]string{}, namedDateLayouts...), numeric...) {
+		for _, clock := range timeLayouts {
+			// T-separated times only follow ISO dates
+			if strings.HasPrefix(clock, "T") && !strings.HasPrefix(date, "2006") {
+				continue
+			}
+			for _, zone := range zoneLayouts {
+				if clock == "" && zone != "" {
+					continue
+				}
+				layouts = append(layouts, date+clock+zone)
+			}
+		}
+	}
+	return append(layouts, yearLastLayouts...)
+}
+
+// ParseDateTime parses a date in any of the formats found in abuse reports:
+// ISO 8601 variants, unix epochs (seconds, milliseconds, microseconds),
+// RFC 5322 including obsolete zones and comments, US/EU numeric dates,
+// localised month and weekday names, timezone abbreviations, and log dates
+// in the Common Log Format or with the time before the date.
+// The returned time is in UTC.
+func ParseDateTime(dateStr string, options *DateOptions) (*ParsedDate, error) {
+	if options == nil {
+		options = &DateOptions{}
+	}
+	location := options.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	trimmed := strings.TrimSpace(dateStr)
+	if trimmed == "" {
+		return nil, NewParserError("empty date")
+	}
+
+	if epochPattern.MatchString(trimmed) {
+		if parsed := parseEpoch(trimmed); parsed != nil {
+			return parsed, nil
+		}
+	}
+
+	normalised := normaliseDate(trimmed)
+	order := resolveDateOrder(normalised, options.Order)
+
+	for _, layout := range dateLayoutsByShape[order][dateShape(normalised)] {
+		if t, err := time.ParseInLocation(layout, normalised, location); err == nil {
+			return &ParsedDate{Time: t.UTC(), Layout: layout}, nil
+		}
+	}
+	return nil, NewParserError(fmt.Sprintf("unrecognised date: %q", dateStr))
+}
+
+// ParseDate is the convenience form of ParseDateTime for event dates: it uses
+// the automatic order and returns nil instead of an error
+func ParseDate(dateStr string) *time.Time {
+	parsed, err := ParseDateTime(dateStr, nil)
+	if err != nil {
+		return nil
+	}
+	return &parsed.Time
+}
+
+// latestEpoch bounds the epochs taken for timestamps; longer runs of digits
+// are left to the layouts
+var latestEpoch = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// parseEpoch interprets 10, 13 or 16 digits as a unix timestamp in seconds,
+// milliseconds or microseconds. Other lengths are left to the layouts, they
+// are compact dates such as 20251018 or 20251018123456.
+func parseEpoch(value string) *ParsedDate {
+	integer, fraction, _ := strings.Cut(value, ".")
+	number, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	var parsed *ParsedDate
+	switch len(integer) {
+	case 10:
+		nanos := int64(0)
+		if fraction != "" {
+			frac, err := strconv.ParseFloat("0."+fraction, 64)
+			if err != nil {
+				return nil
+			}
+			nanos = int64(frac * 1e9)
+		}
+		parsed = &ParsedDate{Time: time.Unix(number, nanos).UTC(), Layout: "unix"}
+	case 13:
+		parsed = &ParsedDate{Time: time.UnixMilli(number).UTC(), Layout: "unix_ms"}
+	case 16:
+		parsed = &ParsedDate{Time: time.UnixMicro(number).UTC(), Layout: "unix_us"}
+	default:
+		return nil
+	}
+	if !parsed.Time.Before(latestEpoch) {
+		return nil
+	}
+	return parsed
+}
+
+// normaliseDate rewrites a date into the English, numeric-offset form the
+// layouts expect: weekdays, fillers and ordinals are dropped, month names are
+// translated, zone abbreviations become offsets unless an offset is present,
+// and log-style dates put the date before the time
+func normaliseDate(value string) string {
+	value = fractionCommaPattern.ReplaceAllString(value, "$1.$2")
+	value = clfDatePattern.ReplaceAllString(value, "$1 $2 $3 ")
+	value = leadingTimePattern.ReplaceAllString(value, "$2 $1")
+	value = strings.NewReplacer(",", " ", "(", " ", ")", " ").Replace(value)
+	tokens := strings.Fields(value)
+
+	hasOffset := false
+	for _, token := range tokens {
+		if numericOffsetPattern.MatchString(token) {
+			hasOffset = true
+			break
+		}
+	}
+
+	result := make([]string, 0, len(tokens))
+	seenTime := false
+	for _, token := range tokens {
+		lower := strings.ToLower(token)
+		word := strings.TrimSuffix(lower, ".")
+
+		switch {
+		case weekdayNames[word] || dateFillers[word]:
+			continue
+		case monthNames[word] != "":
+			result = append(result, monthNames[word])
+			continue
+		case word == "am" || word == "pm" || word == "a.m" || word == "p.m":
+			if seenTime {
+				result = append(result, strings.ToUpper(strings.ReplaceAll(word, ".", "")))
+			}
+			continue
+		}
+
+		if match := ordinalDayPattern.FindStringSubmatch(lower); match != nil {
+			result = append(result, match[1])
+			continue
+		}
+
+		if seenTime {
+			if match := zoneWithOffset.FindStringSubmatch(lower); match != nil {
+				if !hasOffset {
+					result = append(result, formatOffset(match[2], match[3], match[4]))
+					hasOffset = true
+				}
+				continue
+			}
+			if offset, ok := zoneOffsets[word]; ok {
+				if !hasOffset {
+					result = append(result, formatMinutes(offset))
+					hasOffset = true
+				}
+				continue
+			}
+		}
+
+		if strings.Contains(token, ":") {
+			seenTime = true
+		}
+		result = append(result, token)
+	}
+
+	return strings.Join(result, " ")
+}
+
+// resolveDateOrder applies the hint, overriding it when a field cannot be a month
+func resolveDateOrder(normalised string, hint DateOrder) DateOrder {
+	match := numericDatePattern.FindStringSubmatch(normalised)
+	if match == nil {
+		if hint == DateOrderDMY {
+			return DateOrderDMY
+		}
+		return DateOrderMDY
+	}
+
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[3])
+	switch {
+	case first > 12 && second <= 12:
+		return DateOrderDMY
+	case second > 12 && first <= 12:
+		return DateOrderMDY
+	case hint != DateOrderAuto:
+		return hint
+	case match[2] == ".":
+		return DateOrderDMY
+	}
+	return DateOrderMDY
+}
+
+func formatOffset(sign, hours, minutes string) string {
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	total := h*60 + m
+	if sign == "-" {
+		total = -total
+	}
+	return formatMinutes(total)
+}
+
+func formatMinutes(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/60, offset%60)
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateTime_Formats(t *testing.T) {
+	cases := map[string]string{
+		"2025-10-18T12:34:56Z":                  "2025-10-18T12:34:56Z",
+		"2025-10-18T14:34:56.123+02:00":         "2025-10-18T12:34:56.123Z",
+		"2025-10-18 12:34:56,5":                 "2025-10-18T12:34:56.5Z",
+		"20251018T123456Z":                      "2025-10-18T12:34:56Z",
+		"2025-10-18":                            "2025-10-18T00:00:00Z",
+		"1760790896":                            "2025-10-18T12:34:56Z",
+		"1760790896000":                         "2025-10-18T12:34:56Z",
+		"1760790896000000":                      "2025-10-18T12:34:56Z",
+		"1760790896.5":                          "2025-10-18T12:34:56.5Z",
+		"20251018123456":                        "2025-10-18T12:34:56Z",
+		"202510181234":                          "2025-10-18T12:34:00Z",
+		"20251018":                              "2025-10-18T00:00:00Z",
+		"Sat, 18 Oct 2025 08:34:56 EDT":         "2025-10-18T12:34:56Z",
+		"Sat, 18 Oct 2025 12:34:56 +0000 (UTC)": "2025-10-18T12:34:56Z",
+		"18 Oct 25 12:34 UT":                    "2025-10-18T12:34:00Z",
+		"Oct 18, 2025 5:34 AM PDT":              "2025-10-18T12:34:00Z",
+		"Oct 18th, 2025 12:34:56 GMT+2":         "2025-10-18T10:34:56Z",
+		"Sat Oct 18 12:34:56 2025":              "2025-10-18T12:34:56Z",
+		"18. Oktober 2025 um 14:34 Uhr MESZ":    "2025-10-18T12:34:00Z",
+		"samedi 18 octobre 2025 à 14:34 CEST":   "2025-10-18T12:34:00Z",
+		"18 de octubre de 2025 12:34":           "2025-10-18T12:34:00Z",
+		"18 de outubro de 2025 12:34":           "2025-10-18T12:34:00Z",
+		"18 ottobre 2025 12:34":                 "2025-10-18T12:34:00Z",
+		"18 oktober 2025 12:34":                 "2025-10-18T12:34:00Z",
+		"18 października 2025 12:34":            "2025-10-18T12:34:00Z",
+		"18 октября 2025 г. в 15:34 MSK":        "2025-10-18T12:34:00Z",
+		"10/18/2025 12:34:56":                   "2025-10-18T12:34:56Z",
+		"18/10/2025 12:34:56":                   "2025-10-18T12:34:56Z",
+		"18.10.2025 12:34":                      "2025-10-18T12:34:00Z",
+		"18/Oct/2025:12:34:56 +0000":            "2025-10-18T12:34:56Z",
+		"18/Oct/2025:14:34:56 +0200":            "2025-10-18T12:34:56Z",
+		"12:34:56 18.10.2025":                   "2025-10-18T12:34:56Z",
+		"12:34 18/10/2025":                      "2025-10-18T12:34:00Z",
+		"14:34:56 2025-10-18 +0200":             "2025-10-18T12:34:56Z",
+	}
+
+	for input, expected := range cases {
+		parsed, err := ParseDateTime(input, nil)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+			continue
+		}
+		if got := parsed.Time.Format(time.RFC3339Nano); got != expected {
+			t.Errorf("%q: expected %s, got %s (layout %q)", input, expected, got, parsed.Layout)
+		}
+		if parsed.Time.Location() != time.UTC {
+			t.Errorf("%q: expected UTC result", input)
+		}
+	}
+}
+
+func TestParseDateTime_OrderHint(t *testing.T) {
+	parsed, err := ParseDateTime("03/04/2025", nil)
+	if err != nil || parsed.Time.Month() != time.March {
+		t.Errorf("Expected month-first default, got %v (%v)", parsed, err)
+	}
+	if parsed.Layout != "1/2/2006" {
+		t.Errorf("Unexpected layout %q", parsed.Layout)
+	}
+
+	parsed, err = ParseDateTime("03/04/2025", &DateOptions{Order: DateOrderDMY})
+	if err != nil || parsed.Time.Month() != time.April {
+		t.Errorf("Expected day-first with hint, got %v (%v)", parsed, err)
+	}
+
+	// A day above 12 overrides the hint
+	parsed, err = ParseDateTime("25/04/2025", &DateOptions{Order: DateOrderMDY})
+	if err != nil || parsed.Time.Day() != 25 {
+		t.Errorf("Expected unambiguous day-first, got %v (%v)", parsed, err)
+	}
+}
+
+func TestParseDateTime_LocationAndErrors(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	parsed, err := ParseDateTime("2025-10-18 14:34:56", &DateOptions{Location: berlin})
+	if err != nil || parsed.Time.Hour() != 12 {
+		t.Errorf("Expected location to apply to zoneless dates, got %v (%v)", parsed, err)
+	}
+
+	// Nine digits are neither an epoch nor a compact date, and an epoch
+	// beyond 2100 is not taken for one
+	for _, input := range []string{"", "not a date", "32/13/2025", "176079089", "9999999999999999"} {
+		if _, err := ParseDateTime(input, nil); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+	if ParseDate("not a date") != nil {
+		t.Error("Expected nil for unparseable date")
+	}
+}
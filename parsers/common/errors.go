@@ -48,9 +48,14 @@ func NewRejectError(reason string) *RejectError {
 // IgnoreError indicates that an email should be ignored (not processed)
 type IgnoreError struct {
 	Reason string
+	// TicketID is the reporter's ticket the ignored email refers to, if any
+	TicketID string
 }
 
 func (e *IgnoreError) Error() string {
+	if e.TicketID != "" {
+		return fmt.Sprintf("email ignored: %s (ticket %s)", e.Reason, e.TicketID)
+	}
 	return fmt.Sprintf("email ignored: %s", e.Reason)
 }
 
@@ -58,3 +63,8 @@ func (e *IgnoreError) Error() string {
 func NewIgnoreError(reason string) *IgnoreError {
 	return &IgnoreError{Reason: reason}
 }
+
+// NewTicketIgnoreError creates a new IgnoreError for an email about a ticket
+func NewTicketIgnoreError(reason, ticketID string) *IgnoreError {
+	return &IgnoreError{Reason: reason, TicketID: ticketID}
+}
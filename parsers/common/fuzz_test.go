@@ -0,0 +1,137 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// The fuzz targets below only check that the helpers do not panic on
+// arbitrary input; their results are covered by the unit tests.
+
+// FuzzMarkerHelpers covers the helpers that cut text around markers
+func FuzzMarkerHelpers(f *testing.F) {
+	f.Add("Source IP: 192.0.2.1\nPort: 25\n\nEnd", "Source IP:", "\n", 2)
+	f.Add("a\r\nb\r\n\r\nc", "b", "c", 0)
+	f.Add("marker", "marker", "marker", -1)
+	f.Add("", "", "", 1)
+
+	f.Fuzz(func(t *testing.T, text, startMarker, endMarker string, lines int) {
+		_ = FindString(text, startMarker, endMarker)
+		_ = FindStringWithoutMarkers(text, startMarker, endMarker)
+		_ = GetNonEmptyLineAfter(text, startMarker)
+		_ = RemoveCarriageReturn(text)
+		_ = GetBlockAround(text, startMarker)
+		_, _ = GetBlockAroundWithContinueUntil(text, startMarker, endMarker)
+		_ = GetContinuousLinesUntilEmptyLine(text, startMarker)
+		_ = GetLineAfter(text, startMarker, lines%64)
+		_ = GetBlockAfter(text, startMarker)
+		_ = GetBlockAfterWithStop(text, startMarker, endMarker)
+		_ = OneLineColonKeyValueGenerator(text)
+	})
+}
+
+// FuzzValueHelpers covers the helpers that extract or normalize a value
+func FuzzValueHelpers(f *testing.F) {
+	f.Add("Host 192.0.2.1 sent spam to abuse@example.com via hxxp://example[.]com/a")
+	f.Add("2001:db8::1 port 8080 at 2024-03-01T10:00:00Z")
+	f.Add("brute-force ssh 22/tcp")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		_ = ExtractOneIP(text)
+		_ = IsIP(text)
+		_ = ExtractAllIPv4(text)
+		_ = ExtractOneEmail(text)
+		_ = RefangURL(text)
+		_ = CleanURL(text)
+		_ = IsURL(text)
+		_, _ = ProcessURL(text)
+		_, _ = ParsePort(text)
+		_, _ = ParseInt(text)
+		_ = MapServiceStrings(text)
+		_ = IncidentTypeToEventType(text)
+		_ = ParseDate(text)
+		_, _ = ParseDateTime(text, &DateOptions{Order: DateOrderDMY})
+		_ = FindValueFromKeylist([]string{text, "ip"}, map[string]string{text: text})
+	})
+}
+
+// FuzzCSVHelpers covers the CSV reader and the CSV helpers
+func FuzzCSVHelpers(f *testing.F) {
+	f.Add("# comment\nip;port\n192.0.2.1;25\n192.0.2.2\n", "ip")
+	f.Add("\xff\xfei\x00p\x00\n\x001\x00", "")
+	f.Add("\"a,b\nc\"", "a")
+
+	f.Fuzz(func(t *testing.T, data, headerContains string) {
+		_, _ = ParseCSVString(data)
+		_ = SniffCSVDelimiter([]string{data, headerContains})
+		_ = NormalizeCSVHeader(data)
+		reader, err := NewCSVReaderFromString(data, &CSVOptions{HeaderContains: headerContains})
+		if err != nil {
+			return
+		}
+		_, _ = reader.ReadAll()
+	})
+}
+
+// FuzzHTMLHelpers covers the HTML table extraction and text rendering
+func FuzzHTMLHelpers(f *testing.F) {
+	f.Add("<table><tr><th>IP</th></tr><tr><td rowspan=3>192.0.2.1</td></tr></table>")
+	f.Add("<p>a<br>b</p><table><tr><td colspan=99999>x</td></tr>")
+	f.Add("<html><body>text")
+
+	f.Fuzz(func(t *testing.T, html string) {
+		_, _ = ExtractHTMLTables(html)
+		_, _ = ExtractHTMLTable(html)
+		_, _ = ExtractHTMLTableAsCSV(html)
+		_ = HTMLToText(html)
+		_ = IsHTML(html)
+	})
+}
+
+// FuzzNoticeHelpers covers the copyright and phishing notice extraction
+func FuzzNoticeHelpers(f *testing.F) {
+	f.Add("<Infringement><Case><ID>1</ID></Case><Source><IP_Address>192.0.2.1</IP_Address></Source></Infringement>")
+	f.Add("Infringing URL: http://example.com/a\nCopyrighted work: Title\n")
+	f.Add("Phishing site: http://example.com/login\nBrand: Example Bank\n")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		_, _ = ExtractACNSNotices(text)
+		_, _ = ExtractDMCANotice(text)
+		_, _ = ExtractPhishingNotice(text)
+	})
+}
+
+// FuzzEmailHelpers covers the helpers reading a serialized email, built from
+// a subject, a body and one part with a content disposition
+func FuzzEmailHelpers(f *testing.F) {
+	f.Add("Abuse report", "Source IP: 192.0.2.1", "ip,port\n192.0.2.1,25\n", `attachment; filename="report.csv"`, "text/csv")
+	f.Add("", "<html><table><tr><td>x</td></tr></table>", "PK\x03\x04", `attachment; filename="a.zip"`, "application/zip")
+	f.Add("DMCA", "", "", "", "")
+
+	f.Fuzz(func(t *testing.T, subject, body, partBody, disposition, contentType string) {
+		serializedEmail := &email.SerializedEmail{
+			Headers: map[string][]string{"subject": {subject}, "from": {subject}},
+			Body:    body,
+			Parts: []email.EmailPart{
+				{Body: body, ContentType: "text/plain"},
+				{
+					Body:        []byte(partBody),
+					ContentType: contentType,
+					Headers:     map[string][]string{"content-disposition": {disposition}, "content-type": {contentType}},
+				},
+			},
+		}
+		_, _ = GetBody(serializedEmail, true)
+		_, _ = GetSubject(serializedEmail, true)
+		_, _ = GetFrom(serializedEmail, true)
+		_, _ = GetTextBody(serializedEmail, true)
+		_, _ = FindFirstAttachmentWithMimeType(serializedEmail, ".csv")
+		_, _ = ExtractCSVFromEmail(serializedEmail)
+		_, _ = FindCopyrightNotices(serializedEmail)
+		_, _ = FindACNSNotices(serializedEmail)
+		_, _ = HandleZipPart([]byte(partBody))
+		_, _ = HandleZipPart(partBody)
+	})
+}
@@ -5,6 +5,7 @@ package common
 import (
 	"encoding/csv"
 	"fmt"
+	"mime"
 	"regexp"
 	"strings"
 
@@ -70,15 +71,50 @@ func GetFrom(serializedEmail *email.SerializedEmail, throws bool) (string, error
 		return "", nil
 	}
 
-	// Extract email address from "Name <email@example.com>" format
-	fromAddr := from[0]
-	if startIdx := strings.Index(fromAddr, "<"); startIdx != -1 {
-		if endIdx := strings.Index(fromAddr[startIdx:], ">"); endIdx != -1 {
-			return strings.ToLower(strings.TrimSpace(fromAddr[startIdx+1 : startIdx+endIdx])), nil
+	return address(from[0]), nil
+}
+
+// address extracts the lowercased email address of a "Name <email@example.com>"
+// header value
+func address(value string) string {
+	if startIdx := strings.Index(value, "<"); startIdx != -1 {
+		if endIdx := strings.Index(value[startIdx:], ">"); endIdx != -1 {
+			return strings.ToLower(strings.TrimSpace(value[startIdx+1 : startIdx+endIdx]))
 		}
 	}
 
-	return strings.ToLower(strings.TrimSpace(fromAddr)), nil
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// FromDomain reports whether the From address of an email is at domain or
+// one of its subdomains
+func FromDomain(serializedEmail *email.SerializedEmail, domain string) bool {
+	from, _ := GetFrom(serializedEmail, false)
+	return atDomain(from, domain)
+}
+
+// SentFrom reports whether an email is from one of domains or their
+// subdomains, also when a mailing list relayed it under its own From address
+// and kept the sender in X-Original-From, X-Original-Sender or Reply-To
+func SentFrom(serializedEmail *email.SerializedEmail, domains ...string) bool {
+	for _, header := range []string{"from", "x-original-from", "x-original-sender", "reply-to"} {
+		for _, value := range serializedEmail.Headers[header] {
+			for _, domain := range domains {
+				if atDomain(address(value), domain) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// atDomain reports whether addr, which may be followed by a comment as in
+// "abuse@example.com (Abuse Desk)", is at domain or one of its subdomains
+func atDomain(addr, domain string) bool {
+	_, host, ok := strings.Cut(addr, "@")
+	host, _, _ = strings.Cut(host, " ")
+	return ok && (host == domain || strings.HasSuffix(host, "."+domain))
 }
 
 // FindStringWithoutMarkers finds text between two markers
@@ -190,46 +226,81 @@ func FindFirstAttachmentWithMimeType(serializedEmail *email.SerializedEmail, ext
 	return "", fmt.Errorf("attachment with extension '%s' not found", extension)
 }
 
-// ExtractHTMLTableAsCSV extracts an HTML table and converts it to CSV
+// ExtractHTMLTableAsCSV extracts the rows of all HTML tables as CSV lines.
+// Header rows are included as the first line of their table and cells are
+// quoted where needed, so each line can be read back with encoding/csv.
 func ExtractHTMLTableAsCSV(html string) ([]string, error) {
-	// Simple implementation - extract text between <tr> tags
+	tables, err := ExtractHTMLTables(html)
+	if err != nil {
+		return nil, err
+	}
+
 	var rows []string
+	for _, table := range tables {
+		if table.Header != nil {
+			rows = append(rows, csvLine(table.Header))
+		}
+		for _, row := range table.Rows {
+			rows = append(rows, csvLine(row))
+		}
+	}
 
-	// Remove HTML tags and extract table data
-	html = strings.ReplaceAll(html, "<th", "<td")
-	html = strings.ReplaceAll(html, "</th", "</td")
-
-	// Find all rows
-	rowPattern := regexp.MustCompile(`<tr[^>]*>(.*?)</tr>`)
-	rowMatches := rowPattern.FindAllStringSubmatch(html, -1)
-
-	for _, rowMatch := range rowMatches {
-		if len(rowMatch) > 1 {
-			// Extract cells
-			cellPattern := regexp.MustCompile(`<td[^>]*>(.*?)</td>`)
-			cellMatches := cellPattern.FindAllStringSubmatch(rowMatch[1], -1)
-
-			var cells []string
-			for _, cellMatch := range cellMatches {
-				if len(cellMatch) > 1 {
-					// Clean up cell text
-					cell := regexp.MustCompile(`<[^>]+>`).ReplaceAllString(cellMatch[1], "")
-					cell = strings.TrimSpace(cell)
-					cells = append(cells, cell)
-				}
-			}
+	return rows, nil
+}
 
-			if len(cells) > 0 {
-				rows = append(rows, strings.Join(cells, ","))
-			}
-		}
+// csvLine encodes a single record as a CSV line without the trailing newline
+func csvLine(record []string) string {
+	var sb strings.Builder
+	writer := csv.NewWriter(&sb)
+	_ = writer.Write(record)
+	writer.Flush()
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// GetTextBody returns the email body as plain text, rendering HTML-only bodies with HTMLToText
+func GetTextBody(serializedEmail *email.SerializedEmail, throws bool) (string, error) {
+	body, err := GetBody(serializedEmail, throws)
+	if err != nil {
+		return "", err
 	}
 
-	if len(rows) == 0 {
-		return nil, fmt.Errorf("no table rows found in HTML")
+	isHTMLContent := false
+	if ct, ok := serializedEmail.Headers["content-type"]; ok && len(ct) > 0 {
+		isHTMLContent = strings.Contains(strings.ToLower(ct[0]), "text/html")
+	}
+	if isHTMLContent || htmlOnly(serializedEmail.Parts) || IsHTML(body) {
+		return HTMLToText(body), nil
 	}
 
-	return rows, nil
+	return body, nil
+}
+
+// htmlOnly reports whether the parts hold an inline HTML part but no inline
+// plain text one, in which case the email body was taken from the HTML part
+func htmlOnly(parts []email.EmailPart) bool {
+	hasHTML, hasText := false, false
+	var walk func([]email.EmailPart)
+	walk = func(parts []email.EmailPart) {
+		for _, part := range parts {
+			if len(part.Parts) > 0 {
+				walk(part.Parts)
+				continue
+			}
+			if values := part.Headers["content-disposition"]; len(values) > 0 {
+				if disposition, _, _ := mime.ParseMediaType(values[0]); disposition == "attachment" {
+					continue
+				}
+			}
+			switch part.ContentType {
+			case "text/html":
+				hasHTML = true
+			case "text/plain":
+				hasText = true
+			}
+		}
+	}
+	walk(parts)
+	return hasHTML && !hasText
 }
 
 // ParseCSVString parses a CSV string into a slice of maps
@@ -0,0 +1,442 @@
+// Package common provides HTML table and HTML-to-text extraction utilities
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// HTMLTable is a table extracted from an HTML document.
+// Cells spanning several columns or rows (colspan/rowspan) are repeated in
+// every grid position they cover, so all rows are aligned to the same columns.
+type HTMLTable struct {
+	// Header holds the detected header row, nil if the table has none
+	Header []string
+	// Rows holds the data rows (without the header row)
+	Rows [][]string
+}
+
+// Records returns the data rows as maps keyed by header name.
+// Returns nil if the table has no header. Duplicate header names are
+// suffixed with "_2", "_3", ... so no column is lost.
+func (t *HTMLTable) Records() []map[string]string {
+	if t.Header == nil {
+		return nil
+	}
+
+	keys := uniqueHeaderNames(t.Header)
+	records := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]string, len(keys))
+		for i, key := range keys {
+			if i < len(row) {
+				record[key] = row[i]
+			} else {
+				record[key] = ""
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// uniqueHeaderNames suffixes repeated header names with their occurrence count
+func uniqueHeaderNames(header []string) []string {
+	seen := make(map[string]int, len(header))
+	keys := make([]string, len(header))
+	for i, name := range header {
+		seen[name]++
+		if seen[name] > 1 {
+			keys[i] = name + "_" + strconv.Itoa(seen[name])
+		} else {
+			keys[i] = name
+		}
+	}
+	return keys
+}
+
+// ExtractHTMLTables extracts every table of an HTML document in document order.
+// Nested tables are returned as separate tables and their text is not repeated
+// inside the cell of the enclosing table.
+func ExtractHTMLTables(htmlBody string) ([]*HTMLTable, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var tables []*HTMLTable
+	doc.Find("table").Each(func(i int, s *goquery.Selection) {
+		if table := extractTable(s); len(table.Rows) > 0 || table.Header != nil {
+			tables = append(tables, table)
+		}
+	})
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no table rows found in HTML")
+	}
+	return tables, nil
+}
+
+// ExtractHTMLTable returns the first non-empty table of an HTML document
+func ExtractHTMLTable(htmlBody string) (*HTMLTable, error) {
+	tables, err := ExtractHTMLTables(htmlBody)
+	if err != nil {
+		return nil, err
+	}
+	return tables[0], nil
+}
+
+// tableRows returns the rows belonging directly to the table (not to nested tables)
+func tableRows(table *goquery.Selection) *goquery.Selection {
+	return table.ChildrenFiltered("tr").
+		AddSelection(table.ChildrenFiltered("thead, tbody, tfoot").ChildrenFiltered("tr"))
+}
+
+// extractTable converts a <table> selection into an aligned cell grid and detects its header
+func extractTable(table *goquery.Selection) *HTMLTable {
+	type pending struct {
+		text string
+		left int
+	}
+
+	var grid [][]string
+	var headerFlags []bool
+	// spans carries rowspan cells over to the following rows, keyed by column
+	spans := make(map[int]pending)
+
+	tableRows(table).Each(func(i int, tr *goquery.Selection) {
+		var row []string
+		allHeader := true
+		hasCells := false
+		col := 0
+
+		fillSpans := func() {
+			for {
+				span, ok := spans[col]
+				if !ok {
+					return
+				}
+				row = append(row, span.text)
+				span.left--
+				if span.left == 0 {
+					delete(spans, col)
+				} else {
+					spans[col] = span
+				}
+				col++
+			}
+		}
+
+		tr.ChildrenFiltered("td, th").Each(func(j int, cell *goquery.Selection) {
+			fillSpans()
+			hasCells = true
+			if goquery.NodeName(cell) != "th" {
+				allHeader = false
+			}
+
+			text := cellText(cell)
+			colspan := spanAttr(cell, "colspan")
+			rowspan := spanAttr(cell, "rowspan")
+			for k := 0; k < colspan; k++ {
+				row = append(row, text)
+				if rowspan > 1 {
+					spans[col] = pending{text: text, left: rowspan - 1}
+				}
+				col++
+			}
+		})
+		fillSpans()
+
+		if !hasCells {
+			return
+		}
+		grid = append(grid, row)
+		headerFlags = append(headerFlags, allHeader)
+	})
+
+	result := &HTMLTable{}
+	if len(grid) == 0 {
+		return result
+	}
+
+	// The header is the leading row made only of <th> cells, or the first
+	// row of an explicit <thead>
+	hasTHead := table.ChildrenFiltered("thead").ChildrenFiltered("tr").Length() > 0
+	if headerFlags[0] || hasTHead {
+		result.Header = grid[0]
+		grid = grid[1:]
+	}
+
+	for _, row := range grid {
+		if isEmptyRow(row) {
+			continue
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+// spanAttr reads a colspan/rowspan attribute, defaulting to 1 for missing or invalid values
+func spanAttr(cell *goquery.Selection, name string) int {
+	value, ok := cell.Attr(name)
+	if !ok {
+		return 1
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 1 {
+		return 1
+	}
+	// Browsers cap spans, do the same so a hostile value cannot blow up the grid
+	if n > 1000 {
+		return 1000
+	}
+	return n
+}
+
+// cellText returns the normalised text of a table cell, ignoring nested tables
+func cellText(cell *goquery.Selection) string {
+	clone := cell.Clone()
+	clone.Find("table").Remove()
+	clone.Find("br").ReplaceWithHtml(" ")
+	return collapseWhitespace(clone.Text())
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}
+
+var whitespacePattern = regexp.MustCompile(`[\s\x{00a0}]+`)
+
+// collapseWhitespace trims a string and collapses whitespace runs (including &nbsp;) into single spaces
+func collapseWhitespace(s string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " "))
+}
+
+// HTMLToText renders an HTML document as readable plain text.
+// Block elements are separated by line breaks, list items are prefixed with
+// "- ", table cells are separated by " | " and link targets are kept in angle
+// brackets after the link text (e.g. "click here <http://example.com/>"),
+// so URL extraction on the result still finds them.
+func HTMLToText(htmlBody string) string {
+	root, err := html.Parse(strings.NewReader(htmlBody))
+	if err != nil {
+		return htmlBody
+	}
+
+	r := &textRenderer{}
+	r.render(root)
+	return r.String()
+}
+
+// blockElements start on a new line and end with a line break
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"tr": true, "ul": true,
+}
+
+// skippedElements never contribute text
+var skippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true,
+	"template": true, "title": true,
+}
+
+// textRenderer accumulates the plain text rendering of an HTML tree
+type textRenderer struct {
+	lines   []string
+	current strings.Builder
+	inPre   int
+}
+
+func (r *textRenderer) write(s string) {
+	if r.inPre > 0 {
+		parts := strings.Split(s, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				r.newline()
+			}
+			r.current.WriteString(part)
+		}
+		return
+	}
+
+	s = whitespacePattern.ReplaceAllString(s, " ")
+	if s == " " || s == "" {
+		if r.current.Len() > 0 && !strings.HasSuffix(r.current.String(), " ") {
+			r.current.WriteString(" ")
+		}
+		return
+	}
+	if r.current.Len() == 0 || strings.HasSuffix(r.current.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	r.current.WriteString(s)
+}
+
+func (r *textRenderer) newline() {
+	r.lines = append(r.lines, strings.TrimRight(r.current.String(), " "))
+	r.current.Reset()
+}
+
+// blockBreak ends the current line unless it is already empty
+func (r *textRenderer) blockBreak() {
+	if strings.TrimSpace(r.current.String()) != "" {
+		r.newline()
+	} else {
+		r.current.Reset()
+	}
+}
+
+func (r *textRenderer) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.write(n.Data)
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		r.renderChildren(n)
+		return
+	default:
+		return
+	}
+
+	tag := n.Data
+	if skippedElements[tag] {
+		return
+	}
+
+	switch tag {
+	case "br":
+		r.newline()
+		return
+	case "img":
+		if alt := attr(n, "alt"); alt != "" {
+			r.write(alt)
+		}
+		return
+	case "td", "th":
+		if r.current.Len() > 0 && strings.TrimSpace(r.current.String()) != "" {
+			r.write(" | ")
+		}
+		r.renderChildren(n)
+		return
+	case "a":
+		r.renderLink(n)
+		return
+	case "pre":
+		r.blockBreak()
+		r.inPre++
+		r.renderChildren(n)
+		r.inPre--
+		r.blockBreak()
+		return
+	}
+
+	if blockElements[tag] {
+		r.blockBreak()
+		if tag == "li" {
+			r.write("- ")
+		}
+		if tag == "p" || isHeading(tag) {
+			r.paragraphBreak()
+		}
+		r.renderChildren(n)
+		r.blockBreak()
+		if tag == "p" || tag == "table" || isHeading(tag) {
+			r.paragraphBreak()
+		}
+		return
+	}
+
+	r.renderChildren(n)
+}
+
+func isHeading(tag string) bool {
+	return len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6'
+}
+
+// paragraphBreak inserts an empty line unless one is already present
+func (r *textRenderer) paragraphBreak() {
+	if len(r.lines) > 0 && r.lines[len(r.lines)-1] != "" {
+		r.lines = append(r.lines, "")
+	}
+}
+
+func (r *textRenderer) renderChildren(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.render(c)
+	}
+}
+
+// renderLink writes the link text followed by its target, unless the text already is the target
+func (r *textRenderer) renderLink(n *html.Node) {
+	sub := &textRenderer{inPre: r.inPre}
+	sub.renderChildren(n)
+	text := collapseWhitespace(strings.Join(append(sub.lines, sub.current.String()), " "))
+
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		r.write(text)
+		return
+	}
+	href = strings.TrimPrefix(href, "mailto:")
+
+	switch {
+	case text == "":
+		r.write(href)
+	case text == href || strings.TrimSuffix(text, "/") == strings.TrimSuffix(href, "/"):
+		r.write(text)
+	default:
+		r.write(text + " <" + href + ">")
+	}
+}
+
+// String returns the rendered text with trailing blank lines removed
+func (r *textRenderer) String() string {
+	lines := r.lines
+	if rest := strings.TrimRight(r.current.String(), " "); rest != "" {
+		lines = append(lines, rest)
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// IsHTML reports whether a body looks like an HTML document rather than plain text
+func IsHTML(body string) bool {
+	head := strings.ToLower(strings.TrimSpace(body))
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	return strings.HasPrefix(head, "<!doctype html") ||
+		strings.Contains(head, "<html") ||
+		strings.Contains(head, "<body") ||
+		(strings.HasPrefix(head, "<") && strings.Contains(head, "<table"))
+}
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+func TestExtractHTMLTables_SpansAndNesting(t *testing.T) {
+	html := `<html><body>
+<table>
+  <tr><th>IP</th><th>Ports</th><th>Note</th></tr>
+  <tr><td rowspan="2">1.2.3.4</td><td colspan="2">22, 80</td></tr>
+  <tr><td>443</td><td>inner <table><tr><td>nested</td></tr></table></td></tr>
+</table>
+</body></html>`
+
+	tables, err := ExtractHTMLTables(html)
+	if err != nil {
+		t.Fatalf("ExtractHTMLTables failed: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("Expected 2 tables, got %d", len(tables))
+	}
+
+	outer := tables[0]
+	if !reflect.DeepEqual(outer.Header, []string{"IP", "Ports", "Note"}) {
+		t.Errorf("Unexpected header: %q", outer.Header)
+	}
+	expected := [][]string{
+		{"1.2.3.4", "22, 80", "22, 80"},
+		{"1.2.3.4", "443", "inner"},
+	}
+	if !reflect.DeepEqual(outer.Rows, expected) {
+		t.Errorf("Unexpected rows: %q", outer.Rows)
+	}
+
+	if tables[1].Header != nil || len(tables[1].Rows) != 1 || tables[1].Rows[0][0] != "nested" {
+		t.Errorf("Unexpected nested table: %+v", tables[1])
+	}
+
+	records := outer.Records()
+	if records[1]["Ports"] != "443" {
+		t.Errorf("Expected Ports 443, got %q", records[1]["Ports"])
+	}
+}
+
+func TestExtractHTMLTableAsCSV_QuotesCells(t *testing.T) {
+	rows, err := ExtractHTMLTableAsCSV(`<table><tr><td>a, b</td><td>c</td></tr></table>`)
+	if err != nil {
+		t.Fatalf("ExtractHTMLTableAsCSV failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0] != `"a, b",c` {
+		t.Errorf("Unexpected CSV rows: %q", rows)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	html := `<html><head><style>p {}</style></head><body>
+<p>Dear&nbsp;abuse team,</p>
+<p>Please remove <a href="http://bad.example/login">this page</a>.<br>Thanks</p>
+<ul><li>one</li><li>two</li></ul>
+</body></html>`
+
+	text := HTMLToText(html)
+	for _, want := range []string{
+		"Dear abuse team,",
+		"Please remove this page <http://bad.example/login>.\nThanks",
+		"- one\n- two",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in rendered text:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "p {}") {
+		t.Errorf("Style content leaked into text:\n%s", text)
+	}
+}
+
+func TestGetTextBody_HTMLOnlySample(t *testing.T) {
+	// The notice is the only text part of a multipart/mixed email
+	raw, err := os.ReadFile("../../testdata/sample_mails/latam.phishing.0.eml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := GetTextBody(serializedEmail, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := GetNonEmptyLineAfter(text, "phishing website hosted at:"); got != "http [://] compraslatam [.] online" {
+		t.Errorf("Expected the reported URL after the marker, got %q", got)
+	}
+	if strings.Contains(text, "<") || strings.Contains(text, "&#10;") {
+		t.Errorf("Expected no markup in rendered text:\n%s", text)
+	}
+}
@@ -0,0 +1,232 @@
+// Package common provides a shared model for phishing and brand-protection takedown notices
+package common
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// PhishingNotice is a phishing or brand-protection takedown notice as sent by
+// brand-protection vendors. Vendor parsers override what their template
+// states differently and turn it into events with Events.
+type PhishingNotice struct {
+	Brand  string
+	CaseID string
+	// IP is the hosting IP stated for all URLs of the notice
+	IP   string
+	Date *time.Time
+
+	// URLs are the reported (refanged) URLs or domains
+	URLs         []string
+	OfficialURLs []string
+	// ResolvedIPs maps reported URLs to the IP they are said to resolve to
+	ResolvedIPs map[string]string
+	Evidence    []events.UrlStore
+}
+
+var (
+	// Header checks are ordered: a line is an official or evidence header
+	// before it is a reported URL header ("legitimate brand url's:" vs "urls:")
+	phishingOfficialHeaders = []string{"legitimate brand url", "legitimate url", "legitimate site", "legitimate website", "legitimate domain",
+		"official url", "official site", "official website", "official domain", "genuine site", "genuine url", "authentic site"}
+	phishingEvidenceHeaders = []string{"evidence", "screenshot", "screen shot", "screen capture"}
+	phishingURLHeaders      = []string{"phishing content", "phishing url", "phishing site", "phishing link", "phishing page", "phishing domain",
+		"malicious content", "malicious url", "malicious link", "malicious site", "malicious domain", "abusive content",
+		"fraudulent url", "fraudulent site", "fraudulent website", "fraudulent domain", "infringing content", "infringing url",
+		"reported url", "offending url", "offending domain", "scam url", "scam site", "impersonating", "urls:", "url:", "url(s):",
+		"domain:", "domains:", "links:"}
+
+	phishingBrandMarkers = []string{"brand phished:", "phished brand:", "targeted brand:", "impersonated brand:", "brand name:", "brand:"}
+	phishingIPMarkers    = []string{"ip address:", "ip addresses:", "hosting ip:", "resolved ip:", "ip:"}
+
+	phishingBrandPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)our client's brand and name "([^"\n]+)"`),
+		regexp.MustCompile(`(?i)customers of our client, ([^\n]+)`),
+		regexp.MustCompile(`(?i)impersonating our client ([^\n:]+):`),
+	}
+	phishingCasePattern     = regexp.MustCompile(`(?i)\b(?:incident|case|ticket|reference|ref)(?:\s*(?:id|number|no\.?))?\s*[:#]\s*([a-z0-9][\w-]*\d[\w-]*)`)
+	phishingResolvesPattern = regexp.MustCompile(`(?i)(\S+)\s+\(?(?:resolves to|resolving to|resolved to|is hosted (?:at|on)|hosted (?:at|on))(?: ip)?:?\s+((?:\d{1,3}\.){3}\d{1,3})`)
+	phishingTrailingIP      = regexp.MustCompile(`(?i)\s+[(\[]?(?:ip:?\s*)?((?:\d{1,3}\.){3}\d{1,3})[)\]]?$`)
+	phishingHostPattern     = regexp.MustCompile(`(?i)^(?:[a-z][a-z0-9+.-]*://)?(?:[^\s/?#:@]*[a-z0-9-]\.[a-z][a-z0-9-]*|(?:\d{1,3}\.){3}\d{1,3})(?::\d+)?(?:[/?#]\S*)?$`)
+)
+
+// RefangURL strips list bullets from a reported URL and undoes the
+// defanging styles [://], [/], {.} and HXXP, then cleans it with CleanURL,
+// which handles [.], [:] and hxxp and removes spaces
+func RefangURL(url string) string {
+	url = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(url), "-*•>"))
+	url = strings.NewReplacer("[://]", "://", "[:/]", ":/", "{.}", ".", "[/]", "/", "HXXP", "http", "hxxxp", "http").Replace(url)
+	url = CleanURL(url)
+	return strings.TrimRight(url, ".,;")
+}
+
+// ExtractPhishingNotice extracts the brand, case reference, hosting IPs,
+// reported, official and evidence URLs of a takedown notice. Reported URLs are
+// taken from labelled blocks (one URL per line up to the next empty line).
+func ExtractPhishingNotice(text string) (*PhishingNotice, error) {
+	text = strings.ReplaceAll(text, "\r", "")
+	lines := strings.Split(text, "\n")
+
+	notice := &PhishingNotice{ResolvedIPs: make(map[string]string)}
+	notice.Brand = labelledValue(lines, phishingBrandMarkers)
+	if notice.Brand == "" {
+		for _, pattern := range phishingBrandPatterns {
+			if match := pattern.FindStringSubmatch(text); match != nil {
+				notice.Brand = strings.TrimSpace(match[1])
+				break
+			}
+		}
+	}
+	if match := phishingCasePattern.FindStringSubmatch(text); match != nil {
+		notice.CaseID = match[1]
+	}
+	notice.IP = IsIP(labelledValue(lines, phishingIPMarkers))
+
+	for _, match := range phishingResolvesPattern.FindAllStringSubmatch(text, -1) {
+		if url := RefangURL(match[1]); phishingHostPattern.MatchString(url) {
+			notice.ResolvedIPs[url] = match[2]
+		}
+	}
+
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		switch {
+		case containsAny(lower, phishingOfficialHeaders):
+			for _, block := range phishingURLBlock(lines, i) {
+				notice.OfficialURLs = append(notice.OfficialURLs, block.url)
+			}
+		case containsAny(lower, phishingEvidenceHeaders):
+			description := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.SplitN(line, ":", 2)[0], "-*• ")))
+			for _, block := range phishingURLBlock(lines, i) {
+				notice.Evidence = append(notice.Evidence, events.UrlStore{Description: description, URL: block.url})
+			}
+		case containsAny(lower, phishingURLHeaders):
+			for _, block := range phishingURLBlock(lines, i) {
+				notice.URLs = append(notice.URLs, block.url)
+				if block.ip != "" {
+					notice.ResolvedIPs[block.url] = block.ip
+				}
+			}
+		}
+	}
+	notice.URLs = uniqueURLs(notice.URLs)
+	notice.OfficialURLs = uniqueURLs(notice.OfficialURLs)
+
+	if len(notice.URLs) == 0 && notice.IP == "" {
+		return nil, NewParserError("no reported URL or IP found in phishing notice")
+	}
+	return notice, nil
+}
+
+type phishingBlockEntry struct {
+	url string
+	ip  string
+}
+
+// phishingURLBlock returns the URLs after the colon of the header line and on
+// the following lines, up to the first empty or non-URL line
+func phishingURLBlock(lines []string, header int) []phishingBlockEntry {
+	var entries []phishingBlockEntry
+	add := func(value string) bool {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return false
+		}
+		entry := phishingBlockEntry{}
+		if match := phishingTrailingIP.FindStringSubmatchIndex(value); match != nil && match[0] > 0 {
+			entry.ip = value[match[2]:match[3]]
+			value = value[:match[0]]
+		}
+		entry.url = RefangURL(value)
+		if !phishingHostPattern.MatchString(entry.url) {
+			return false
+		}
+		entries = append(entries, entry)
+		return true
+	}
+
+	if _, value, ok := strings.Cut(lines[header], ":"); ok {
+		add(value)
+	}
+	started := len(entries) > 0
+	for _, line := range lines[header+1:] {
+		if strings.TrimSpace(line) == "" {
+			if started {
+				break
+			}
+			continue
+		}
+		if !add(line) {
+			break
+		}
+		started = true
+	}
+	return entries
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// Events creates one phishing event per reported URL (or a single event for
+// an IP-only notice) with the official URL, case id, brand and evidence
+func (n *PhishingNotice) Events(parserName string) ([]*events.Event, error) {
+	officialURL := ""
+	if len(n.OfficialURLs) > 0 {
+		officialURL = n.OfficialURLs[0]
+	}
+
+	newEvent := func(url string) *events.Event {
+		event := events.NewEvent(parserName)
+		event.URL = url
+		event.IP = n.IP
+		if ip, ok := n.ResolvedIPs[url]; ok {
+			event.IP = ip
+		}
+		event.EventDate = n.Date
+
+		target := url
+		if target == "" {
+			target = event.IP
+		}
+		phishing := events.NewPhishingWithOfficialURL(officialURL)
+		phishing.PhishingTarget = target
+		event.EventTypes = []events.EventType{phishing}
+
+		if n.CaseID != "" {
+			event.AddEventDetail(&events.ExternalID{ID: n.CaseID})
+		}
+		if n.Brand != "" {
+			event.AddEventDetailSimple("brand", n.Brand)
+		}
+		if len(n.Evidence) > 0 {
+			evidence := &events.Evidence{}
+			for _, store := range n.Evidence {
+				evidence.AddEvidence(store)
+			}
+			event.AddEventDetail(evidence)
+		}
+		return event
+	}
+
+	var eventsList []*events.Event
+	for _, url := range n.URLs {
+		eventsList = append(eventsList, newEvent(url))
+	}
+	if len(eventsList) == 0 && n.IP != "" {
+		eventsList = append(eventsList, newEvent(""))
+	}
+
+	if len(eventsList) == 0 {
+		return nil, NewParserError("phishing notice contains no URLs")
+	}
+	return eventsList, nil
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+func TestExtractPhishingNotice(t *testing.T) {
+	text := `[Incident#SBU-622249]
+Hello,
+
+IP Address: 198.199.109.95
+
+Phishing Content:
+hxxps://login-example .codeanyapp .com /wpall/load3.php?id=1.146.160.29
+hxxps://login-example[.]codeanyapp[.]com/wpall/ (203.0.113.9)
+
+Evidence of malicious activity:
+https://urlscan.io/result/3e9f9071/
+
+Brand Phished: Example Bank
+Legitimate Brand URL's:
+ - http://bank.example
+
+Web: http://www.vendor.example`
+
+	notice, err := ExtractPhishingNotice(text)
+	if err != nil {
+		t.Fatalf("ExtractPhishingNotice failed: %v", err)
+	}
+	if notice.Brand != "Example Bank" || notice.CaseID != "SBU-622249" || notice.IP != "198.199.109.95" {
+		t.Errorf("Unexpected notice: %+v", notice)
+	}
+	if len(notice.URLs) != 2 || notice.URLs[0] != "https://login-example.codeanyapp.com/wpall/load3.php?id=1.146.160.29" {
+		t.Fatalf("Unexpected URLs: %v", notice.URLs)
+	}
+	if len(notice.OfficialURLs) != 1 || len(notice.Evidence) != 1 {
+		t.Fatalf("Unexpected official/evidence URLs: %v / %v", notice.OfficialURLs, notice.Evidence)
+	}
+
+	result, err := notice.Events("vendor")
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+	if result[0].IP != "198.199.109.95" || result[1].IP != "203.0.113.9" {
+		t.Errorf("Expected notice IP and per-URL IP, got %s / %s", result[0].IP, result[1].IP)
+	}
+	phishing := result[1].EventTypes[0].(*events.Phishing)
+	if phishing.PhishingTarget != "https://login-example.codeanyapp.com/wpall/" || phishing.OfficialURL != "http://bank.example" {
+		t.Errorf("Unexpected phishing type: %+v", phishing)
+	}
+}
+
+func TestExtractPhishingNotice_BrandSentenceAndResolution(t *testing.T) {
+	text := `We have been made aware of following site that is using our client's brand and name "Roundstone Finance" to scam their customers.
+
+Direct Links to Infringing Content:
+http://roundstone-fl[.]com/contact.html
+
+roundstone-fl[.]com resolves to 192.0.2.80
+
+Screenshot of infringing content: https://portal.example/images/1.jpg`
+
+	notice, err := ExtractPhishingNotice(text)
+	if err != nil {
+		t.Fatalf("ExtractPhishingNotice failed: %v", err)
+	}
+	if notice.Brand != "Roundstone Finance" || notice.CaseID != "" {
+		t.Errorf("Unexpected notice: %+v", notice)
+	}
+	if len(notice.URLs) != 1 || notice.URLs[0] != "http://roundstone-fl.com/contact.html" {
+		t.Fatalf("Unexpected URLs: %v", notice.URLs)
+	}
+	if notice.ResolvedIPs["roundstone-fl.com"] != "192.0.2.80" {
+		t.Errorf("Expected resolution line, got %v", notice.ResolvedIPs)
+	}
+	if len(notice.Evidence) != 1 || notice.Evidence[0].Description != "screenshot of infringing content" {
+		t.Errorf("Unexpected evidence: %+v", notice.Evidence)
+	}
+
+	if _, err := ExtractPhishingNotice("nothing to report"); err == nil {
+		t.Error("Expected error for notice without URLs")
+	}
+}
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "comvive.com", "acedatacenter.com") {
+		return nil, common.NewParserError("not from comvive.com or acedatacenter.com")
+	}
+
 	subject, _ := common.GetSubject(serializedEmail, false)
 	subjectLower := strings.ToLower(subject)
 
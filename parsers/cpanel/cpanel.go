@@ -16,6 +16,10 @@ func NewParser() *Parser {
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
+	// cPanel servers mark their mail with X-AntiAbuse headers
+	if _, ok := serializedEmail.Headers["x-antiabuse"]; !ok || !strings.Contains(bodyLower, "report an ip address abused") {
+		return nil, common.NewParserError("no cPanel abuse report")
+	}
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -1,7 +1,6 @@
 package cpragency
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/abusix/inbound-parsers/pkg/email"
@@ -15,19 +14,8 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-// stripHTML removes HTML tags from a string (simple implementation)
-func stripHTML(html string) string {
-	// Remove HTML tags
-	re := regexp.MustCompile(`<[^>]*>`)
-	text := re.ReplaceAllString(html, " ")
-	// Clean up extra whitespace
-	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
-	return strings.TrimSpace(text)
-}
-
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	body, _ := common.GetBody(serializedEmail, false)
-	body = stripHTML(body)
+	body, _ := common.GetTextBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 	subjectLower := strings.ToLower(subject)
 
@@ -42,9 +30,14 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		if dateHeaders, ok := serializedEmail.Headers["date"]; ok && len(dateHeaders) > 0 {
 		}
 
-		// Extract URL
-		url := common.FindStringWithoutMarkers(body, "the infringing content below:", "I confirm")
-		eventTemplate.URL = url
+		// Extract URL: the first link listed under the title of the work
+		block := common.FindStringWithoutMarkers(body, "the infringing content below:", "I confirm")
+		for _, line := range strings.Split(block, "\n") {
+			if common.IsURL(line) {
+				eventTemplate.URL = strings.TrimSpace(line)
+				break
+			}
+		}
 
 		// Check for IP addresses
 		if strings.Contains(body, "IP addresses:") {
@@ -28,6 +28,10 @@ func checkMarkers(body, subject string, markers []string) bool {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "csa.gov.sg", "akamai.com") {
+		return nil, common.NewParserError("not from csa.gov.sg or akamai.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
@@ -15,10 +15,11 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
-	body, _ := common.GetBody(serializedEmail, false)
-	// Strip HTML and convert to lower
-	re := regexp.MustCompile(`<[^>]*>`)
-	bodyClean := re.ReplaceAllString(body, " ")
+	if !common.SentFrom(serializedEmail, "cscglobal.com") {
+		return nil, common.NewParserError("not from cscglobal.com")
+	}
+
+	bodyClean, _ := common.GetTextBody(serializedEmail, false)
 	bodyLower := strings.ToLower(strings.ReplaceAll(bodyClean, "at :", "at:"))
 
 	subject, err := common.GetSubject(serializedEmail, true)
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "csirt.cz") {
+		return nil, common.NewParserError("not from csirt.cz")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
 
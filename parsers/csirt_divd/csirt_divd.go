@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "divd.nl") {
+		return nil, common.NewParserError("not from divd.nl")
+	}
+
 	subject, _ := common.GetSubject(serializedEmail, false)
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
@@ -21,6 +21,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dnofd.com") {
+		return nil, common.NewParserError("not from dnofd.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 func TestParsePhishing(t *testing.T) {
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnofd.com"},
 			"subject": {"#71706538 Phishing hosted at your site (autobrasilgerenciadorempresarial[.]com->191[.]96[.]56[.]3)"},
 			"date":    {"Tue, 27 Sep 2022 14:15:58 -0300"},
 		},
@@ -109,6 +110,7 @@ CSIRT.`,
 func TestParsePhishingNoIPOrURL(t *testing.T) {
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnofd.com"},
 			"subject": {"Phishing report"},
 			"date":    {"Tue, 27 Sep 2022 14:15:58 -0300"},
 		},
@@ -126,6 +128,7 @@ func TestParsePhishingNoIPOrURL(t *testing.T) {
 func TestParseNonPhishing(t *testing.T) {
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnofd.com"},
 			"subject": {"Some other abuse report"},
 			"date":    {"Tue, 27 Sep 2022 14:15:58 -0300"},
 		},
@@ -29,6 +29,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "muni.cz") {
+		return nil, common.NewParserError("not from muni.cz")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	if body == "" {
 		return []*events.Event{}, nil
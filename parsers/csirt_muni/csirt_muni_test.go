@@ -14,7 +14,7 @@ func TestParserBasic(t *testing.T) {
 
 	// Test with empty email
 	serializedEmail := &email.SerializedEmail{
-		Headers: make(map[string][]string),
+		Headers: map[string][]string{"from": {"abuse@muni.cz"}},
 		Body:    "",
 	}
 
@@ -39,6 +39,7 @@ Incident: Incident type: SSH brute force attacks
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@muni.cz"},
 			"subject": {"Test Subject"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 		},
@@ -84,6 +85,7 @@ Incident type: port scanning
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@muni.cz"},
 			"subject": {"Test / English Subject"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 		},
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "customvisuals.com") {
+		return nil, common.NewParserError("not from customvisuals.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, true)
 	subject, _ := common.GetSubject(serializedEmail, true)
 
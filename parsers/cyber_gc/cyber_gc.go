@@ -19,6 +19,10 @@ type Parser struct{}
 
 // Parse parses emails from Canadian Cyber Centre (@cyber.gc.ca, @ops.cyber.gc.ca)
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "gc.ca") {
+		return nil, common.NewParserError("not from gc.ca")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -694,37 +698,13 @@ func getHeaderDate(serializedEmail *email.SerializedEmail) *time.Time {
 	return nil
 }
 
-// magicDateTimeParse attempts to parse various date formats
+// magicDateTimeParse parses report dates, which Cyber GC writes day first
 func magicDateTimeParse(dateStr string) *time.Time {
-	if dateStr == "" {
+	parsed, err := common.ParseDateTime(dateStr, &common.DateOptions{Order: common.DateOrderDMY})
+	if err != nil {
 		return nil
 	}
-
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common formats
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04",
-		"2006-01-02",
-		"02/01/2006 15:04:05",
-		"01/02/2006 15:04:05",
-		"Jan 2 2006 15:04:05",
-		"Jan 02 2006 15:04:05",
-		"2 Jan 2006 15:04:05",
-		"02 Jan 2006 15:04:05",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t
-		}
-	}
-
-	// Try email.ParseDate as fallback
-	return email.ParseDate(dateStr)
+	return &parsed.Time
 }
 
 // GetPriority returns the parser priority (lower numbers run first)
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cyberint.io") {
+		return nil, common.NewParserError("not from cyberint.io")
+	}
+
 	// Get event date from email header
 	var eventDate *time.Time
 	if dateHeader, ok := serializedEmail.Headers["date"]; ok && len(dateHeader) > 0 {
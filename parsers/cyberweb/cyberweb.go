@@ -21,6 +21,10 @@ func NewParser() *Parser {
 // 1. XARF JSON attachments (xarf-report.json)
 // 2. Login attack / brute force reports from email body/subject
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cyberweb.com.br") {
+		return nil, common.NewParserError("not from cyberweb.com.br")
+	}
+
 	// First, try to find and parse XARF JSON attachment
 	if attachment, err := common.FindFirstAttachmentWithMimeType(serializedEmail, "xarf-report.json"); err == nil {
 		if event, err := p.parseXARFAttachment(attachment); err == nil {
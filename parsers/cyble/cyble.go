@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "cyble.com") {
+		return nil, common.NewParserError("not from cyble.com")
+	}
+
 	// Get event date from headers
 	var eventDate *string
 	if dateHeaders, ok := serializedEmail.Headers["date"]; ok && len(dateHeaders) > 0 {
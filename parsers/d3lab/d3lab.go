@@ -31,6 +31,10 @@ func NewParser() *Parser {
 
 // Parse parses the email and returns events
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "d3lab.net") {
+		return nil, common.NewParserError("not from d3lab.net")
+	}
+
 	// Get event date from headers
 	var eventDate *time.Time
 	if dateHeaders, ok := serializedEmail.Headers["date"]; ok && len(dateHeaders) > 0 {
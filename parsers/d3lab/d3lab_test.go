@@ -19,6 +19,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from": {"abuse@d3lab.net"},
 			"date": {"Mon, 02 Jan 2006 15:04:05 -0700"},
 		},
 		Body: body,
@@ -70,6 +71,7 @@ This is a phishing report without the specific marker.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@d3lab.net"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -97,6 +99,7 @@ func TestParseNonPhishing(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@d3lab.net"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {"Regular Email"},
 		},
@@ -120,6 +123,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@d3lab.net"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {"Phishing Alert"},
 		},
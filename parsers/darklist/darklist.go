@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "darklist.de") {
+		return nil, common.NewParserError("not from darklist.de")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
@@ -41,11 +45,11 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	if dateLine != "" {
 		// Replace dots with slashes and append timezone
 		dateLine = strings.ReplaceAll(dateLine, ".", "/") + " " + tz
-		eventDate = parseMagicDateTime(dateLine)
+		eventDate = common.ParseDate(dateLine)
 	} else if strings.Contains(body, "Date:") {
 		// Try to find "Date:" line
 		dateLine = common.FindStringWithoutMarkers(body, "Date:", "")
-		eventDate = parseMagicDateTime(dateLine)
+		eventDate = common.ParseDate(dateLine)
 	} else {
 		// Extract year from email date header
 		var year int
@@ -64,7 +68,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 				// Format: "DD MMM HH:MM:SS"
 				// Reconstruct as "MMM DD YYYY HH:MM:SS TZ"
 				dateStr := fmt.Sprintf("%s %s %d %s %s", parts[1], parts[0], year, parts[2], tz)
-				eventDate = parseMagicDateTime(dateStr)
+				eventDate = common.ParseDate(dateStr)
 			}
 		}
 
@@ -142,47 +146,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	return eventList, nil
 }
 
-// parseMagicDateTime attempts to parse datetime in various formats
-func parseMagicDateTime(dateStr string) *time.Time {
-	if dateStr == "" {
-		return nil
-	}
-
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common formats to try
-	formats := []string{
-		time.RFC3339,
-		time.RFC1123Z,
-		time.RFC1123,
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05.999999999",
-		"2006-01-02T15:04:05.999999999",
-		"Jan 2 2006 15:04:05",
-		"Jan 02 2006 15:04:05",
-		"2 Jan 2006 15:04:05",
-		"02 Jan 2006 15:04:05",
-		"Mon Jan 2 15:04:05 2006",
-		"Mon Jan 02 15:04:05 2006",
-		"2006-01-02",
-		"01/02/2006 15:04:05 MST",
-		"1/2/2006 15:04:05 MST",
-		"Jan 2 2006 15:04:05 MST",
-		"Jan 02 2006 15:04:05 MST",
-		"2 Jan 2006 15:04:05 MST",
-		"02 Jan 2006 15:04:05 MST",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t
-		}
-	}
-
-	return nil
-}
-
 // GetPriority returns the parser priority (lower numbers run first)
 func (p *Parser) GetPriority() int {
 	return 100 // Default vendor parser priority
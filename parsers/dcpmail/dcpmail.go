@@ -116,6 +116,10 @@ func parseHTMLTable(body string, eventTemplate *events.Event) ([]*events.Event,
 
 // Parse parses emails from @dcpmail
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dcpmail.it") {
+		return nil, common.NewParserError("not from dcpmail.it")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
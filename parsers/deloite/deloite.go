@@ -1,7 +1,6 @@
 package deloite
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/abusix/inbound-parsers/events"
@@ -16,24 +15,20 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "deloitte.es") {
+		return nil, common.NewParserError("not from deloitte.es")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := common.GetBody(serializedEmail, true)
+	text, err := common.GetTextBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// Strip HTML tags - replace <br> with newlines and remove other tags
-	text := strings.ReplaceAll(body, "<br>", "\n")
-	text = strings.ReplaceAll(text, "<br/>", "\n")
-	text = strings.ReplaceAll(text, "<br />", "\n")
-	// Remove all remaining HTML tags
-	re := regexp.MustCompile(`<[^>]+>`)
-	text = re.ReplaceAllString(text, "")
-
 	subjectLower := strings.ToLower(subject)
 
 	// Route based on subject
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "desmoweb.com") {
+		return nil, common.NewParserError("not from desmoweb.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
 		return nil, common.NewParserError("no email body found")
@@ -24,6 +24,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	if err != nil || body == "" {
 		return []*events.Event{}, nil
 	}
+	if !strings.Contains(body, "contents protected by copyright") {
+		return nil, common.NewParserError("not a copyright notice")
+	}
 
 	event := events.NewEvent("dgt")
 
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "digiturk.com.tr") {
+		return nil, common.NewParserError("not from digiturk.com.tr")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
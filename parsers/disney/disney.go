@@ -19,6 +19,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from @disney.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "disney.com") {
+		return nil, common.NewParserError("not from disney.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
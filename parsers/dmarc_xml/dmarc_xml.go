@@ -8,9 +8,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/abusix/inbound-parsers/events"
 	"github.com/abusix/inbound-parsers/parsers/common"
@@ -317,13 +315,12 @@ func extractFromGzip(gzipData []byte) ([]*events.Event, error) {
 	}
 	defer reader.Close()
 
-	var buf bytes.Buffer
-	_, err = buf.ReadFrom(reader)
+	content, err := common.ReadDecoded(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read gzip content: %w", err)
 	}
 
-	return parseXMLContent(buf.Bytes())
+	return parseXMLContent(content)
 }
 
 // extractFromZip extracts and parses XML from zip data
@@ -342,15 +339,14 @@ func extractFromZip(zipData []byte) ([]*events.Event, error) {
 			continue // Skip files that can't be opened
 		}
 
-		var buf bytes.Buffer
-		_, err = buf.ReadFrom(rc)
+		content, err := common.ReadDecoded(rc)
 		rc.Close()
 
 		if err != nil {
 			continue // Skip files that can't be read
 		}
 
-		events, err := parseXMLContent(buf.Bytes())
+		events, err := parseXMLContent(content)
 		if err != nil {
 			continue // Skip files that can't be parsed
 		}
@@ -402,19 +398,9 @@ func parseXMLContent(xmlData []byte) ([]*events.Event, error) {
 	domain := feedback.PolicyPublished.Domain
 
 	// Parse dates
-	var dateBegin, dateEnd *time.Time
-	if feedback.ReportMetadata.DateRange.Begin != "" {
-		if ts, err := strconv.ParseInt(feedback.ReportMetadata.DateRange.Begin, 10, 64); err == nil {
-			t := time.Unix(ts, 0)
-			dateBegin = &t
-		}
-	}
-	if feedback.ReportMetadata.DateRange.End != "" {
-		if ts, err := strconv.ParseInt(feedback.ReportMetadata.DateRange.End, 10, 64); err == nil {
-			t := time.Unix(ts, 0)
-			dateEnd = &t
-		}
-	}
+	// Reporters send unix timestamps, a few send ISO 8601 dates
+	dateBegin := common.ParseDate(feedback.ReportMetadata.DateRange.Begin)
+	dateEnd := common.ParseDate(feedback.ReportMetadata.DateRange.End)
 
 	// Create events for each record
 	var eventsList []*events.Event
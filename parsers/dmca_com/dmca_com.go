@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dmca.com") {
+		return nil, common.NewParserError("not from dmca.com")
+	}
+
 	// Get body with error if empty
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
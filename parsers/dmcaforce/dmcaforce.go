@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dmcaforce.com") {
+		return nil, common.NewParserError("not from dmcaforce.com")
+	}
+
 	// Get body with throws=True to match Python behavior
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
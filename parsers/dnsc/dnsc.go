@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dnsc.ro") {
+		return nil, common.NewParserError("not from dnsc.ro")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
 
@@ -12,6 +12,7 @@ func TestParser_Fraud(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"Fraud alert for IP 192.168.1.100"},
 			"date":    {"Mon, 18 Oct 2025 12:00:00 +0000"},
 		},
@@ -63,6 +64,7 @@ func TestParser_CyberSecurityIncident(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"Cyber Security Incident - IP 10.0.0.1"},
 			"date":    {"Mon, 18 Oct 2025 13:00:00 +0000"},
 		},
@@ -108,6 +110,7 @@ func TestParser_UnknownType(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"Some other alert type"},
 			"date":    {"Mon, 18 Oct 2025 12:00:00 +0000"},
 		},
@@ -130,6 +133,7 @@ func TestParser_CaseInsensitive(t *testing.T) {
 	// Test with uppercase FRAUD
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"FRAUD ALERT - 1.1.1.1"},
 			"date":    {"Mon, 18 Oct 2025 12:00:00 +0000"},
 		},
@@ -155,6 +159,7 @@ func TestParser_NoURLMarkers(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"Fraud alert - 8.8.8.8"},
 			"date":    {"Mon, 18 Oct 2025 12:00:00 +0000"},
 		},
@@ -181,6 +186,7 @@ func TestParser_NoIPInSubject(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@dnsc.ro"},
 			"subject": {"Fraud alert with no IP"},
 			"date":    {"Mon, 18 Oct 2025 12:00:00 +0000"},
 		},
@@ -13,6 +13,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "docusign.com") {
+		return nil, common.NewParserError("not from docusign.com")
+	}
+
 	// Get email body (throws=True in Python)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
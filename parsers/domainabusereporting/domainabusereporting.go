@@ -200,6 +200,10 @@ func generateEvents(urls []string, ip string, eventDate string, eventType events
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "domainabusereporting.com") {
+		return nil, common.NewParserError("not from domainabusereporting.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
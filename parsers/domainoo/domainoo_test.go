@@ -21,6 +21,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -81,6 +82,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -123,6 +125,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -158,6 +161,7 @@ Please take action immediately.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -185,6 +189,7 @@ func TestParseNonTrademark(t *testing.T) {
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {"Regular Email"},
 		},
@@ -216,6 +221,7 @@ Please take action.`
 
 	serializedEmail := &email.SerializedEmail{
 		Headers: map[string][]string{
+			"from":    {"abuse@domainoo.com"},
 			"date":    {"Mon, 02 Jan 2006 15:04:05 -0700"},
 			"subject": {subject},
 		},
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "doppel.com") {
+		return nil, common.NewParserError("not from doppel.com")
+	}
+
 	// Get body and subject
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
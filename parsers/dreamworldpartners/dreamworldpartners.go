@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dreamworldpartners.com") {
+		return nil, common.NewParserError("not from dreamworldpartners.com")
+	}
+
 	// Get body and subject
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
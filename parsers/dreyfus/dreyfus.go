@@ -19,6 +19,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from contact@dreyfus.fr
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "dreyfus.fr") {
+		return nil, common.NewParserError("not from dreyfus.fr")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,112 @@
+package dsn
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+var (
+	blocklistHintPattern = regexp.MustCompile(`(?i)black ?list|block ?list|deny ?list|\bdnsbl\b|\brbl\b|blocked using|listed (?:at|in|on|by)|spamhaus|spamcop|barracuda|sorbs|uceprotect|spamrats|abuseat|mailspike|psbl|backscatterer|invaluement|banned sending ip`)
+	// blocklistNamePattern captures the DNS zone of the list: "blocked using zen.spamhaus.org",
+	// "listed at bl.spamcop.net", "listed in b.barracudacentral.org"
+	blocklistNamePattern = regexp.MustCompile(`(?i)(?:blocked using|blacklisted (?:at|by|in|on)|blocklisted (?:at|by|in|on)|listed (?:at|in|on|by)|rejected by|found in)\s+(?:the\s+)?(?:dnsbl\s+|rbl\s+)?([a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.[a-z]{2,})`)
+	blocklistURLPattern  = regexp.MustCompile(`(?i)https?://(?:www\.)?([a-z0-9.-]+\.[a-z]{2,})`)
+
+	// blockedIPPatterns find the refused client address, most specific first;
+	// "mx.example.com[203.0.113.5]" is the remote MTA, not the client
+	blockedIPPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(?:client host|client|sending ip|ip address|\bip)\s*:?\s*\[?((?:\d{1,3}\.){3}\d{1,3})`),
+		regexp.MustCompile(`(?i)((?:\d{1,3}\.){3}\d{1,3})\]?\s+(?:is |has been |was )?(?:currently )?(?:listed|blacklisted|blocklisted|blocked)`),
+		regexp.MustCompile(`(?:^|[\s(:;])\[((?:\d{1,3}\.){3}\d{1,3})\]`),
+	}
+	remoteMTAIPPattern = regexp.MustCompile(`[a-zA-Z0-9.-]\[((?:\d{1,3}\.){3}\d{1,3})\]`)
+
+	// knownBlocklists are recognised in diagnostics that name the list but not its zone
+	knownBlocklists = []string{"spamhaus", "spamcop", "barracuda", "sorbs", "uceprotect", "spamrats", "abuseat",
+		"mailspike", "psbl", "backscatterer", "invaluement"}
+)
+
+// blocklistEvent returns a Blacklist event if the diagnostic of a recipient
+// says our sending IP was refused because it is listed
+func blocklistEvent(serializedEmail *email.SerializedEmail, report *Report, recipient Recipient) *events.Event {
+	diagnostic := recipient.DiagnosticCode
+	if !blocklistHintPattern.MatchString(diagnostic) {
+		return nil
+	}
+
+	ip := blockedIP(diagnostic)
+	if ip == "" {
+		return nil
+	}
+
+	event := events.NewEvent("dsn")
+	event.IP = ip
+	event.EventTypes = []events.EventType{events.NewBlacklist(blocklistName(diagnostic))}
+	event.EventDate = common.ParseDate(recipient.LastAttemptDate)
+	if event.EventDate == nil {
+		event.EventDate = common.ParseDate(report.ArrivalDate)
+	}
+	if event.EventDate == nil {
+		if dates := serializedEmail.Headers["date"]; len(dates) > 0 {
+			event.EventDate = email.ParseDate(dates[0])
+		}
+	}
+
+	event.AddEventDetailSimple("recipient", recipient.FinalRecipient)
+	event.AddEventDetailSimple("status", recipient.Status)
+	event.AddEventDetailSimple("diagnostic_code", diagnostic)
+	if recipient.RemoteMTA != "" {
+		event.AddEventDetailSimple("remote_mta", recipient.RemoteMTA)
+	}
+	return event
+}
+
+// blockedIP returns the client IP the diagnostic refers to, skipping the
+// addresses of the remote MTA
+func blockedIP(diagnostic string) string {
+	for _, pattern := range blockedIPPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(diagnostic, -1) {
+			if net.ParseIP(match[1]) != nil {
+				return match[1]
+			}
+		}
+	}
+
+	remote := make(map[string]bool)
+	for _, match := range remoteMTAIPPattern.FindAllStringSubmatch(diagnostic, -1) {
+		remote[match[1]] = true
+	}
+	for _, ip := range common.ExtractAllIPv4(diagnostic) {
+		if !remote[ip] {
+			return ip
+		}
+	}
+	return ""
+}
+
+// blocklistName returns the list zone, the domain of the list's lookup URL or
+// the known list name mentioned in a diagnostic
+func blocklistName(diagnostic string) string {
+	if match := blocklistNamePattern.FindStringSubmatch(diagnostic); match != nil {
+		return strings.ToLower(match[1])
+	}
+	lower := strings.ToLower(diagnostic)
+	for _, match := range blocklistURLPattern.FindAllStringSubmatch(lower, -1) {
+		for _, name := range knownBlocklists {
+			if strings.Contains(match[1], name) {
+				return match[1]
+			}
+		}
+	}
+	for _, name := range knownBlocklists {
+		if strings.Contains(lower, name) {
+			return name
+		}
+	}
+	return ""
+}
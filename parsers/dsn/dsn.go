@@ -0,0 +1,336 @@
+// Package dsn parses delivery status notifications (RFC 3464) and the common
+// non-standard bounce layouts of qmail, Exim and Postfix
+package dsn
+
+import (
+	"bufio"
+	"fmt"
+	"mime"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// OwnSenders are the From addresses or "@domain" suffixes of our outbound
+// notifications; bounces of messages sent from them are rejected
+var OwnSenders = []string{"@abusix.com", "@abusix.org"}
+
+// Report is a parsed delivery status notification
+type Report struct {
+	ReportingMTA string
+	ArrivalDate  string
+	Recipients   []Recipient
+
+	// Headers of the returned (original) message, if included
+	OriginalHeaders map[string][]string
+	// Standard is set for multipart/report; report-type=delivery-status messages
+	Standard bool
+}
+
+// Recipient is the delivery status of one recipient
+type Recipient struct {
+	FinalRecipient    string
+	OriginalRecipient string
+	// Action is failed, delayed, delivered, relayed or expanded
+	Action          string
+	Status          string
+	DiagnosticCode  string
+	RemoteMTA       string
+	LastAttemptDate string
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+var (
+	bounceSenderPattern  = regexp.MustCompile(`(?i)^(?:mailer-daemon|postmaster|mail-daemon|mailerdaemon)@`)
+	bounceSubjectPattern = regexp.MustCompile(`(?i)undeliver|delivery status notification|delivery failure|delivery has failed|returned mail|mail delivery failed|failure notice|nondeliverable|non-delivery|delivery problem|warning: message .* delayed`)
+
+	// Copies of the returned message in non-standard bounces start after one of these lines
+	originalMarkers = []string{
+		"--- below this line is a copy of the message.",
+		"------ this is a copy of the message, including all the headers. ------",
+		"------ this is a copy of the message's headers. ------",
+		"----- original message -----",
+		"------ original message ------",
+		"original message headers:",
+	}
+
+	statusPattern       = regexp.MustCompile(`\b([245]\.\d{1,3}\.\d{1,3})\b`)
+	smtpReplyPattern    = regexp.MustCompile(`\b[45]\d\d[ -]`)
+	addressLinePattern  = regexp.MustCompile(`^\s*<?([^<>@\s]+@[^<>@\s:]+?)>?:?\s*$`)
+	remoteHostPattern   = regexp.MustCompile(`(?i)(?:host|server)\s+([a-z0-9.-]+\.[a-z]{2,})\s*\[((?:\d{1,3}\.){3}\d{1,3})\]`)
+	qmailRemotePattern  = regexp.MustCompile(`^((?:\d{1,3}\.){3}\d{1,3}) (?:does not like recipient|failed after i sent the message)`)
+	postfixInlineReport = regexp.MustCompile(`(?i)^<([^<>@\s]+@[^<>\s]+)>:\s*(.+)$`)
+)
+
+// Parse turns bounces with blocklist information into Blacklist events and
+// rejects all other delivery status notifications
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	report := ParseReport(serializedEmail)
+	if report == nil {
+		return nil, common.NewParserError("not a delivery status notification")
+	}
+
+	var eventsList []*events.Event
+	for _, recipient := range report.Recipients {
+		if event := blocklistEvent(serializedEmail, report, recipient); event != nil {
+			eventsList = append(eventsList, event)
+		}
+	}
+	if len(eventsList) > 0 {
+		return eventsList, nil
+	}
+
+	status := ""
+	if len(report.Recipients) > 0 {
+		status = fmt.Sprintf(" (%s %s)", report.Recipients[0].Action, report.Recipients[0].Status)
+	}
+	if IsOwnMessage(report.OriginalHeaders) {
+		return nil, common.NewRejectError("bounce of own notification" + status)
+	}
+	return nil, common.NewRejectError("delivery status notification" + status)
+}
+
+// IsOwnMessage reports whether message headers carry one of OwnSenders as From
+func IsOwnMessage(headers map[string][]string) bool {
+	from, _ := common.GetFrom(&email.SerializedEmail{Headers: headers}, false)
+	if from == "" {
+		return false
+	}
+	for _, sender := range OwnSenders {
+		if from == sender || (strings.HasPrefix(sender, "@") && strings.HasSuffix(from, sender)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseReport parses a delivery status notification, standard or not; nil
+// means the message is not a bounce
+func ParseReport(serializedEmail *email.SerializedEmail) *Report {
+	if statusPart := findPart(serializedEmail.Parts, "message/delivery-status", "message/global-delivery-status"); statusPart != nil {
+		report := parseDeliveryStatus(partText(*statusPart))
+		report.Standard = true
+		if original := findPart(serializedEmail.Parts, "message/rfc822", "text/rfc822-headers", "message/global", "message/global-headers"); original != nil {
+			report.OriginalHeaders = parseHeaders(partText(*original))
+		}
+		if len(report.Recipients) > 0 {
+			return report
+		}
+	}
+
+	from, _ := common.GetFrom(serializedEmail, false)
+	subject, _ := common.GetSubject(serializedEmail, false)
+	if !bounceSenderPattern.MatchString(from) && !bounceSubjectPattern.MatchString(subject) {
+		return nil
+	}
+	return parsePlainBounce(bodyText(serializedEmail))
+}
+
+// parseDeliveryStatus parses the per-message block and the per-recipient
+// blocks of a message/delivery-status part
+func parseDeliveryStatus(text string) *Report {
+	report := &Report{}
+	for i, block := range splitBlocks(text) {
+		fields := parseHeaders(block)
+		if i == 0 && len(fields["final-recipient"]) == 0 {
+			report.ReportingMTA = fieldValue(fields, "reporting-mta")
+			report.ArrivalDate = fieldValue(fields, "arrival-date")
+			continue
+		}
+		if len(fields["final-recipient"]) == 0 && len(fields["action"]) == 0 {
+			continue
+		}
+		report.Recipients = append(report.Recipients, Recipient{
+			FinalRecipient:    fieldValue(fields, "final-recipient"),
+			OriginalRecipient: fieldValue(fields, "original-recipient"),
+			Action:            strings.ToLower(fieldValue(fields, "action")),
+			Status:            fieldValue(fields, "status"),
+			DiagnosticCode:    fieldValue(fields, "diagnostic-code"),
+			RemoteMTA:         fieldValue(fields, "remote-mta"),
+			LastAttemptDate:   fieldValue(fields, "last-attempt-date"),
+		})
+	}
+	return report
+}
+
+// fieldValue returns a DSN field without its type ("rfc822;", "dns;", "smtp;")
+func fieldValue(fields map[string][]string, name string) string {
+	if len(fields[name]) == 0 {
+		return ""
+	}
+	value := fields[name][0]
+	if kind, rest, ok := strings.Cut(value, ";"); ok && !strings.ContainsAny(kind, " <@") {
+		value = rest
+	}
+	return strings.TrimSpace(value)
+}
+
+// parsePlainBounce parses the human readable bounce layouts: one block per
+// recipient address line followed by the remote server's answer
+func parsePlainBounce(text string) *Report {
+	text = strings.ReplaceAll(text, "\r", "")
+	lower := strings.ToLower(text)
+
+	report := &Report{}
+	for _, marker := range originalMarkers {
+		if idx := strings.Index(lower, marker); idx != -1 {
+			original := strings.TrimLeft(text[idx+len(marker):], "\n")
+			report.OriginalHeaders = parseHeaders(original)
+			text = text[:idx]
+			break
+		}
+	}
+
+	var current *Recipient
+	flush := func() {
+		if current != nil && current.DiagnosticCode != "" {
+			current.DiagnosticCode = strings.TrimSpace(current.DiagnosticCode)
+			if match := statusPattern.FindStringSubmatch(current.DiagnosticCode); match != nil {
+				current.Status = match[1]
+			}
+			current.Action = "failed"
+			if strings.HasPrefix(current.Status, "4.") {
+				current.Action = "delayed"
+			}
+			report.Recipients = append(report.Recipients, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := postfixInlineReport.FindStringSubmatch(trimmed); match != nil && !addressLinePattern.MatchString(trimmed) {
+			flush()
+			current = &Recipient{FinalRecipient: match[1], DiagnosticCode: match[2]}
+			if host := remoteHostPattern.FindStringSubmatch(match[2]); host != nil {
+				current.RemoteMTA = host[1]
+			}
+			continue
+		}
+		if match := addressLinePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &Recipient{FinalRecipient: match[1]}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if trimmed == "" {
+			if current.DiagnosticCode != "" {
+				flush()
+			}
+			continue
+		}
+		if host := remoteHostPattern.FindStringSubmatch(trimmed); host != nil && current.RemoteMTA == "" {
+			current.RemoteMTA = host[1]
+		}
+		if match := qmailRemotePattern.FindStringSubmatch(trimmed); match != nil && current.RemoteMTA == "" {
+			current.RemoteMTA = match[1]
+		}
+		if smtpReplyPattern.MatchString(trimmed) || strings.HasPrefix(strings.ToLower(trimmed), "remote host said:") || current.DiagnosticCode != "" {
+			current.DiagnosticCode += " " + trimmed
+		}
+	}
+	flush()
+
+	if len(report.Recipients) == 0 {
+		return nil
+	}
+	return report
+}
+
+// splitBlocks splits header-like text at empty lines
+func splitBlocks(text string) []string {
+	text = strings.ReplaceAll(text, "\r", "")
+	var blocks []string
+	for _, block := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(block) != "" {
+			blocks = append(blocks, strings.Trim(block, "\n"))
+		}
+	}
+	return blocks
+}
+
+// parseHeaders parses a header block (up to the first empty line) with
+// lowercased names, tolerating malformed lines
+func parseHeaders(text string) map[string][]string {
+	text = strings.TrimLeft(strings.ReplaceAll(text, "\r", ""), "\n")
+	if end := strings.Index(text, "\n\n"); end != -1 {
+		text = text[:end]
+	}
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(text + "\n\n")))
+	header, _ := reader.ReadMIMEHeader()
+
+	headers := make(map[string][]string, len(header))
+	for name, values := range header {
+		headers[strings.ToLower(name)] = values
+	}
+	return headers
+}
+
+func findPart(parts []email.EmailPart, contentTypes ...string) *email.EmailPart {
+	for i := range parts {
+		for _, contentType := range contentTypes {
+			if strings.EqualFold(mediaType(parts[i].ContentType), contentType) {
+				return &parts[i]
+			}
+		}
+		if part := findPart(parts[i].Parts, contentTypes...); part != nil {
+			return part
+		}
+	}
+	return nil
+}
+
+func mediaType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+	return contentType
+}
+
+func partText(part email.EmailPart) string {
+	switch body := part.Body.(type) {
+	case string:
+		return body
+	case []byte:
+		return string(body)
+	}
+	// message/rfc822 parts serialized with their own headers
+	if len(part.Parts) > 0 && len(part.Parts[0].Headers) > 0 {
+		var lines []string
+		for name, values := range part.Parts[0].Headers {
+			for _, value := range values {
+				lines = append(lines, name+": "+value)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// bodyText returns the body of a message, falling back to its first text/plain part
+func bodyText(serializedEmail *email.SerializedEmail) string {
+	if body, _ := common.GetBody(serializedEmail, false); body != "" {
+		return body
+	}
+	if part := findPart(serializedEmail.Parts, "text/plain"); part != nil {
+		return partText(*part)
+	}
+	return ""
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityFormat
+}
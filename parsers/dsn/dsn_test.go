@@ -0,0 +1,147 @@
+package dsn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+const deliveryStatus = `Reporting-MTA: dns; mail.isp.example
+Arrival-Date: Sat, 18 Oct 2025 10:00:00 +0000
+
+Final-Recipient: rfc822; user@remote.example
+Action: failed
+Status: 5.7.1
+Remote-MTA: dns; mx.remote.example
+Diagnostic-Code: smtp; 554 5.7.1 Service unavailable; Client host
+ [192.0.2.25] blocked using zen.spamhaus.org; https://www.spamhaus.org/query/ip/192.0.2.25
+Last-Attempt-Date: Sat, 18 Oct 2025 10:00:05 +0000
+
+Final-Recipient: rfc822; other@remote.example
+Action: failed
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 5.1.1 <other@remote.example>: Recipient address rejected
+`
+
+func standardBounce(original string) *email.SerializedEmail {
+	return &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":         {"MAILER-DAEMON@mail.isp.example"},
+			"subject":      {"Undelivered Mail Returned to Sender"},
+			"content-type": {`multipart/report; report-type=delivery-status; boundary="b"`},
+		},
+		Parts: []email.EmailPart{
+			{ContentType: "text/plain", Body: "This is the mail system at host mail.isp.example."},
+			{ContentType: "message/delivery-status", Body: deliveryStatus},
+			{ContentType: "text/rfc822-headers", Body: original},
+		},
+	}
+}
+
+func TestParseReport_Standard(t *testing.T) {
+	report := ParseReport(standardBounce("From: reports@customer.example\nSubject: hello\n"))
+	if report == nil || !report.Standard {
+		t.Fatalf("Expected standard report, got %+v", report)
+	}
+	if report.ReportingMTA != "mail.isp.example" || len(report.Recipients) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	recipient := report.Recipients[0]
+	if recipient.FinalRecipient != "user@remote.example" || recipient.Action != "failed" || recipient.Status != "5.7.1" || recipient.RemoteMTA != "mx.remote.example" {
+		t.Errorf("Unexpected recipient: %+v", recipient)
+	}
+}
+
+func TestParser_BlocklistBounce(t *testing.T) {
+	result, err := NewParser().Parse(standardBounce("From: reports@customer.example\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 1 || result[0].IP != "192.0.2.25" {
+		t.Fatalf("Expected one blacklist event for the listed IP, got %+v", result)
+	}
+	blacklist := result[0].EventTypes[0].(*events.Blacklist)
+	if blacklist.ListName != "zen.spamhaus.org" {
+		t.Errorf("Expected zen.spamhaus.org, got %q", blacklist.ListName)
+	}
+	if result[0].EventDate == nil || result[0].EventDate.Second() != 5 {
+		t.Errorf("Expected Last-Attempt-Date as event date, got %v", result[0].EventDate)
+	}
+}
+
+func TestParser_OwnBounceRejected(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":    {"MAILER-DAEMON@mx.customer.example"},
+			"subject": {"failure notice"},
+		},
+		Body: `Hi. This is the qmail-send program at mx.customer.example.
+I'm afraid I wasn't able to deliver your message to the following addresses.
+
+<abuse@customer.example>:
+198.51.100.4 does not like recipient.
+Remote host said: 550 5.1.1 <abuse@customer.example>: mailbox unavailable
+Giving up on 198.51.100.4.
+
+--- Below this line is a copy of the message.
+
+From: Abuse Reports <reports@abusix.com>
+Subject: Abuse report for 192.0.2.1
+
+report`,
+	}
+
+	report := ParseReport(serializedEmail)
+	if report == nil || len(report.Recipients) != 1 {
+		t.Fatalf("Expected one recipient, got %+v", report)
+	}
+	if report.Recipients[0].Status != "5.1.1" || report.Recipients[0].RemoteMTA != "198.51.100.4" {
+		t.Errorf("Unexpected recipient: %+v", report.Recipients[0])
+	}
+
+	_, err := NewParser().Parse(serializedEmail)
+	var rejectErr *common.RejectError
+	if !errors.As(err, &rejectErr) || rejectErr.Reason != "bounce of own notification (failed 5.1.1)" {
+		t.Errorf("Expected own bounce to be rejected, got %v", err)
+	}
+}
+
+func TestParseReport_EximAndPostfix(t *testing.T) {
+	exim := `This message was created automatically by mail delivery software.
+
+A message that you sent could not be delivered to one or more of its
+recipients. This is a permanent error. The following address(es) failed:
+
+  user@remote.example
+    host mx.remote.example [203.0.113.5]
+    SMTP error from remote mail server after RCPT TO:<user@remote.example>:
+    550 5.7.1 192.0.2.77 is listed at bl.spamcop.net
+
+------ This is a copy of the message, including all the headers. ------
+From: someone@isp.example
+`
+	postfix := `<user@remote.example>: host mx.remote.example[203.0.113.5] said: 554 5.7.1
+    Rejected: IP [192.0.2.78] is blacklisted (in reply to RCPT TO command)`
+
+	for _, tc := range []struct {
+		body, ip, list string
+	}{
+		{exim, "192.0.2.77", "bl.spamcop.net"},
+		{postfix, "192.0.2.78", ""},
+	} {
+		serializedEmail := &email.SerializedEmail{
+			Headers: map[string][]string{"from": {"mailer-daemon@isp.example"}, "subject": {"Mail delivery failed"}},
+			Body:    tc.body,
+		}
+		result, err := NewParser().Parse(serializedEmail)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if len(result) != 1 || result[0].IP != tc.ip || result[0].EventTypes[0].(*events.Blacklist).ListName != tc.list {
+			t.Errorf("Unexpected events for %q: %+v", tc.ip, result[0])
+		}
+	}
+}
@@ -17,6 +17,10 @@ var ipPattern = regexp.MustCompile(`(?i)(IP Add?ress:)[^.0-9]*(\[?\d{0,3}\[?\.\]
 
 // Parse parses emails from easysol.net
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "easysol.net") {
+		return nil, common.NewParserError("not from easysol.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails for ebay phishing reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ebay.com") {
+		return nil, common.NewParserError("not from ebay.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
 		return nil, err
@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse parses emails for ebrand trademark and phishing reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ebrand.com") {
+		return nil, common.NewParserError("not from ebrand.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails for ebs child abuse and illegal advertisement reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "eco.de") {
+		return nil, common.NewParserError("not from eco.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails for eca web hack reports from Ukrainian authorities
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "eca.gov.ua") {
+		return nil, common.NewParserError("not from eca.gov.ua")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -6,7 +6,6 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/csv"
-	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -204,7 +203,7 @@ func getCSVFromZip(rawBody []byte) ([]string, error) {
 			}
 			defer rc.Close()
 
-			content, err := io.ReadAll(rc)
+			content, err := common.ReadDecoded(rc)
 			if err != nil {
 				continue
 			}
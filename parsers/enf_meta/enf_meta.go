@@ -13,6 +13,9 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "enf-meta.com") {
+		return nil, common.NewParserError("not from enf-meta.com")
+	}
 	body, _ := common.GetBody(serializedEmail, false)
 	_ = body
 
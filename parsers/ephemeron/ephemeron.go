@@ -121,6 +121,10 @@ func parseLoginAttack(logs string, serializedEmail *email.SerializedEmail) ([]*e
 
 // Parse parses emails from @ephemeron.org
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ephemeron.org") {
+		return nil, common.NewParserError("not from ephemeron.org")
+	}
+
 	if len(serializedEmail.Parts) < 3 {
 		return nil, common.NewParserError(fmt.Sprintf("expected at least 3 parts, got %d", len(serializedEmail.Parts)))
 	}
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @veebimajutus2.eq.ee for malicious activity reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "eq.ee") {
+		return nil, common.NewParserError("not from eq.ee")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
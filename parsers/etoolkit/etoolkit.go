@@ -14,6 +14,11 @@ type Parser struct{}
 
 // Parse parses emails from @etoolkit for copyright, bot, and malware reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	from, _ := common.GetFrom(serializedEmail, false)
+	if !strings.Contains(from, "@etoolkit") {
+		return nil, common.NewParserError("not from etoolkit")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -24,7 +29,10 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	if dateHeaders, ok := serializedEmail.Headers["date"]; ok && len(dateHeaders) > 0 {
 		event.EventDate = email.ParseDate(dateHeaders[0])
 	}
-	event.IP = subjectLower
+	event.IP = common.ExtractOneIP(subjectLower)
+	if event.IP == "" {
+		return nil, common.NewParserError("no IP in subject")
+	}
 
 	if strings.Contains(subjectLower, "copyright") {
 		event.EventTypes = []events.EventType{events.NewCopyright("", "", "")}
@@ -32,6 +40,8 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		event.EventTypes = []events.EventType{events.NewBot("")}
 	} else if strings.Contains(subjectLower, "malware") {
 		event.EventTypes = []events.EventType{events.NewMalware("")}
+	} else {
+		return nil, common.NewParserError("unknown report type")
 	}
 
 	return []*events.Event{event}, nil
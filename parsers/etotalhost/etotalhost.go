@@ -52,6 +52,10 @@ func parseSpamAndFraud(serializedEmail *email.SerializedEmail, body string, even
 
 // Parse parses emails from @etotalhost for web hack, spam, and fraud reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "etotalhost.com") {
+		return nil, common.NewParserError("not from etotalhost.com")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, false)
 	if err != nil {
 		return nil, err
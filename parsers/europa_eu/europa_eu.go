@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from europa.eu for malicious activity reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "europa.eu") {
+		return nil, common.NewParserError("not from europa.eu")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,10 @@ var datePattern = regexp.MustCompile(`\d{4}:\d{2}:\d{2}:\d{2}`)
 
 // Parse parses emails from marksitkowski@exemail.com.au for bot reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "exemail.com.au") {
+		return nil, common.NewParserError("not from exemail.com.au")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
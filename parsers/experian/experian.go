@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @experian.com for malicious activity reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "experian.com") {
+		return nil, common.NewParserError("not from experian.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
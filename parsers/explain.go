@@ -0,0 +1,348 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// maxExplainedEvents is the number of events whose field sources are traced
+const maxExplainedEvents = 10
+
+// maxMarkerLength bounds the text kept in front of an extracted value
+const maxMarkerLength = 80
+
+// Attempt outcomes
+const (
+	OutcomeMatched          = "matched"
+	OutcomeMatchedWithError = "matched_with_error"
+	OutcomeNoEvents         = "no_events"
+	OutcomeIgnored          = "ignored"
+	OutcomeRejected         = "rejected"
+	OutcomeNewType          = "new_type"
+	OutcomeDeclined         = "declined"
+	OutcomeError            = "error"
+	OutcomePanicked         = "panicked"
+	OutcomeTimedOut         = "timed_out"
+	OutcomeQuarantined      = "quarantined"
+)
+
+// Explanation describes how an email was routed through the parsers
+type Explanation struct {
+	// Attempts lists the parsers tried, in order, up to the chosen one
+	Attempts []Attempt `json:"attempts"`
+	// Chosen is the parser whose events were emitted or that ignored or
+	// rejected the email, empty if none matched
+	Chosen string `json:"chosen,omitempty"`
+	// Fields tells where the values of the first events were found
+	Fields []FieldSource `json:"fields,omitempty"`
+}
+
+// Attempt is one parser run on the email
+type Attempt struct {
+	Parser   string `json:"parser"`
+	Priority int    `json:"priority"`
+	Tier     string `json:"tier"`
+	Outcome  string `json:"outcome"`
+	// Reason is the error of the parser, or why it matched or not
+	Reason     string  `json:"reason"`
+	Events     int     `json:"events"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// FieldSource is the place of the email an event field was extracted from
+type FieldSource struct {
+	Event int    `json:"event"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+	// Source is subject, body, attachment, header or derived when the value
+	// does not appear verbatim in the email (e.g. refanged or computed)
+	Source string `json:"source"`
+	// Location is the content type of the body part, the attachment file
+	// name or the header name
+	Location string `json:"location,omitempty"`
+	// Marker is the text in front of the value on its line
+	Marker string `json:"marker,omitempty"`
+	Line   int    `json:"line,omitempty"`
+}
+
+// Explain parses an email like ParseEmailStream and records the parsers
+// tried and the sources of the extracted fields. If parsers is nil, all
+// registered parsers are tried. emit may be nil.
+func Explain(serializedEmail *email.SerializedEmail, metadata map[string]interface{}, parsers []ParserWrapper, emit base.EmitFunc) (int, *Explanation, error) {
+	return ExplainContext(context.Background(), serializedEmail, metadata, parsers, emit)
+}
+
+// ExplainContext is Explain stopping when ctx is done
+func ExplainContext(ctx context.Context, serializedEmail *email.SerializedEmail, metadata map[string]interface{}, parsers []ParserWrapper, emit base.EmitFunc) (int, *Explanation, error) {
+	if parsers == nil {
+		parsers = AllParsers()
+	}
+	var traced []*events.Event
+	recorder := &attemptRecorder{}
+	count, err := parseEmailStream(ctx, parsers, serializedEmail, func(event *events.Event) error {
+		if len(traced) < maxExplainedEvents {
+			traced = append(traced, event)
+		}
+		if emit == nil {
+			return nil
+		}
+		return emit(event)
+	}, recorder)
+
+	explanation := &Explanation{Attempts: recorder.attempts}
+	if explanation.Attempts == nil {
+		explanation.Attempts = []Attempt{}
+	}
+	var classifiedErr *ClassifiedError
+	if count > 0 || errors.As(err, &classifiedErr) {
+		explanation.Chosen = explanation.Attempts[len(explanation.Attempts)-1].Parser
+	}
+	if count > 0 {
+		explanation.Fields = traceFields(serializedEmail, traced)
+	}
+	return count, explanation, err
+}
+
+// attemptRecorder collects the attempts of parseEmailStream and passes them
+// to the observer
+type attemptRecorder struct {
+	attempts []Attempt
+	started  time.Time
+	// last is the parser of the last attempt
+	last string
+	// discard drops the attempts once observed
+	discard  bool
+	observer Observer
+}
+
+func (r *attemptRecorder) start() {
+	r.started = time.Now()
+}
+
+// skip records a parser that was not run
+func (r *attemptRecorder) skip(pw ParserWrapper, err error) Attempt {
+	r.start()
+	return r.finish(pw, 0, err)
+}
+
+func (r *attemptRecorder) finish(pw ParserWrapper, emitted int, err error) Attempt {
+	outcome, reason := classifyAttempt(emitted, err)
+	attempt := Attempt{
+		Parser:     pw.Name(),
+		Priority:   pw.Priority,
+		Tier:       priorityTier(pw.Priority),
+		Outcome:    outcome,
+		Reason:     reason,
+		Events:     emitted,
+		DurationMS: float64(time.Since(r.started).Microseconds()) / 1000,
+	}
+	r.last = attempt.Parser
+	if r.observer != nil {
+		r.observer.ParserAttempted(attempt)
+	}
+	if !r.discard {
+		r.attempts = append(r.attempts, attempt)
+	}
+	return attempt
+}
+
+func classifyAttempt(emitted int, err error) (string, string) {
+	if err == nil {
+		if emitted > 0 {
+			if emitted == 1 {
+				return OutcomeMatched, "emitted 1 event"
+			}
+			return OutcomeMatched, fmt.Sprintf("emitted %d events", emitted)
+		}
+		return OutcomeNoEvents, "returned no events"
+	}
+	if emitted > 0 {
+		return OutcomeMatchedWithError, err.Error()
+	}
+
+	if errors.Is(err, errQuarantined) {
+		return OutcomeQuarantined, err.Error()
+	}
+	var parserPanic *ParserPanic
+	var timeoutErr *ParserTimeoutError
+	var ignoreErr *common.IgnoreError
+	var rejectErr *common.RejectError
+	var newTypeErr *common.NewTypeError
+	var parserErr *common.ParserError
+	switch {
+	case errors.As(err, &parserPanic):
+		return OutcomePanicked, err.Error()
+	case errors.As(err, &timeoutErr):
+		return OutcomeTimedOut, err.Error()
+	case errors.As(err, &ignoreErr):
+		return OutcomeIgnored, err.Error()
+	case errors.As(err, &rejectErr):
+		return OutcomeRejected, err.Error()
+	case errors.As(err, &newTypeErr):
+		return OutcomeNewType, err.Error()
+	case errors.As(err, &parserErr):
+		return OutcomeDeclined, err.Error()
+	}
+	return OutcomeError, err.Error()
+}
+
+// priorityTier names the tier of a parser priority (see the base.Priority
+// constants)
+func priorityTier(priority int) string {
+	switch {
+	case priority < base.PriorityFormat:
+		return "preprocessor"
+	case priority < base.PriorityVendor:
+		return "format"
+	case priority < base.PriorityFallbackZX:
+		return "vendor"
+	case priority < base.PriorityFallbackZY:
+		return "fallback_zx"
+	case priority < base.PriorityFallbackZZ:
+		return "fallback_zy"
+	}
+	return "fallback_zz"
+}
+
+// textSource is a searchable text of the email
+type textSource struct {
+	source   string
+	location string
+	text     string
+}
+
+// traceFields looks up the extracted values of the events in the email
+func traceFields(serializedEmail *email.SerializedEmail, evs []*events.Event) []FieldSource {
+	sources := emailSources(serializedEmail)
+	var fields []FieldSource
+	for i, event := range evs {
+		for _, field := range []struct{ name, value string }{
+			{"ip", event.IP},
+			{"url", event.URL},
+			{"domain", event.Domain},
+			{"report_id", event.ReportID},
+		} {
+			if field.value == "" {
+				continue
+			}
+			fields = append(fields, locate(sources, i, field.name, field.value))
+		}
+	}
+	return fields
+}
+
+func locate(sources []textSource, event int, field, value string) FieldSource {
+	result := FieldSource{Event: event, Field: field, Value: value, Source: "derived"}
+	needle := strings.ToLower(value)
+	for _, source := range sources {
+		for n, line := range strings.Split(source.text, "\n") {
+			lower := strings.ToLower(line)
+			idx := strings.Index(lower, needle)
+			if idx < 0 {
+				continue
+			}
+			// Lowercasing may change the byte length of non-ASCII text
+			if len(lower) != len(line) {
+				line = lower
+			}
+			result.Source, result.Location = source.source, source.location
+			result.Marker = marker(line[:idx])
+			if source.source != "subject" && source.source != "header" {
+				result.Line = n + 1
+			}
+			return result
+		}
+	}
+	return result
+}
+
+// marker returns the end of the text in front of a value, e.g. "Source IP:"
+func marker(prefix string) string {
+	prefix = strings.TrimSpace(prefix)
+	if len(prefix) > maxMarkerLength {
+		prefix = prefix[len(prefix)-maxMarkerLength:]
+	}
+	return strings.ToValidUTF8(prefix, "")
+}
+
+// emailSources returns the texts of the email in lookup order: subject,
+// body and inline parts, attachments, then the other headers
+func emailSources(serializedEmail *email.SerializedEmail) []textSource {
+	var sources, attachments []textSource
+	if subject, _ := common.GetSubject(serializedEmail, false); subject != "" {
+		sources = append(sources, textSource{source: "subject", text: subject})
+	}
+	if body, _ := common.GetBody(serializedEmail, false); body != "" {
+		sources = append(sources, textSource{source: "body", location: "body", text: body})
+	}
+
+	var walk func(parts []email.EmailPart)
+	walk = func(parts []email.EmailPart) {
+		for _, part := range parts {
+			walk(part.Parts)
+			text := partText(part.Body)
+			if text == "" {
+				continue
+			}
+			if filename, ok := attachmentName(part); ok {
+				attachments = append(attachments, textSource{source: "attachment", location: filename, text: text})
+				continue
+			}
+			sources = append(sources, textSource{source: "body", location: part.ContentType, text: text})
+		}
+	}
+	walk(serializedEmail.Parts)
+	sources = append(sources, attachments...)
+
+	names := make([]string, 0, len(serializedEmail.Headers))
+	for name := range serializedEmail.Headers {
+		if name != "subject" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range serializedEmail.Headers[name] {
+			sources = append(sources, textSource{source: "header", location: name, text: value})
+		}
+	}
+	return sources
+}
+
+func partText(body interface{}) string {
+	switch body := body.(type) {
+	case string:
+		return body
+	case []byte:
+		return string(body)
+	}
+	return ""
+}
+
+// attachmentName returns the file name of an attachment part; parts without
+// a content-disposition are inline
+func attachmentName(part email.EmailPart) (string, bool) {
+	for _, disposition := range part.Headers["content-disposition"] {
+		kind, params, err := mime.ParseMediaType(disposition)
+		if err == nil && (kind == "attachment" || params["filename"] != "") {
+			if params["filename"] != "" {
+				return params["filename"], true
+			}
+			return "attachment", true
+		}
+		if err != nil && strings.Contains(strings.ToLower(disposition), "attachment") {
+			return "attachment", true
+		}
+	}
+	return "", false
+}
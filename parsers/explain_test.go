@@ -0,0 +1,99 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// stubParser returns fixed events or a fixed error
+type stubParser struct {
+	events []*events.Event
+	err    error
+}
+
+func (p *stubParser) Parse(*email.SerializedEmail) ([]*events.Event, error) {
+	return p.events, p.err
+}
+
+func (p *stubParser) GetPriority() int { return 0 }
+
+func TestExplain(t *testing.T) {
+	event := events.NewEvent("stub")
+	event.IP = "192.0.2.10"
+	event.URL = "http://example.com/phish"
+	event.ReportID = "TICKET-42"
+
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"subject":    {"Abuse report [TICKET-42]"},
+			"x-reporter": {"192.0.2.99"},
+		},
+		Body: "Hello,\n\nSource IP: 192.0.2.10\n",
+		Parts: []email.EmailPart{{
+			Headers: map[string][]string{"content-disposition": {`attachment; filename="report.csv"`}},
+			Body:    "url\nhttp://example.com/phish\n",
+		}},
+	}
+	candidates := []ParserWrapper{
+		{Parser: &stubParser{err: common.NewParserError("no marker")}, Priority: 1},
+		{Parser: &stubParser{err: common.NewIgnoreError("not for us")}, Priority: 100},
+		{Parser: &stubParser{events: []*events.Event{event}}, Priority: 1000},
+		{Parser: &stubParser{events: []*events.Event{event}}, Priority: 9999},
+	}
+
+	count, explanation, err := Explain(serializedEmail, nil, candidates, nil)
+	if err != nil || count != 1 {
+		t.Fatalf("Expected 1 event without error, got %d, %v", count, err)
+	}
+
+	expected := []struct{ outcome, tier string }{
+		{OutcomeDeclined, "preprocessor"},
+		{OutcomeIgnored, "vendor"},
+		{OutcomeMatched, "fallback_zx"},
+	}
+	if len(explanation.Attempts) != len(expected) {
+		t.Fatalf("Expected %d attempts, got %+v", len(expected), explanation.Attempts)
+	}
+	for i, attempt := range explanation.Attempts {
+		if attempt.Outcome != expected[i].outcome || attempt.Tier != expected[i].tier {
+			t.Errorf("Attempt %d: expected %s in %s, got %+v", i, expected[i].outcome, expected[i].tier, attempt)
+		}
+	}
+	if explanation.Chosen != "parsers" {
+		t.Errorf("Expected the stub parser to be chosen, got %q", explanation.Chosen)
+	}
+
+	sources := make(map[string]FieldSource)
+	for _, field := range explanation.Fields {
+		sources[field.Field] = field
+	}
+	if ip := sources["ip"]; ip.Source != "body" || ip.Marker != "Source IP:" || ip.Line != 3 {
+		t.Errorf("Unexpected ip source: %+v", ip)
+	}
+	if url := sources["url"]; url.Source != "attachment" || url.Location != "report.csv" {
+		t.Errorf("Unexpected url source: %+v", url)
+	}
+	if reportID := sources["report_id"]; reportID.Source != "subject" || reportID.Marker != "Abuse report [" {
+		t.Errorf("Unexpected report_id source: %+v", reportID)
+	}
+}
+
+func TestExplain_Classified(t *testing.T) {
+	candidates := []ParserWrapper{
+		{Parser: &stubParser{err: common.NewRejectError("bounce")}, Priority: 10},
+		{Parser: &stubParser{events: []*events.Event{events.NewEvent("stub")}}, Priority: 100},
+	}
+
+	count, explanation, err := Explain(&email.SerializedEmail{}, nil, candidates, nil)
+	var classifiedErr *ClassifiedError
+	if count != 0 || !errors.As(err, &classifiedErr) {
+		t.Fatalf("Expected the format parser to reject the email, got %d events, %v", count, err)
+	}
+	if len(explanation.Attempts) != 1 || explanation.Attempts[0].Outcome != OutcomeRejected || explanation.Chosen != "parsers" {
+		t.Errorf("Expected the rejecting parser to be chosen, got %+v", explanation)
+	}
+}
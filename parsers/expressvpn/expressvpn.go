@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from enforcement@expressvpn.com for copyright infringement notices
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "expressvpn.com") {
+		return nil, common.NewParserError("not from expressvpn.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -245,7 +245,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, common.NewParserError("email body is empty")
 	}
 
-	body := removeHTML(rawBody, true, "")
+	body, _ := common.GetTextBody(serializedEmail, false)
 
 	// Check for Vietnam version
 	bodyTrimmed := strings.TrimSpace(body)
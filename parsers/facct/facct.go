@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @facct.ru for copyright, trademark, phishing, and fraud reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "facct.ru") {
+		return nil, common.NewParserError("not from facct.ru")
+	}
+
 	// Get body with HTML stripped (BeautifulSoup equivalent)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
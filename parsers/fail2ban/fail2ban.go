@@ -206,6 +206,13 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		}
 	}
 
+	// Reports are sent by the hosts running fail2ban, so they are recognized
+	// by their content
+	from, _ := common.GetFrom(serializedEmail, false)
+	if !hasLog && !strings.Contains(bodyLower, "fail2ban") && !strings.Contains(strings.ToLower(subject), "fail2ban") && !strings.Contains(from, "fail2ban") {
+		return nil, common.NewParserError("not a fail2ban report")
+	}
+
 	event.EventTypes = []events.EventType{eventType}
 
 	// Extract IP from subject or body
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @abuse.ipv6home.eu for portscan reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ipv6home.eu") {
+		return nil, common.NewParserError("not from ipv6home.eu")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -153,7 +153,10 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 			if part.Headers != nil {
 				if cfblAddr, exists := part.Headers["cfbl-address"]; exists && len(cfblAddr) > 0 {
 					hasCFBLAddress = true
-					serializedEmail.Headers = part.Headers
+					// Continue with the embedded message's headers on a copy
+					embedded := *serializedEmail
+					embedded.Headers = part.Headers
+					serializedEmail = &embedded
 					// Update auth results if available in the embedded message
 					if authResultHeaders, exists := part.Headers["authentication-results"]; exists && len(authResultHeaders) > 0 {
 						authResults = strings.Split(authResultHeaders[0], ";")[1:]
@@ -0,0 +1,326 @@
+// Package forwarded unwraps abuse reports that customers and upstream
+// providers forward (as message/rfc822 attachment or inline, possibly quoted)
+// or that bounce back, and runs the registry on the inner message
+package forwarded
+
+import (
+	"mime"
+	"regexp"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/parsers/dsn"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// MaxDepth is the number of nested forwards that are unwrapped
+const MaxDepth = 3
+
+// Reparse parses an unwrapped message reached through chain. It is set by
+// the parsers registry, which cannot be imported from here without an import
+// cycle, and must run Nested(chain) in place of the forwarded parser so that
+// nested forwards continue the chain.
+var Reparse func(serializedEmail *email.SerializedEmail, chain []Hop) ([]*events.Event, error)
+
+// Hop is one forwarding step, outermost first in a chain
+type Hop struct {
+	// Kind is "attachment", "inline", "quoted" or "bounce"
+	Kind    string `json:"kind"`
+	From    string `json:"from,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+type Parser struct {
+	// chain is the forwarding chain of the messages parsed, empty for
+	// outermost messages
+	chain []Hop
+}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Nested returns a parser for the messages unwrapped through chain
+func Nested(chain []Hop) *Parser {
+	return &Parser{chain: chain}
+}
+
+var (
+	forwardSubjectPattern = regexp.MustCompile(`(?i)^\s*(?:(?:fwd?|wg|tr|rv|enc|doorst|vs|i)\s*:\s*)+`)
+	bounceSubjectPattern  = regexp.MustCompile(`(?i)undeliver|delivery status notification|delivery failure|returned mail|mail delivery failed|failure notice|nondeliverable`)
+
+	// forwardMarkers and originalMarkers start an inline forwarded message; the
+	// header block of the forwarded message follows on the next lines. Replies
+	// use originalMarkers too, so these only count with a forward subject.
+	forwardMarkers = []string{
+		"---------- forwarded message ---------",
+		"-------- forwarded message --------",
+		"begin forwarded message:",
+		"-------- weitergeleitete nachricht --------",
+		"-------- message transféré --------",
+		"-------- mensaje reenviado --------",
+	}
+	originalMarkers = []string{
+		"-------- original message --------",
+		"-----original message-----",
+		"-----ursprüngliche nachricht-----",
+	}
+	inlineHeaderPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z-]*|Von|Gesendet|Betreff|An|De|Envoyé|Objet|À|Enviado|Asunto|Para):\s*(.*)$`)
+	inlineHeaderNames   = map[string]string{
+		"from": "from", "von": "from", "de": "from",
+		"date": "date", "sent": "date", "gesendet": "date", "datum": "date", "envoyé": "date", "enviado": "date", "fecha": "date",
+		"subject": "subject", "betreff": "subject", "objet": "subject", "asunto": "subject",
+		"to": "to", "an": "to", "à": "to", "para": "to",
+		"cc": "cc", "reply-to": "reply-to",
+	}
+)
+
+// Parse unwraps a forwarded report and returns the events of the inner
+// message, each carrying the forwarding chain as "forwarding_chain" detail
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if Reparse == nil {
+		return nil, common.NewParserError("forwarded: no registry to reparse with")
+	}
+	if len(p.chain) >= MaxDepth {
+		return nil, common.NewParserError("forwarded: recursion limit reached")
+	}
+
+	inner, hop := Unwrap(serializedEmail)
+	if inner == nil {
+		return nil, common.NewParserError("forwarded: not a forwarded report")
+	}
+
+	innerChain := append(append([]Hop{}, p.chain...), hop)
+	eventsList, err := Reparse(inner, innerChain)
+	if err != nil {
+		return nil, err
+	}
+	if len(eventsList) == 0 {
+		return nil, common.NewParserError("forwarded: no parser matched the inner message")
+	}
+
+	for _, event := range eventsList {
+		// Nested unwraps record their longer chain first
+		if !hasChain(event) {
+			event.AddEventDetailSimple("forwarding_chain", innerChain)
+		}
+	}
+	return eventsList, nil
+}
+
+func hasChain(event *events.Event) bool {
+	for _, detail := range event.EventDetails {
+		if simple, ok := detail.(*events.SimpleDetail); ok && simple.Key == "forwarding_chain" {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the forwarded message of a forward or bounce
+// and the hop describing the outer message, or nil if there is none
+func Unwrap(serializedEmail *email.SerializedEmail) (*email.SerializedEmail, Hop) {
+	subject, _ := common.GetSubject(serializedEmail, false)
+	from, _ := common.GetFrom(serializedEmail, false)
+	hop := Hop{From: from, Subject: subject}
+	if dates := serializedEmail.Headers["date"]; len(dates) > 0 {
+		hop.Date = dates[0]
+	}
+
+	reportType := reportType(serializedEmail)
+	if reportType != "" && reportType != "delivery-status" {
+		// ARF and other reports attach the reported message, not a forwarded report
+		return nil, hop
+	}
+	bounce := reportType == "delivery-status" || bounceSubjectPattern.MatchString(subject)
+	forward := forwardSubjectPattern.MatchString(subject)
+
+	if bounce || forward {
+		if inner := attachedMessage(serializedEmail.Parts); inner != nil {
+			hop.Kind = "attachment"
+			if bounce {
+				// Bounces of our own notifications are rejected by the dsn parser
+				if dsn.IsOwnMessage(inner.Headers) {
+					return nil, hop
+				}
+				hop.Kind = "bounce"
+			}
+			inner.Identifier = serializedEmail.Identifier
+			return inner, hop
+		}
+	}
+
+	markers := forwardMarkers
+	if forward {
+		markers = append(append([]string{}, forwardMarkers...), originalMarkers...)
+	}
+	if inner, quoted := inlineMessage(textBody(serializedEmail), markers); inner != nil {
+		hop.Kind = "inline"
+		if quoted {
+			hop.Kind = "quoted"
+		}
+		inner.Identifier = serializedEmail.Identifier
+		return inner, hop
+	}
+	return nil, hop
+}
+
+// reportType returns the report-type of a multipart/report message
+func reportType(serializedEmail *email.SerializedEmail) string {
+	contentTypes := serializedEmail.Headers["content-type"]
+	if len(contentTypes) == 0 {
+		return ""
+	}
+	mediaType, params, err := mime.ParseMediaType(contentTypes[0])
+	if err != nil || mediaType != "multipart/report" {
+		return ""
+	}
+	return strings.ToLower(params["report-type"])
+}
+
+// attachedMessage returns the first message/rfc822 part as message
+func attachedMessage(parts []email.EmailPart) *email.SerializedEmail {
+	for _, part := range parts {
+		if strings.EqualFold(part.ContentType, "message/rfc822") {
+			if inner := partMessage(part); inner != nil {
+				return inner
+			}
+		}
+		if inner := attachedMessage(part.Parts); inner != nil {
+			return inner
+		}
+	}
+	return nil
+}
+
+// partMessage converts a message/rfc822 part, which is either the raw message
+// or already split into headers and parts, into a message
+func partMessage(part email.EmailPart) *email.SerializedEmail {
+	var raw []byte
+	switch body := part.Body.(type) {
+	case string:
+		raw = []byte(body)
+	case []byte:
+		raw = body
+	}
+	if len(raw) > 0 {
+		inner, err := email.Parse(raw)
+		if err == nil && len(inner.Headers) > 0 {
+			inner.Body = firstTextPart(inner.Parts)
+			return inner
+		}
+	}
+
+	if len(part.Parts) == 1 && len(part.Parts[0].Headers) > 0 {
+		inner := part.Parts[0]
+		return &email.SerializedEmail{Headers: inner.Headers, Body: inner.Body, Parts: inner.Parts}
+	}
+	if len(part.Headers) > 0 && len(part.Parts) > 0 {
+		return &email.SerializedEmail{Headers: part.Headers, Body: firstTextPart(part.Parts), Parts: part.Parts}
+	}
+	return nil
+}
+
+// textBody returns the body of a message, falling back to its first text/plain part
+func textBody(serializedEmail *email.SerializedEmail) string {
+	if body, _ := common.GetBody(serializedEmail, false); body != "" {
+		return body
+	}
+	if body, ok := firstTextPart(serializedEmail.Parts).(string); ok {
+		return body
+	}
+	return ""
+}
+
+func firstTextPart(parts []email.EmailPart) interface{} {
+	for _, part := range parts {
+		if part.ContentType == "" || strings.EqualFold(part.ContentType, "text/plain") {
+			switch body := part.Body.(type) {
+			case string:
+				return body
+			case []byte:
+				return string(body)
+			}
+		}
+		if body := firstTextPart(part.Parts); body != nil {
+			return body
+		}
+	}
+	return nil
+}
+
+// inlineMessage reconstructs a message forwarded inline: a marker line, a
+// header block and the forwarded body. quoted reports whether the forwarded
+// message was quoted with '>'.
+func inlineMessage(body string, markers []string) (*email.SerializedEmail, bool) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r", ""), "\n")
+	for i, line := range lines {
+		trimmed, quoted := unquote(line)
+		if !hasMarkerPrefix(trimmed, markers) {
+			continue
+		}
+
+		headers := make(map[string][]string)
+		j := i + 1
+		for ; j < len(lines); j++ {
+			headerLine, _ := unquote(lines[j])
+			headerLine = strings.Trim(headerLine, "* ")
+			if headerLine == "" {
+				if len(headers) == 0 {
+					continue
+				}
+				break
+			}
+			match := inlineHeaderPattern.FindStringSubmatch(headerLine)
+			if match == nil {
+				break
+			}
+			if name, ok := inlineHeaderNames[strings.ToLower(match[1])]; ok {
+				headers[name] = append(headers[name], strings.TrimSpace(match[2]))
+			}
+		}
+		if len(headers["from"]) == 0 || len(headers["subject"]) == 0 {
+			continue
+		}
+
+		var innerLines []string
+		for _, innerLine := range lines[j:] {
+			if quoted {
+				innerLine, _ = unquote(innerLine)
+			}
+			innerLines = append(innerLines, innerLine)
+		}
+		return &email.SerializedEmail{
+			Headers: headers,
+			Body:    strings.TrimSpace(strings.Join(innerLines, "\n")),
+		}, quoted
+	}
+	return nil, false
+}
+
+// unquote strips one level of '>' quoting and surrounding whitespace
+func unquote(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if rest, ok := strings.CutPrefix(trimmed, ">"); ok {
+		return strings.TrimSpace(rest), true
+	}
+	return trimmed, false
+}
+
+func hasMarkerPrefix(line string, markers []string) bool {
+	lower := strings.ToLower(line)
+	for _, marker := range markers {
+		if strings.HasPrefix(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityPreprocessor
+}
@@ -0,0 +1,150 @@
+package forwarded
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// reportParser stands in for the registry: it recognises messages from
+// reports@vendor.example and unwraps everything else again
+func reportParser() func(*email.SerializedEmail, []Hop) ([]*events.Event, error) {
+	return func(serializedEmail *email.SerializedEmail, chain []Hop) ([]*events.Event, error) {
+		if from := serializedEmail.Headers["from"]; len(from) > 0 && from[0] == "Vendor <reports@vendor.example>" {
+			event := events.NewEvent("vendor")
+			event.IP = "192.0.2.1"
+			return []*events.Event{event}, nil
+		}
+		return Nested(chain).Parse(serializedEmail)
+	}
+}
+
+func chainOf(t *testing.T, event *events.Event) []Hop {
+	for _, detail := range event.EventDetails {
+		if simple, ok := detail.(*events.SimpleDetail); ok && simple.Key == "forwarding_chain" {
+			return simple.Value.([]Hop)
+		}
+	}
+	t.Fatalf("Expected forwarding chain on event")
+	return nil
+}
+
+func TestParser_InlineForward(t *testing.T) {
+	Reparse = reportParser()
+	defer func() { Reparse = nil }()
+
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":    {"customer@example.net"},
+			"subject": {"Fwd: Abuse report 192.0.2.1"},
+		},
+		Body: `Please have a look.
+
+---------- Forwarded message ---------
+From: Vendor <reports@vendor.example>
+Date: Sat, 18 Oct 2025 10:00:00 +0000
+Subject: Abuse report 192.0.2.1
+To: <abuse@example.net>
+
+We observed abuse from 192.0.2.1.`,
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 1 || result[0].Parser != "vendor" {
+		t.Fatalf("Expected inner report events, got %+v", result)
+	}
+	chain := chainOf(t, result[0])
+	if len(chain) != 1 || chain[0].Kind != "inline" || chain[0].From != "customer@example.net" {
+		t.Errorf("Unexpected chain: %+v", chain)
+	}
+}
+
+func TestParser_NestedAttachmentAndQuotedForward(t *testing.T) {
+	Reparse = reportParser()
+	defer func() { Reparse = nil }()
+
+	inner := "From: reseller@example.org\r\nSubject: Fwd: Abuse report\r\nContent-Type: text/plain\r\n\r\n" +
+		"> -------- Forwarded Message --------\r\n" +
+		"> Subject: Abuse report\r\n" +
+		"> From: Vendor <reports@vendor.example>\r\n" +
+		">\r\n" +
+		"> We observed abuse from 192.0.2.1.\r\n"
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":    {"customer@example.net"},
+			"subject": {"FW: Abuse report"},
+		},
+		Parts: []email.EmailPart{
+			{ContentType: "text/plain", Body: "See attached."},
+			{ContentType: "message/rfc822", Body: inner},
+		},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	chain := chainOf(t, result[0])
+	if len(chain) != 2 || chain[0].Kind != "attachment" || chain[1].Kind != "quoted" || chain[1].From != "reseller@example.org" {
+		t.Errorf("Unexpected chain: %+v", chain)
+	}
+}
+
+func TestParser_NotUnwrapped(t *testing.T) {
+	Reparse = reportParser()
+	defer func() { Reparse = nil }()
+
+	arf := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":         {"fbl@isp.example"},
+			"subject":      {"Fwd: spam report"},
+			"content-type": {`multipart/report; report-type=feedback-report; boundary="x"`},
+		},
+		Parts: []email.EmailPart{
+			{ContentType: "message/rfc822", Body: "From: Vendor <reports@vendor.example>\r\nSubject: spam\r\n\r\nspam"},
+		},
+	}
+	if _, err := NewParser().Parse(arf); err == nil {
+		t.Error("Expected ARF report not to be unwrapped")
+	}
+
+	reply := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"customer@example.net"}, "subject": {"Re: Abuse report"}},
+		Body:    "Fixed.\n\n-----Original Message-----\nFrom: Vendor <reports@vendor.example>\nSubject: Abuse report\n\nabuse",
+	}
+	if _, err := NewParser().Parse(reply); err == nil {
+		t.Error("Expected reply not to be unwrapped")
+	}
+}
+
+func TestParser_RecursionLimit(t *testing.T) {
+	unwrapped := 0
+	Reparse = func(serializedEmail *email.SerializedEmail, chain []Hop) ([]*events.Event, error) {
+		unwrapped++
+		// The registry may hand a copy of the message to the parsers
+		return Nested(chain).Parse(serializedEmail.Clone())
+	}
+	defer func() { Reparse = nil }()
+
+	var body strings.Builder
+	for i := 0; i < MaxDepth+2; i++ {
+		body.WriteString("---------- Forwarded message ---------\nFrom: reseller@example.org\nSubject: Fwd: Abuse report\n\n")
+	}
+	body.WriteString("We observed abuse from 192.0.2.1.")
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"customer@example.net"}, "subject": {"Fwd: Abuse report"}},
+		Body:    body.String(),
+	}
+
+	if _, err := NewParser().Parse(serializedEmail); err == nil {
+		t.Error("Expected an error past the recursion limit")
+	}
+	if unwrapped != MaxDepth {
+		t.Errorf("Expected %d unwrapped messages, got %d", MaxDepth, unwrapped)
+	}
+}
@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "fraudwatch.com") {
+		return nil, common.NewParserError("not from fraudwatch.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -119,147 +123,53 @@ func parseTrademark(body string, eventDate *time.Time, externalID string) ([]*ev
 }
 
 func parsePhishing(body string, eventDate *time.Time, externalID string) ([]*events.Event, error) {
-	ip := strings.TrimSpace(common.FindStringWithoutMarkers(body, "ip address:", ""))
-
-	brand := common.FindStringWithoutMarkers(body, "brand phished:", "")
-	if brand == "" {
-		brand = common.FindStringWithoutMarkers(body, "customers of our client, ", "")
-	}
-	if brand == "" {
-		brand = common.FindStringWithoutMarkers(body, "impersonating our client ", ":")
-	}
-	if brand == "" {
-		brand = common.FindStringWithoutMarkers(body, "our client's brand and name \"", "\"")
-	}
-	brand = strings.TrimSpace(brand)
-
-	body = strings.ReplaceAll(body, "phishing content:", "phishing content:\n")
-	body = strings.ReplaceAll(body, "urls:", "urls:\n")
-	body = strings.ReplaceAll(body, "malicious content:", "malicious content:\n")
-	body = strings.ReplaceAll(body, "abusive content:", "abusive content:\n")
-	body = strings.ReplaceAll(body, "infringing content:", "infringing content:\n")
-
-	var phishingURLs []string
-	phishingURLs = common.GetBlockAfterWithStop(body, "phishing content:", "")
-	if len(phishingURLs) == 0 {
-		phishingURLs = common.GetBlockAfterWithStop(body, "urls:", "")
-	}
-	if len(phishingURLs) == 0 {
-		phishingURLs = common.GetBlockAfterWithStop(body, "malicious content:", "")
-	}
-	if len(phishingURLs) == 0 {
-		phishingURLs = common.GetBlockAfterWithStop(body, "abusive content:", "")
-	}
-	if len(phishingURLs) == 0 {
-		phishingURLs = common.GetBlockAfterWithStop(body, "impersonating our client", "")
-	}
-	if len(phishingURLs) == 0 {
-		phishingURLs = common.GetBlockAfterWithStop(body, "direct links to infringing content:", "")
-	}
-	if len(phishingURLs) == 0 {
+	notice, err := common.ExtractPhishingNotice(body)
+	if err != nil {
 		return nil, common.NewParserError("did not find phishing urls")
 	}
-
-	body = strings.ReplaceAll(body, "legitimate brand url's:", "legitimate brand url's:\n")
-	officialURLs := common.GetBlockAfterWithStop(body, "legitimate brand url's:", "")
-	var officialURL string
-	for _, url := range officialURLs {
-		officialURL = strings.Trim(url, " -")
-		break
+	notice.Date = eventDate
+	if externalID != "" {
+		notice.CaseID = externalID
 	}
 
-	var result []*events.Event
-	for _, phishingURL := range phishingURLs {
-		event := events.NewEvent("fraudwatch")
-		cleanURL := strings.ReplaceAll(phishingURL, " ", "")
-		event.URL = cleanURL
-
-		// Set IP if valid
-		if ip != "" {
-			if validIP := common.IsIP(ip); validIP != "" {
-				event.IP = validIP
-			}
-		}
-
-		event.EventDate = eventDate
-
-		phishing := &events.Phishing{
-			BaseEventType: events.BaseEventType{
-				Name: "phishing",
-				Type: "phishing",
-			},
-			PhishingTarget: cleanURL,
-			OfficialURL:    officialURL,
-		}
-		event.EventTypes = []events.EventType{phishing}
-
-		if externalID != "" {
-			event.AddEventDetail(&events.ExternalID{ID: externalID})
-		}
-		if brand != "" {
-			event.AddEventDetailSimple("brand", brand)
-		}
-
-		result = append(result, event)
-	}
-
-	return result, nil
+	return notice.Events("fraudwatch")
 }
 
 func parseCopyright(body string, eventDate *time.Time, externalID string) ([]*events.Event, error) {
 	body = strings.ReplaceAll(body, "located at the following urls:", "located at the following urls:\n\n")
-	infringingURLs := common.GetBlockAfterWithStop(body, "infringing material is located at", "")
-	if len(infringingURLs) == 0 {
-		body = strings.ReplaceAll(body, "infringing content:", "infringing content:\n")
-		infringingURLs = common.GetBlockAfterWithStop(body, "infringing content:", "")
-		if len(infringingURLs) == 0 {
-			return nil, common.NewParserError("did not find infringing urls")
-		}
-	}
-
-	officialURLs := common.GetBlockAfterWithStop(body, "original material is located at", "")
-	var officialURL string
-	for _, url := range officialURLs {
-		officialURL = url
-		break
+	body = strings.ReplaceAll(body, "infringing content:", "infringing content:\n")
+	notice, err := common.ExtractDMCANotice(body)
+	if err != nil || len(notice.InfringingURLs) == 0 {
+		return nil, common.NewParserError("did not find infringing urls")
 	}
 
+	// The owner is usually on the line after its label
 	body = strings.ReplaceAll(body, "brand abused:", "brand abused:\n")
-	copyrightOwner := common.GetLineAfter(body, "copyright owner:", 1)
+	copyrightOwner := notice.Owner
+	if copyrightOwner == "" {
+		copyrightOwner = common.GetLineAfter(body, "copyright owner:", 1)
+	}
 	if copyrightOwner == "" {
 		copyrightOwner = common.GetLineAfter(body, "client information:", 1)
 	}
 	if copyrightOwner == "" {
 		copyrightOwner = common.GetLineAfter(body, "brand abused:", 1)
 	}
-	copyrightOwner = strings.TrimSpace(copyrightOwner)
-	if copyrightOwner == "" {
+	notice.Owner = strings.TrimSpace(copyrightOwner)
+	if notice.Owner == "" {
 		return nil, common.NewParserError("did not find copyright owner")
 	}
 
-	var result []*events.Event
-	for _, infringingURL := range infringingURLs {
-		event := events.NewEvent("fraudwatch")
-		event.URL = infringingURL
-		event.EventDate = eventDate
-
-		copyright := &events.Copyright{
-			BaseEventType: events.BaseEventType{
-				Name: "copyright",
-				Type: "copyright",
-			},
-			CopyrightOwner: copyrightOwner,
-			OfficialURL:    officialURL,
-		}
-		event.EventTypes = []events.EventType{copyright}
-
-		if externalID != "" {
+	notice.Date = eventDate
+	result, err := notice.Events("fraudwatch")
+	if err != nil {
+		return nil, err
+	}
+	if externalID != "" {
+		for _, event := range result {
 			event.AddEventDetail(&events.ExternalID{ID: externalID})
 		}
-
-		result = append(result, event)
 	}
-
 	return result, nil
 }
 
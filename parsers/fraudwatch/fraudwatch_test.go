@@ -0,0 +1,163 @@
+package fraudwatch
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// Notice of testdata/sample_mails/fraudwatch.phishing.0.eml
+const phishingNotice = `[Incident#SBU-622249]
+Hello,
+
+We are contacting you because we have located some phishing content on your servers, that we would like to have removed.
+
+IP Address: 198.199.109.95
+
+Phishing Content:
+hxxps://wpaklslkhaas-jodoman744202448 .codeanyapp .com /wpall/load3.php?id=1.146.160.29
+hxxps://wpaklslkhaas-jodoman744202448 .codeanyapp .com /wpall/
+
+Evidence of malicious activity: 
+https://www.virustotal.com/gui/url/55ae27f0a332e21212bf35b551af2596fc9bd06db1b32ae4f0fc712554dc930c?nocache=1
+https://urlscan.io/result/3e9f9071-a4df-49b2-aa4d-c2cc3fdc4f58/
+
+Brand Phished: NAB - National Australia Bank
+Legitimate Brand URL's:
+ - http://nab.com.au 
+
+Fraudwatch is a cyber security agency legally authorized to act on behalf of our clients in matters relating to online fraud protection from fraud related financial losses, brand damage and online abuse.
+
+Regards,
+
+Security Operations
+FraudWatch
+Email: security@fraudwatch.com
+Web: http://www.fraudwatch.com`
+
+// Notice of testdata/sample_mails/fraudwatch.phising.1.eml
+const impersonationNotice = `[Incident#WXU-334371]
+Hello,
+
+We have detected the following malicious website(s) hosted on your network that are impersonating our client CIMB Bank:
+
+https://104.248.157.40:8888
+
+
+These websites contain fake online casino gambling content, which is used to fraudulently deceive internet users.
+
+Legitimate Brand URL's:
+ - http://www.cimbclicks.com.my
+ - http://www.cimb.com
+
+Regards,
+
+Security Operations
+FraudWatch
+Web: http://www.fraudwatch.com`
+
+const copyrightNotice = `[Incident#SCD-714145]
+TAKEDOWN NOTICE PURSUANT TO THE DIGITAL MILLENNIUM COPYRIGHT ACT OF 1998
+Dear Sir or Madam,
+
+This is a notice in accordance with the Digital Millennium Copyright Act of 1998 requesting that you cease to provide access to copyrighted material.
+
+The infringing material is located at the following URLs:
+https://findorra.com/app/418
+
+
+The original material is located at the following URLs:
+http://play.google.com/store/apps/details?id=com.investec.app
+
+
+Contact information:
+Lewis
+FraudWatch Security
+Mailing address: PO Box 311. Blackburn VIC 3130, Australia
+Telephone number: +61398876777
+Fax number: +61 3 8660 2688
+E-mail address: security@fraudwatch.com
+
+Copyright Owner:
+Investec
+
+I have a good faith belief that the use of the described material in the manner complained of is not authorized by the copyright owner, or the law.
+
+Electronic Signature: Lewis Spiliotis`
+
+func parseNotice(t *testing.T, subject, body string) []*events.Event {
+	t.Helper()
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"from":    {"abuse@fraudwatch.com"},
+			"subject": {subject},
+			"date":    {"Tue, 22 Mar 2022 00:51:28 +0000 (UTC)"},
+		},
+		Body: body,
+	}
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return result
+}
+
+func TestParsePhishingContent(t *testing.T) {
+	result := parseNotice(t, "[Incident#SBU-622249] Phishing Content", phishingNotice)
+
+	expected := []string{
+		"https://wpaklslkhaas-jodoman744202448.codeanyapp.com/wpall/load3.php?id=1.146.160.29",
+		"https://wpaklslkhaas-jodoman744202448.codeanyapp.com/wpall/",
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d events, got: %d", len(expected), len(result))
+	}
+	for i, event := range result {
+		if event.URL != expected[i] {
+			t.Errorf("Event %d: Expected URL %q, got: %q", i, expected[i], event.URL)
+		}
+		if event.IP != "198.199.109.95" {
+			t.Errorf("Event %d: Expected IP 198.199.109.95, got: %q", i, event.IP)
+		}
+		phishing, ok := event.EventTypes[0].(*events.Phishing)
+		if !ok {
+			t.Fatalf("Event %d: Expected a phishing event type, got: %T", i, event.EventTypes[0])
+		}
+		if phishing.OfficialURL != "http://nab.com.au" {
+			t.Errorf("Event %d: Expected official URL http://nab.com.au, got: %q", i, phishing.OfficialURL)
+		}
+	}
+}
+
+func TestParsePhishingImpersonation(t *testing.T) {
+	result := parseNotice(t, "[Incident#WXU-334371] Malicious Fake Casino Website(s)", impersonationNotice)
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 event, got: %d", len(result))
+	}
+	if result[0].URL != "https://104.248.157.40:8888" {
+		t.Errorf("Expected URL https://104.248.157.40:8888, got: %q", result[0].URL)
+	}
+}
+
+func TestParseCopyright(t *testing.T) {
+	result := parseNotice(t, "[Incident#SCD-714145] DMCA Notice", copyrightNotice)
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 event, got: %d", len(result))
+	}
+	if result[0].URL != "https://findorra.com/app/418" {
+		t.Errorf("Expected URL https://findorra.com/app/418, got: %q", result[0].URL)
+	}
+	copyright, ok := result[0].EventTypes[0].(*events.Copyright)
+	if !ok {
+		t.Fatalf("Expected a copyright event type, got: %T", result[0].EventTypes[0])
+	}
+	if copyright.CopyrightOwner != "investec" {
+		t.Errorf("Expected copyright owner investec, got: %q", copyright.CopyrightOwner)
+	}
+	if copyright.OfficialURL != "http://play.google.com/store/apps/details?id=com.investec.app" {
+		t.Errorf("Expected the Google Play official URL, got: %q", copyright.OfficialURL)
+	}
+}
@@ -257,6 +257,10 @@ func (p *Parser) parseDisneyCopyright(bodyLower string) ([]*events.Event, error)
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "friendmts.com") {
+		return nil, common.NewParserError("not from friendmts.com")
+	}
+
 	subject, _ := common.GetSubject(serializedEmail, false)
 	subject = strings.ReplaceAll(subject, getLinebreak(subject), "")
 
@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse parses emails from isac@fsec.or.kr
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "fsec.or.kr") {
+		return nil, common.NewParserError("not from fsec.or.kr")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// crasherDir holds mails that crashed a parser; they are seeds of FuzzParsers
+// and so run as regression tests with every go test
+const crasherDir = "../testdata/crashers"
+
+// FuzzParsers feeds mutations of the sample mails through every registered
+// parser, not only the one the registry would pick, and fails naming the
+// parser that panicked. Plain go test runs one sample per parser and the
+// crashers; with -fuzz all sample mails seed the corpus.
+func FuzzParsers(f *testing.F) {
+	for _, path := range fuzzSeeds(f) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+
+	parsers := AllParsers()
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		serializedEmail, err := email.Parse(raw)
+		if err != nil {
+			return
+		}
+		// Each parser gets its own copy, so that a parser changing the email
+		// cannot make a later one fail on input the fuzzer never produced
+		for _, pw := range parsers {
+			runIsolated(t, pw, serializedEmail.Clone())
+		}
+	})
+}
+
+// runIsolated runs a parser and reports a panic with the parser name
+func runIsolated(t *testing.T, pw ParserWrapper, serializedEmail *email.SerializedEmail) {
+	err := callParser(pw, serializedEmail, true, func(*events.Event) error { return nil })
+	var parserPanic *ParserPanic
+	if errors.As(err, &parserPanic) {
+		t.Fatalf("%v\n%s", parserPanic, parserPanic.Stack)
+	}
+}
+
+// fuzzSeeds returns the crashers and the sample mails: all of them when
+// fuzzing, else the first sample of each parser
+func fuzzSeeds(f *testing.F) []string {
+	crashers, err := filepath.Glob(filepath.Join(crasherDir, "*.eml"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	samples, err := filepath.Glob(filepath.Join(sampleDir, "*.eml"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	if fuzzing := flag.Lookup("test.fuzz"); fuzzing != nil && fuzzing.Value.String() != "" {
+		return append(crashers, samples...)
+	}
+	seen := make(map[string]bool)
+	for _, sample := range samples {
+		parser, _, _ := strings.Cut(filepath.Base(sample), ".")
+		if !seen[parser] {
+			seen[parser] = true
+			crashers = append(crashers, sample)
+		}
+	}
+	return crashers
+}
@@ -29,6 +29,10 @@ func getIPFromBody(body string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "gmx.de") {
+		return nil, common.NewParserError("not from gmx.de")
+	}
+
 	// Get body and convert to lowercase
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
@@ -76,6 +76,10 @@ func (p *Parser) parseCompromisedWebsite(body string, dateFallback string) ([]*e
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "gmx.com") {
+		return nil, common.NewParserError("not from gmx.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
@@ -26,12 +26,12 @@ func parseBot(body string, event *events.Event, dateFallback string) ([]*events.
 	// Try to parse date from body
 	dateStr := common.FindStringWithoutMarkers(body, "date ", "")
 	if dateStr != "" {
-		event.EventDate = email.ParseDate(dateStr)
+		event.EventDate = common.ParseDate(dateStr)
 	}
 
 	// If date parsing failed, use fallback from email header
 	if event.EventDate == nil && dateFallback != "" {
-		event.EventDate = email.ParseDate(dateFallback)
+		event.EventDate = common.ParseDate(dateFallback)
 	}
 
 	// Set event type to Bot
@@ -95,7 +95,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	return nil, common.NewParserError("unknown subject type: " + subject)
 }
 
-// GetPriority returns the parser priority (lower numbers run first)
+// GetPriority returns the parser priority (lower numbers run first). Any
+// mail with "botnet" in its subject and an IP in its body matches, so the
+// parser runs after the vendor parsers, which know their own reports better.
 func (p *Parser) GetPriority() int {
-	return 16
+	return 999
 }
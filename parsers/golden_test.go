@@ -0,0 +1,468 @@
+package parsers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/parity"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the sample mails")
+
+const (
+	sampleDir = "../testdata/sample_mails"
+	goldenDir = "../testdata/golden"
+	// misroutedFile lists the samples handled by another parser than
+	// expected, as "<sample> <expected> <got> # <reason>" lines. Those
+	// samples have no golden file, as it would only lock in the other
+	// parser's output.
+	misroutedFile = goldenDir + "/misrouted.txt"
+	// maxDiffs is the number of field differences reported per sample
+	maxDiffs = 20
+)
+
+// goldenResult is what the registry produced for a sample mail
+type goldenResult struct {
+	Events []*events.Event `json:"events"`
+	Error  string          `json:"error,omitempty"`
+	// classifiedBy is the parser that ignored or rejected the sample
+	classifiedBy string
+}
+
+// orderingPrefix is the prefix of samples named after a parser that only
+// decides their position in the sample directory
+var orderingPrefix = regexp.MustCompile(`^(\d+_|Z[XYZ]_)`)
+
+// TestGolden runs every sample mail through the registry and compares the
+// result with its golden file in testdata/golden. Subtests are named
+// <parser>/<sample>, after the sample naming convention, so a single parser's
+// samples run with -run Golden/<parser>. Samples handled by another parser
+// than expected must be listed in misrouted.txt and are not compared. Run
+// with -update to regenerate the golden files and drop the samples routed
+// correctly again from the list; new entries are only added by hand.
+func TestGolden(t *testing.T) {
+	samples, err := filepath.Glob(filepath.Join(sampleDir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) == 0 {
+		t.Fatalf("No sample mails in %s", sampleDir)
+	}
+	// Each sample is routed on its own, without parsers that panicked on
+	// earlier samples being quarantined
+	l := CurrentLimits()
+	l.QuarantineAfter = 0
+	withLimits(t, l)
+
+	byParser := make(map[string][]string)
+	for _, sample := range samples {
+		parser, _, _ := strings.Cut(filepath.Base(sample), ".")
+		byParser[parser] = append(byParser[parser], sample)
+	}
+	parserNames := make([]string, 0, len(byParser))
+	for parser := range byParser {
+		parserNames = append(parserNames, parser)
+	}
+	sort.Strings(parserNames)
+
+	routes := make(map[string]route)
+	reasons := make(map[string]string)
+	for _, parser := range parserNames {
+		t.Run(parser, func(t *testing.T) {
+			for _, sample := range byParser[parser] {
+				name := strings.TrimSuffix(filepath.Base(sample), ".eml")
+				t.Run(name, func(t *testing.T) {
+					got, err := parseSample(sample)
+					if err != nil {
+						t.Fatal(err)
+					}
+					r := route{expected: expectedParser(sample), got: got.parser()}
+					routes[filepath.Base(sample)] = r
+					if r.got == r.expected {
+						checkGolden(t, sample, got)
+					} else if *update {
+						removeGolden(t, sample)
+						reasons[filepath.Base(sample)] = misrouteReason(sample, r)
+					}
+				})
+			}
+		})
+	}
+	checkRoutes(t, routes, reasons)
+}
+
+// route is the parser expected to handle a sample and the one that did
+type route struct {
+	expected string
+	got      string
+}
+
+// expectedParser returns the parser of the sample's Python assertions, or
+// the parser named by the sample's file name without them. Python spells
+// some parser names with dashes where the Go packages use underscores.
+func expectedParser(sample string) string {
+	parser, _, _ := strings.Cut(filepath.Base(sample), ".")
+	parser = orderingPrefix.ReplaceAllString(parser, "")
+	if output, err := parity.LoadPython(sample + ".assertions.json"); err == nil {
+		parser = output.Parser()
+	}
+	return strings.ReplaceAll(parser, "-", "_")
+}
+
+// checkRoutes compares the misrouted samples of this run with misrouted.txt.
+// Samples missing from the list fail, even with -update, so the list cannot
+// grow unnoticed. Listed samples now handled by the expected parser or by yet
+// another one fail too, unless -update records that in the list.
+func checkRoutes(t *testing.T, routes map[string]route, reasons map[string]string) {
+	listed, err := readMisrouted()
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := updateMisrouted(routes, reasons); err != nil {
+			t.Fatal(err)
+		}
+		listed, err = readMisrouted()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	samples := make([]string, 0, len(routes))
+	for sample := range routes {
+		samples = append(samples, sample)
+	}
+	sort.Strings(samples)
+	for _, sample := range samples {
+		r := routes[sample]
+		known, ok := listed[sample]
+		switch {
+		case r.got != r.expected && !ok:
+			t.Errorf("%s: handled by %q instead of %q (list it in %s if expected)", sample, r.got, r.expected, misroutedFile)
+		case r.got != r.expected && known != r:
+			t.Errorf("%s: handled by %q instead of %q, listed as %q", sample, r.got, r.expected, known.got)
+		case r.got == r.expected && ok:
+			t.Errorf("%s: now handled by %q, remove it from %s", sample, r.got, misroutedFile)
+		}
+	}
+}
+
+func readMisrouted() (map[string]route, error) {
+	listed := make(map[string]route)
+	data, err := os.ReadFile(misroutedFile)
+	if err != nil {
+		return listed, err
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, _, _ := strings.Cut(line, " # ")
+		fields := strings.Split(entry, " ")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected \"<sample> <expected> <got> # <reason>\"", misroutedFile, i+1)
+		}
+		listed[fields[0]] = route{expected: unlisted(fields[1]), got: unlisted(fields[2])}
+	}
+	return listed, nil
+}
+
+// updateMisrouted removes the listed samples that are routed as expected and
+// records the parser now handling the others and the reason. Comments and
+// samples left out by -run are kept.
+func updateMisrouted(routes map[string]route, reasons map[string]string) error {
+	data, err := os.ReadFile(misroutedFile)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		sample, _, _ := strings.Cut(line, " ")
+		r, ok := routes[sample]
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || !ok:
+			b.WriteString(line)
+		case r.got != r.expected:
+			fmt.Fprintf(&b, "%s %s %s # %s\n", sample, listedName(r.expected), listedName(r.got), reasons[sample])
+		}
+	}
+	return os.WriteFile(misroutedFile, []byte(b.String()), 0o644)
+}
+
+// misrouteReason tells why the expected parser did not handle a sample: it
+// is missing, it declines the sample when run on its own, or it handles it
+// on its own but the registry picks another parser
+func misrouteReason(sample string, r route) string {
+	if r.expected == "" {
+		return "no Python parser handled it"
+	}
+	pw, ok := ParserByName(r.expected)
+	if !ok {
+		return "no such parser"
+	}
+	serializedEmail, err := loadSample(sample)
+	if err != nil {
+		return err.Error()
+	}
+	count := 0
+	err = RunParser(context.Background(), pw, serializedEmail, func(*events.Event) error {
+		count++
+		return nil
+	})
+	switch {
+	case err != nil:
+		reason, _, _ := strings.Cut(err.Error(), "\n")
+		if len(reason) > 100 {
+			reason = reason[:100] + "..."
+		}
+		return "declines: " + reason
+	case count == 0:
+		return "declines without an error"
+	}
+	return "handles it alone, but the registry picks " + listedName(r.got)
+}
+
+func listedName(parser string) string {
+	if parser == "" {
+		return "-"
+	}
+	return parser
+}
+
+func unlisted(name string) string {
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// parser returns the parser of the first event, or the one that ignored or
+// rejected the sample, or "" if no parser handled it
+func (r *goldenResult) parser() string {
+	if len(r.Events) == 0 {
+		return r.classifiedBy
+	}
+	return r.Events[0].Parser
+}
+
+// checkGolden compares a sample's result with its golden file
+func checkGolden(t *testing.T, sample string, got *goldenResult) {
+	gotJSON, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal events: %v", err)
+	}
+	gotJSON = append(gotJSON, '\n')
+
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(gotJSON, &gotValue); err != nil {
+		t.Fatal(err)
+	}
+	gotValue = canonicalJSON(gotValue)
+
+	golden := filepath.Join(goldenDir, filepath.Base(sample)+".json")
+	wantJSON, err := os.ReadFile(golden)
+	if err == nil {
+		err = json.Unmarshal(wantJSON, &wantValue)
+		wantValue = canonicalJSON(wantValue)
+	}
+	if *update {
+		// Golden files with the same content in another order are kept
+		if err != nil || !reflect.DeepEqual(gotValue, wantValue) {
+			if err := os.WriteFile(golden, gotJSON, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+	if os.IsNotExist(err) {
+		t.Fatalf("Missing golden file (run with -update): %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Invalid golden file %s: %v", golden, err)
+	}
+
+	diffs := diffJSON("", gotValue, wantValue, nil)
+	if len(diffs) > maxDiffs {
+		diffs = append(diffs[:maxDiffs], fmt.Sprintf("... and %d more", len(diffs)-maxDiffs))
+	}
+	if len(diffs) > 0 {
+		t.Errorf("Output differs from %s:\n  %s", golden, strings.Join(diffs, "\n  "))
+	}
+}
+
+// removeGolden removes the golden file of a misrouted sample
+func removeGolden(t *testing.T, sample string) {
+	golden := filepath.Join(goldenDir, filepath.Base(sample)+".json")
+	if err := os.Remove(golden); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+}
+
+// parseSample runs a sample mail through the registry, with the envelope
+// metadata of its .meta.json file if present
+func parseSample(sample string) (*goldenResult, error) {
+	raw, err := os.ReadFile(sample)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		return &goldenResult{Events: []*events.Event{}, Error: err.Error()}, nil
+	}
+	if err := loadMetadata(sample, serializedEmail); err != nil {
+		return nil, err
+	}
+	result := &goldenResult{Events: []*events.Event{}}
+	_, err = ParseEmailStream(serializedEmail, nil, func(event *events.Event) error {
+		result.Events = append(result.Events, event)
+		return nil
+	})
+	var classifiedErr *ClassifiedError
+	if errors.As(err, &classifiedErr) {
+		result.classifiedBy = classifiedErr.Parser
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// loadSample reads and parses a sample mail with its metadata
+func loadSample(sample string) (*email.SerializedEmail, error) {
+	raw, err := os.ReadFile(sample)
+	if err != nil {
+		return nil, err
+	}
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return serializedEmail, loadMetadata(sample, serializedEmail)
+}
+
+// loadMetadata sets the metadata of a sample from its .meta.json file, if
+// it has one
+func loadMetadata(sample string, serializedEmail *email.SerializedEmail) error {
+	meta, err := os.ReadFile(sample + ".meta.json")
+	if err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(meta, &serializedEmail.Metadata); err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+	return nil
+}
+
+// canonicalJSON sorts the arrays of a decoded JSON value by the encoding of
+// their elements. Many parsers emit events, and lists within them, in map
+// order, so only their content is compared, as in pkg/parity.
+func canonicalJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, element := range typed {
+			typed[key] = canonicalJSON(element)
+		}
+	case []interface{}:
+		keys := make(map[int]string, len(typed))
+		for i, element := range typed {
+			typed[i] = canonicalJSON(element)
+			data, _ := json.Marshal(typed[i])
+			keys[i] = string(data)
+		}
+		indices := make([]int, len(typed))
+		for i := range indices {
+			indices[i] = i
+		}
+		sort.SliceStable(indices, func(a, b int) bool { return keys[indices[a]] < keys[indices[b]] })
+		sorted := make([]interface{}, len(typed))
+		for i, index := range indices {
+			sorted[i] = typed[index]
+		}
+		return sorted
+	}
+	return value
+}
+
+// diffJSON returns the differences between two decoded JSON values as
+// "path: got X, want Y" lines
+func diffJSON(path string, got, want interface{}, diffs []string) []string {
+	switch wantTyped := want.(type) {
+	case map[string]interface{}:
+		gotTyped, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool)
+		for key := range gotTyped {
+			keys[key] = true
+		}
+		for key := range wantTyped {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			gotValue, gotOK := gotTyped[key]
+			wantValue, wantOK := wantTyped[key]
+			switch {
+			case !gotOK:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: missing, want %s", path, key, jsonString(wantValue)))
+			case !wantOK:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: unexpected %s", path, key, jsonString(gotValue)))
+			default:
+				diffs = diffJSON(path+"."+key, gotValue, wantValue, diffs)
+			}
+		}
+		return diffs
+	case []interface{}:
+		gotTyped, ok := got.([]interface{})
+		if !ok {
+			break
+		}
+		if len(gotTyped) != len(wantTyped) {
+			diffs = append(diffs, fmt.Sprintf("%s: got %d elements, want %d", pathOrRoot(path), len(gotTyped), len(wantTyped)))
+		}
+		for i := 0; i < len(gotTyped) && i < len(wantTyped); i++ {
+			diffs = diffJSON(fmt.Sprintf("%s[%d]", path, i), gotTyped[i], wantTyped[i], diffs)
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(got, want) {
+		diffs = append(diffs, fmt.Sprintf("%s: got %s, want %s", pathOrRoot(path), jsonString(got), jsonString(want)))
+	}
+	return diffs
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(data) > 200 {
+		return string(data[:200]) + "..."
+	}
+	return string(data)
+}
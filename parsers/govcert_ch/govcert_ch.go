@@ -31,6 +31,10 @@ func (c *customDialect) NewReader(data string) *csv.Reader {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "govcert.ch") {
+		return nil, common.NewParserError("not from govcert.ch")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -33,6 +33,10 @@ func cleanURL(url string) string {
 
 // Parse parses emails from @griffeshield.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "griffeshield.com") {
+		return nil, common.NewParserError("not from griffeshield.com")
+	}
+
 	// Get body and subject
 	bodyRaw, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -21,6 +21,10 @@ func NewParser() *Parser {
 // Parse parses emails from heficed.com
 // Handles both regular abuse reports and CSV spam attachments
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "heficed.com") {
+		return nil, common.NewParserError("not from heficed.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
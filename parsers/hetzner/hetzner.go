@@ -37,6 +37,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "acedatacenter.com", "hetzner.com") {
+		return nil, common.NewParserError("not from acedatacenter.com or hetzner.com")
+	}
+
 	subject, _ := common.GetSubject(serializedEmail, false)
 	body, _ := common.GetBody(serializedEmail, false)
 
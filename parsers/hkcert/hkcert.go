@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "hkcert.org") {
+		return nil, common.NewParserError("not from hkcert.org")
+	}
+
 	event := events.NewEvent("hkcert")
 
 	// Parse event date from headers
@@ -155,6 +155,10 @@ func parseDateTime(value string) (time.Time, error) {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "hostdime.com") {
+		return nil, common.NewParserError("not from hostdime.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,10 @@ var urlPattern = regexp.MustCompile(`(?i)(uri|url):\s*https?://\w+`)
 
 // Parse parses emails from hosteurope.de
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "hosteurope.de") {
+		return nil, common.NewParserError("not from hosteurope.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *pkgemail.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "hostopia.com") {
+		return nil, common.NewParserError("not from hostopia.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
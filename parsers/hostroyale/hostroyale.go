@@ -23,6 +23,10 @@ var (
 
 // Parse parses emails from abuse@hostroyale.com or support@hostroyale.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "hostroyale.com", "ipvanish.com") {
+		return nil, common.NewParserError("not from hostroyale.com or ipvanish.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "humongoushibiscus.com") {
+		return nil, common.NewParserError("not from humongoushibiscus.com")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	bodyLower := strings.ToLower(body)
 
@@ -20,6 +20,10 @@ var (
 
 // Parse parses emails from drp-response@group-ib.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "group-ib.com") {
+		return nil, common.NewParserError("not from group-ib.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
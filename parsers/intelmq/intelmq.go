@@ -0,0 +1,334 @@
+// Package intelmq parses IntelMQ / n6 exports classified with the Reference
+// Security Incident Taxonomy (RSIT), as sent by many national CERTs
+package intelmq
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// Feed configures a sender of IntelMQ reports. Onboarding a CERT that sends
+// IntelMQ exports only needs an entry here to get its own parser name.
+type Feed struct {
+	// Parser is the parser name set on the events
+	Parser string
+	// Senders are From addresses or "@domain" suffixes
+	Senders []string
+	// Dedicated leaves reports of these senders to their own vendor parser
+	Dedicated bool
+}
+
+// Feeds are the known IntelMQ senders; reports from other senders are
+// attributed to the "intelmq" parser
+var Feeds = []Feed{
+	{Parser: "certat", Senders: []string{"@cert.at"}, Dedicated: true},
+	{Parser: "cert_ee", Senders: []string{"@cert.ee", "@ria.ee"}, Dedicated: true},
+	{Parser: "switchch", Senders: []string{"@switch.ch"}, Dedicated: true},
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Record is one IntelMQ event with its field names as exported. IntelMQ uses
+// dotted names (source.ip); CSV exports frequently flatten them to source_ip.
+type Record map[string]string
+
+// Get returns a field by its dotted IntelMQ name, accepting underscored exports
+func (r Record) Get(field string) string {
+	if value, ok := r[field]; ok {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(r[strings.ReplaceAll(field, ".", "_")])
+}
+
+// Extra returns the free-form extra data, either stored as a JSON object in
+// the "extra" field or flattened into "extra.*" fields
+func (r Record) Extra() map[string]interface{} {
+	extra := make(map[string]interface{})
+	if raw := r.Get("extra"); raw != "" {
+		_ = json.Unmarshal([]byte(raw), &extra)
+	}
+	for key, value := range r {
+		if name, ok := strings.CutPrefix(key, "extra."); ok {
+			extra[name] = value
+		}
+	}
+	return extra
+}
+
+// Parse finds an IntelMQ CSV or JSON export in the attachments or the body
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	records := findRecords(serializedEmail)
+	if records == nil {
+		return nil, common.NewParserError("no IntelMQ export found")
+	}
+
+	from, _ := common.GetFrom(serializedEmail, false)
+	feed := feedForSender(from)
+	if feed.Dedicated {
+		return nil, common.NewParserError(fmt.Sprintf("IntelMQ export of %s is handled by %s", from, feed.Parser))
+	}
+
+	var eventsList []*events.Event
+	for _, record := range records {
+		event, err := NewEvent(feed.Parser, record)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			eventsList = append(eventsList, event)
+		}
+	}
+
+	if len(eventsList) == 0 {
+		return nil, common.NewParserError("IntelMQ export contains no events")
+	}
+	return eventsList, nil
+}
+
+// NewEvent converts a record to an event. Records without source are skipped
+// (nil event); unclassified records fail with a NewTypeError.
+func NewEvent(parserName string, record Record) (*events.Event, error) {
+	event := events.NewEvent(parserName)
+	event.IP = record.Get("source.ip")
+	event.URL = record.Get("source.url")
+	event.Domain = record.Get("source.fqdn")
+	if event.IP == "" && event.URL == "" && event.Domain == "" {
+		return nil, nil
+	}
+
+	eventType := EventType(record)
+	if eventType == nil {
+		return nil, common.NewNewTypeError(firstNonEmpty(record.Get("classification.type"), record.Get("classification.identifier")))
+	}
+	event.EventTypes = []events.EventType{eventType}
+
+	if port, err := strconv.Atoi(record.Get("source.port")); err == nil {
+		event.Port = port
+	}
+
+	date := firstNonEmpty(record.Get("time.source"), record.Get("time.observation"))
+	event.EventDate = common.ParseDate(date)
+
+	if asn := record.Get("source.asn"); asn != "" || record.Get("source.as_name") != "" {
+		event.AddEventDetail(&events.ASN{ASN: asn, ASName: record.Get("source.as_name")})
+	}
+	if protocol := record.Get("protocol.transport"); protocol != "" {
+		event.AddEventDetail(&events.TransportProtocol{Protocol: protocol})
+	}
+	if country, city := record.Get("source.geolocation.cc"), record.Get("source.geolocation.city"); country != "" || city != "" {
+		event.AddEventDetail(&events.Location{Country: country, City: city})
+	}
+	target := &events.Target{
+		IP:   record.Get("destination.ip"),
+		Port: record.Get("destination.port"),
+		URL:  record.Get("destination.url"),
+	}
+	if target.IP != "" || target.Port != "" || target.URL != "" {
+		event.AddEventDetail(target)
+	}
+
+	for _, field := range []string{"feed.name", "feed.provider", "classification.taxonomy", "classification.type", "classification.identifier"} {
+		if value := record.Get(field); value != "" {
+			event.Headers[strings.ReplaceAll(field, ".", "_")] = value
+		}
+	}
+
+	return event, nil
+}
+
+// feedForSender returns the configured feed of a From address
+func feedForSender(from string) Feed {
+	from = strings.ToLower(from)
+	for _, feed := range Feeds {
+		for _, sender := range feed.Senders {
+			if from == sender || (strings.HasPrefix(sender, "@") && strings.HasSuffix(from, sender)) {
+				return feed
+			}
+		}
+	}
+	return Feed{Parser: "intelmq"}
+}
+
+// findRecords returns the records of the first IntelMQ-shaped attachment or body
+func findRecords(serializedEmail *email.SerializedEmail) []Record {
+	var candidates []string
+	var walk func(parts []email.EmailPart)
+	walk = func(parts []email.EmailPart) {
+		for _, part := range parts {
+			if data := partData(part); data != "" {
+				candidates = append(candidates, data)
+			}
+			walk(part.Parts)
+		}
+	}
+	walk(serializedEmail.Parts)
+	if body, err := common.GetBody(serializedEmail, false); err == nil {
+		candidates = append(candidates, body)
+	}
+
+	for _, data := range candidates {
+		if records, err := ParseExport(data); err == nil && isIntelMQ(records) {
+			return records
+		}
+	}
+	return nil
+}
+
+// partData returns the text of a part, unpacking zip and base64 attachments.
+// Text parts are only base64-decoded if their transfer encoding says so, since
+// short CSV or JSON values can happen to be valid base64.
+func partData(part email.EmailPart) string {
+	var data []byte
+	switch b := part.Body.(type) {
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		return ""
+	}
+
+	if !bytes.HasPrefix(data, []byte("PK")) && (!strings.HasPrefix(part.ContentType, "text/") || isBase64Encoded(part)) {
+		if decoded, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(data), nil))); err == nil && len(decoded) > 0 {
+			data = decoded
+		}
+	}
+	if bytes.HasPrefix(data, []byte("PK")) {
+		content, err := common.HandleZipPart(data)
+		if err != nil {
+			return ""
+		}
+		return content
+	}
+	return string(data)
+}
+
+// isBase64Encoded reports whether a part declares a base64 transfer encoding
+func isBase64Encoded(part email.EmailPart) bool {
+	for _, encoding := range part.Headers["content-transfer-encoding"] {
+		if strings.EqualFold(strings.TrimSpace(encoding), "base64") {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseExport parses an IntelMQ JSON (array, object or one object per line) or CSV export
+func ParseExport(data string) ([]Record, error) {
+	trimmed := strings.TrimSpace(data)
+	if trimmed == "" {
+		return nil, common.NewParserError("empty IntelMQ export")
+	}
+	if trimmed[0] == '[' || trimmed[0] == '{' {
+		return parseJSON(trimmed)
+	}
+	return parseCSV(trimmed)
+}
+
+func parseCSV(data string) ([]Record, error) {
+	reader, err := common.NewCSVReaderFromString(data, &common.CSVOptions{RawHeaders: true})
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	err = reader.ForEach(func(row map[string]string) error {
+		records = append(records, Record(row))
+		return nil
+	})
+	return records, err
+}
+
+func parseJSON(data string) ([]Record, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var records []Record
+	for {
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				if object, ok := item.(map[string]interface{}); ok {
+					records = append(records, flatten(object))
+				}
+			}
+		case map[string]interface{}:
+			records = append(records, flatten(v))
+		}
+	}
+	return records, nil
+}
+
+// flatten converts a (possibly nested) JSON event to dotted fields. The extra
+// object is kept as JSON so Record.Extra sees the same shape as in CSV exports.
+func flatten(object map[string]interface{}) Record {
+	record := make(Record)
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if prefix == "extra" {
+				if encoded, err := json.Marshal(v); err == nil {
+					record[prefix] = string(encoded)
+				}
+				return
+			}
+			for key, item := range v {
+				name := key
+				if prefix != "" {
+					name = prefix + "." + key
+				}
+				walk(name, item)
+			}
+		case nil:
+		case string:
+			record[prefix] = v
+		default:
+			if encoded, err := json.Marshal(v); err == nil {
+				record[prefix] = string(encoded)
+			}
+		}
+	}
+	walk("", object)
+	return record
+}
+
+// isIntelMQ reports whether records carry IntelMQ's dotted source and
+// classification fields; underscored exports are too generic to claim
+func isIntelMQ(records []Record) bool {
+	if len(records) == 0 {
+		return false
+	}
+	record := records[0]
+	_, hasIP := record["source.ip"]
+	_, hasURL := record["source.url"]
+	_, hasFQDN := record["source.fqdn"]
+	_, hasType := record["classification.type"]
+	_, hasTaxonomy := record["classification.taxonomy"]
+	return (hasIP || hasURL || hasFQDN) && (hasType || hasTaxonomy)
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityFormat
+}
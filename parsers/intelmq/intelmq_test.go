@@ -0,0 +1,108 @@
+package intelmq
+
+import (
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+func TestParser_CSVExport(t *testing.T) {
+	csvData := "time.source,source.ip,source.port,source.asn,protocol.transport,classification.taxonomy,classification.type,classification.identifier,feed.name,extra\n" +
+		"2025-10-18T12:00:00+00:00,192.0.2.1,123,64496,udp,vulnerable,ddos-amplifier,ntp-monitor,Open NTP,\"{\"\"amplification\"\": 556.9}\"\n" +
+		"2025-10-18T12:05:00+00:00,192.0.2.2,,64496,,malicious-code,infected-system,,Sinkhole,\n"
+
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"CERT <reports@cert.example>"}},
+		Body:    "Please find attached the events for your network.",
+		Parts: []email.EmailPart{
+			{ContentType: "text/csv", Body: csvData},
+		},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+
+	amplifier, ok := result[0].EventTypes[0].(*events.DDosAmplification)
+	if !ok || amplifier.Amplification != "556.9" {
+		t.Errorf("Expected ddos amplification with extra factor, got %+v", result[0].EventTypes[0])
+	}
+	if result[0].Parser != "intelmq" || result[0].IP != "192.0.2.1" || result[0].Port != 123 {
+		t.Errorf("Unexpected event: %+v", result[0])
+	}
+	if result[0].Headers["feed_name"] != "Open NTP" {
+		t.Errorf("Expected feed name header, got %v", result[0].Headers)
+	}
+	if result[0].EventDate == nil || result[0].EventDate.Hour() != 12 {
+		t.Errorf("Expected time.source as event date, got %v", result[0].EventDate)
+	}
+	if result[1].EventTypes[0].GetName() != "malware" {
+		t.Errorf("Expected malware, got %s", result[1].EventTypes[0].GetName())
+	}
+}
+
+func TestParser_JSONExportAndFeedConfig(t *testing.T) {
+	jsonData := `{"source": {"ip": "198.51.100.7", "asn": 64500}, "classification": {"taxonomy": "intrusion-attempts", "type": "brute-force"}, "time.source": "2025-10-18T08:00:00Z"}
+{"source.url": "http://phish.example/login", "classification.type": "phishing", "time.source": "2025-10-18T09:00:00Z"}`
+
+	Feeds = append(Feeds, Feed{Parser: "cert_example", Senders: []string{"@cert.example"}})
+	defer func() { Feeds = Feeds[:len(Feeds)-1] }()
+
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"reports@cert.example"}},
+		Body:    jsonData,
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+	if result[0].Parser != "cert_example" || result[0].EventTypes[0].GetName() != "login_attack" {
+		t.Errorf("Unexpected first event: %+v", result[0])
+	}
+	if result[1].URL != "http://phish.example/login" || result[1].EventTypes[0].GetName() != "phishing" {
+		t.Errorf("Unexpected second event: %+v", result[1])
+	}
+}
+
+func TestParser_DedicatedSenderAndUnderscoredRecords(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"reports@cert.at"}},
+		Body:    "source.ip,classification.type\n192.0.2.1,scanner\n",
+	}
+	if _, err := NewParser().Parse(serializedEmail); err == nil {
+		t.Error("Expected dedicated sender to be left to its own parser")
+	}
+
+	record := Record{"source_ip": "192.0.2.1", "classification_identifier": "open-redis"}
+	open, ok := EventType(record).(*events.Open)
+	if !ok || open.Service != "open-redis" {
+		t.Errorf("Expected open service from identifier, got %+v", EventType(record))
+	}
+}
+
+func TestPartData_Base64OnlyWhenEncoded(t *testing.T) {
+	// "dGVzdA==" is base64 for "test", but also a plausible text value
+	cases := []struct {
+		name string
+		part email.EmailPart
+		want string
+	}{
+		{"text", email.EmailPart{ContentType: "text/plain", Body: "dGVzdA=="}, "dGVzdA=="},
+		{"base64 text", email.EmailPart{ContentType: "text/plain", Headers: map[string][]string{"content-transfer-encoding": {"base64"}}, Body: "dGVzdA=="}, "test"},
+		{"binary", email.EmailPart{ContentType: "application/octet-stream", Body: []byte("dGVzdA==")}, "test"},
+	}
+	for _, tc := range cases {
+		if got := partData(tc.part); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package intelmq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+)
+
+// rsitTypes maps classification.type values of the Reference Security Incident
+// Taxonomy (as used by IntelMQ 3) and the pre-RSIT IntelMQ 1 names to event types.
+// Types that need record context (malware name, identifier, extra) are handled
+// in EventType before this table is consulted.
+var rsitTypes = map[string]func() events.EventType{
+	// abusive-content
+	"spam":           func() events.EventType { return events.NewSpam() },
+	"harmful-speech": func() events.EventType { return events.NewPropaganda() },
+	"violence":       func() events.EventType { return events.NewViolence() },
+	// malicious-code
+	"c2-server":             func() events.EventType { return events.NewMalwareHosting() },
+	"malware-distribution":  func() events.EventType { return events.NewMalwareHosting() },
+	"malware-configuration": func() events.EventType { return events.NewMalwareHosting() },
+	// information-gathering
+	"scanner":            func() events.EventType { return events.NewPortScan() },
+	"sniffing":           func() events.EventType { return events.NewMaliciousActivity() },
+	"social-engineering": func() events.EventType { return events.NewPhishing() },
+	// intrusion-attempts
+	"ids-alert":   func() events.EventType { return events.NewMaliciousActivity() },
+	"brute-force": func() events.EventType { return events.NewLoginAttack("", "") },
+	"exploit":     func() events.EventType { return events.NewExploit() },
+	// intrusions
+	"privileged-account-compromise":   func() events.EventType { return events.NewCompromisedServer() },
+	"unprivileged-account-compromise": func() events.EventType { return events.NewCompromisedAccount("") },
+	"application-compromise":          func() events.EventType { return events.NewCompromisedWebsite("") },
+	"system-compromise":               func() events.EventType { return events.NewCompromisedServer() },
+	"burglary":                        func() events.EventType { return events.NewUnknown() },
+	// availability
+	"dos":      func() events.EventType { return events.NewDDoS() },
+	"ddos":     func() events.EventType { return events.NewDDoS() },
+	"outage":   func() events.EventType { return events.NewUnknown() },
+	"sabotage": func() events.EventType { return events.NewUnknown() },
+	// information-content-security
+	"unauthorised-information-access":       func() events.EventType { return events.NewCompromisedServer() },
+	"unauthorised-information-modification": func() events.EventType { return events.NewDefacement() },
+	"data-loss":                             func() events.EventType { return events.NewUnknown() },
+	"data-leak":                             func() events.EventType { return events.NewUnknown() },
+	// fraud
+	"unauthorized-use-of-resources": func() events.EventType { return events.NewFraud() },
+	"copyright":                     func() events.EventType { return events.NewCopyright("", "", "") },
+	"masquerade":                    func() events.EventType { return events.NewFraud() },
+	"phishing":                      func() events.EventType { return events.NewPhishing() },
+	// vulnerable
+	"weak-crypto":                     func() events.EventType { return events.NewOpen("weak-crypto") },
+	"information-disclosure":          func() events.EventType { return events.NewOpen("information-disclosure") },
+	"potentially-unwanted-accessible": func() events.EventType { return events.NewOpen("") },
+	// other
+	"blacklist":    func() events.EventType { return events.NewBlacklist("") },
+	"dga-domain":   func() events.EventType { return events.NewMalware("") },
+	"tor":          func() events.EventType { return events.NewMaliciousActivity() },
+	"other":        func() events.EventType { return events.NewUnknown() },
+	"undetermined": func() events.EventType { return events.NewUnknown() },
+	"unknown":      func() events.EventType { return events.NewUnknown() },
+	// IntelMQ 1 names
+	"botnet-drone":       func() events.EventType { return events.NewBot("") },
+	"c&c":                func() events.EventType { return events.NewMalwareHosting() },
+	"compromised":        func() events.EventType { return events.NewCompromisedServer() },
+	"defacement":         func() events.EventType { return events.NewDefacement() },
+	"proxy":              func() events.EventType { return events.NewOpen("proxy") },
+	"vulnerable-service": func() events.EventType { return events.NewOpen("") },
+	"leak":               func() events.EventType { return events.NewUnknown() },
+}
+
+// taxonomyDefaults maps classification.taxonomy to an event type for feeds
+// that only classify by taxonomy
+var taxonomyDefaults = map[string]func() events.EventType{
+	"abusive-content":              func() events.EventType { return events.NewSpam() },
+	"malicious-code":               func() events.EventType { return events.NewMalware("") },
+	"information-gathering":        func() events.EventType { return events.NewPortScan() },
+	"intrusion-attempts":           func() events.EventType { return events.NewExploit() },
+	"intrusions":                   func() events.EventType { return events.NewCompromisedServer() },
+	"availability":                 func() events.EventType { return events.NewDDoS() },
+	"information-content-security": func() events.EventType { return events.NewUnknown() },
+	"fraud":                        func() events.EventType { return events.NewFraud() },
+	"vulnerable":                   func() events.EventType { return events.NewOpen("") },
+	"other":                        func() events.EventType { return events.NewUnknown() },
+}
+
+// normaliseClassification lowercases and dashes classification values, so
+// "Infected System", "infected_system" and "infected-system" compare equal
+func normaliseClassification(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(value)
+}
+
+// EventType maps the classification of a record to an event type.
+// classification.identifier refines the type where the taxonomy is too coarse
+// (CVE ids, open-*/accessible-* services); nil means the record is unclassified.
+func EventType(record Record) events.EventType {
+	taxonomy := normaliseClassification(record.Get("classification.taxonomy"))
+	classType := normaliseClassification(record.Get("classification.type"))
+	identifier := strings.ToLower(strings.TrimSpace(record.Get("classification.identifier")))
+
+	switch classType {
+	case "infected-system", "malware":
+		return events.NewMalware(firstNonEmpty(record.Get("malware.name"), identifier))
+	case "ddos-amplifier":
+		amplification := ""
+		if value, ok := record.Extra()["amplification"]; ok {
+			amplification = fmt.Sprintf("%v", value)
+		}
+		return events.NewDDosAmplification("", amplification)
+	case "vulnerable-system", "vulnerable-client":
+		if strings.HasPrefix(identifier, "cve-") {
+			return events.NewCVE(strings.ToUpper(identifier), "", "")
+		}
+		return events.NewOpen(common.MapServiceStrings(identifier))
+	case "potentially-unwanted-accessible", "vulnerable-service":
+		if identifier != "" {
+			return events.NewOpen(common.MapServiceStrings(identifier))
+		}
+	case "blacklist":
+		return events.NewBlacklist(record.Get("feed.name"))
+	}
+
+	if constructor, ok := rsitTypes[classType]; ok {
+		return constructor()
+	}
+
+	// Feeds without a (known) type, identifier heuristics as used by cert_ee
+	switch {
+	case strings.HasPrefix(identifier, "cve-"):
+		return events.NewCVE(strings.ToUpper(identifier), "", "")
+	case strings.HasPrefix(identifier, "open-"), strings.HasPrefix(identifier, "accessible-"):
+		return events.NewOpen(common.MapServiceStrings(identifier))
+	case strings.Contains(identifier, "scan"):
+		return events.NewPortScan()
+	case strings.Contains(identifier, "blacklisted"):
+		return events.NewBlacklist("")
+	case strings.Contains(identifier, "ddos"):
+		return events.NewDDoS()
+	}
+
+	if constructor, ok := taxonomyDefaults[taxonomy]; ok {
+		return constructor()
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -118,6 +118,10 @@ func parseLoginAttack(body string) ([]*events.Event, error) {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "interconnect.amazon") {
+		return nil, common.NewParserError("not from interconnect.amazon")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,422 @@
+// Package iodef parses IODEF incident reports (RFC 5070 and RFC 7970)
+package iodef
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// The structs below cover the subset of IODEF 1 and 2 needed for events.
+// Element names are matched without namespace, so both versions decode
+// into the same types; attributes that were renamed in IODEF 2 are listed twice.
+
+type document struct {
+	XMLName   xml.Name   `xml:"IODEF-Document"`
+	Version   string     `xml:"version,attr"`
+	Incidents []incident `xml:"Incident"`
+}
+
+type incident struct {
+	Purpose     string       `xml:"purpose,attr"`
+	IncidentID  incidentID   `xml:"IncidentID"`
+	ReportTime  string       `xml:"ReportTime"`
+	DetectTime  string       `xml:"DetectTime"`
+	StartTime   string       `xml:"StartTime"`
+	EndTime     string       `xml:"EndTime"`
+	Description []string     `xml:"Description"`
+	Assessments []assessment `xml:"Assessment"`
+	EventData   []eventData  `xml:"EventData"`
+}
+
+type incidentID struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type assessment struct {
+	// Impact is IODEF 1, SystemImpact replaces it in IODEF 2
+	Impacts       []impact `xml:"Impact"`
+	SystemImpacts []impact `xml:"SystemImpact"`
+}
+
+type impact struct {
+	Type     string `xml:"type,attr"`
+	ExtType  string `xml:"ext-type,attr"`
+	Severity string `xml:"severity,attr"`
+}
+
+type eventData struct {
+	DetectTime  string       `xml:"DetectTime"`
+	StartTime   string       `xml:"StartTime"`
+	EndTime     string       `xml:"EndTime"`
+	Assessments []assessment `xml:"Assessment"`
+	Flows       []flow       `xml:"Flow"`
+	EventData   []eventData  `xml:"EventData"`
+}
+
+type flow struct {
+	Systems []system `xml:"System"`
+}
+
+type system struct {
+	Category string    `xml:"category,attr"`
+	Node     node      `xml:"Node"`
+	Services []service `xml:"Service"`
+}
+
+type node struct {
+	Addresses []address `xml:"Address"`
+	// NodeName is IODEF 1, DomainData/Name is IODEF 2
+	NodeNames   []string `xml:"NodeName"`
+	DomainNames []string `xml:"DomainData>Name"`
+}
+
+type address struct {
+	Category string `xml:"category,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type service struct {
+	IPProtocol   string `xml:"ip_protocol,attr"`
+	IPProtocolV2 string `xml:"ip-protocol,attr"`
+	Port         string `xml:"Port"`
+	Portlist     string `xml:"Portlist"`
+}
+
+// ipProtocols maps IANA protocol numbers used in Service@ip_protocol to names
+var ipProtocols = map[string]string{
+	"1":  "icmp",
+	"6":  "tcp",
+	"17": "udp",
+	"41": "ipv6",
+	"47": "gre",
+	"58": "ipv6-icmp",
+}
+
+// impactTypes maps IODEF 1 Impact@type and IODEF 2 SystemImpact@type values to event types
+var impactTypes = map[string]func() events.EventType{
+	"dos":                  func() events.EventType { return events.NewDDoS() },
+	"availability-service": func() events.EventType { return events.NewDDoS() },
+	"availability-system":  func() events.EventType { return events.NewDDoS() },
+	"admin":                func() events.EventType { return events.NewCompromisedServer() },
+	"takeover-system":      func() events.EventType { return events.NewCompromisedServer() },
+	"takeover-service":     func() events.EventType { return events.NewCompromisedServer() },
+	"user":                 func() events.EventType { return events.NewCompromisedAccount("") },
+	"takeover-account":     func() events.EventType { return events.NewCompromisedAccount("") },
+	"breach-credential":    func() events.EventType { return events.NewCompromisedAccount("") },
+	"recon":                func() events.EventType { return events.NewPortScan() },
+	"social-engineering":   func() events.EventType { return events.NewPhishing() },
+	"extortion":            func() events.EventType { return events.NewFraud() },
+	"misconfiguration":     func() events.EventType { return events.NewOpen("") },
+	"file":                 func() events.EventType { return events.NewMalware("") },
+	"integrity-data":       func() events.EventType { return events.NewWebHack() },
+	"traffic-redirection":  func() events.EventType { return events.NewRogueDNS() },
+	"unknown":              func() events.EventType { return events.NewUnknown() },
+}
+
+// extTypes maps IncidentTypeToEventType names used in ext-type attributes to event types
+var extTypes = map[string]func() events.EventType{
+	"spam":            func() events.EventType { return events.NewSpam() },
+	"phishing":        func() events.EventType { return events.NewPhishing() },
+	"bot":             func() events.EventType { return events.NewBot("") },
+	"ddos":            func() events.EventType { return events.NewDDoS() },
+	"malware":         func() events.EventType { return events.NewMalware("") },
+	"malware_hosting": func() events.EventType { return events.NewMalwareHosting() },
+	"login_attack":    func() events.EventType { return events.NewLoginAttack("", "") },
+	"port_scan":       func() events.EventType { return events.NewPortScan() },
+	"exploit":         func() events.EventType { return events.NewExploit() },
+	"web_hack":        func() events.EventType { return events.NewWebHack() },
+	"open":            func() events.EventType { return events.NewOpen("") },
+	"defacement":      func() events.EventType { return events.NewDefacement() },
+}
+
+// Parse extracts one event per source system address of each IODEF incident
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	data := findIODEF(serializedEmail)
+	if data == "" {
+		return nil, common.NewParserError("no IODEF document found")
+	}
+
+	var doc document
+	if err := xml.Unmarshal([]byte(data), &doc); err != nil {
+		return nil, common.NewParserError("invalid IODEF document: " + err.Error())
+	}
+
+	var eventsList []*events.Event
+	for _, inc := range doc.Incidents {
+		eventsList = append(eventsList, parseIncident(inc)...)
+	}
+
+	if len(eventsList) == 0 {
+		return nil, common.NewParserError("IODEF document contains no reportable systems")
+	}
+	return eventsList, nil
+}
+
+// findIODEF returns the first body or attachment holding an IODEF document
+func findIODEF(serializedEmail *email.SerializedEmail) string {
+	if body, err := common.GetBody(serializedEmail, false); err == nil {
+		if doc := extractIODEF(body); doc != "" {
+			return doc
+		}
+	}
+
+	var walk func(parts []email.EmailPart) string
+	walk = func(parts []email.EmailPart) string {
+		for _, part := range parts {
+			var body string
+			switch b := part.Body.(type) {
+			case string:
+				body = b
+			case []byte:
+				body = string(b)
+			}
+			if doc := extractIODEF(body); doc != "" {
+				return doc
+			}
+			if doc := walk(part.Parts); doc != "" {
+				return doc
+			}
+		}
+		return ""
+	}
+	return walk(serializedEmail.Parts)
+}
+
+// extractIODEF returns the IODEF document in body, decoding base64 attachments
+// and dropping any text preceding the XML
+func extractIODEF(body string) string {
+	if index := strings.Index(body, "IODEF-Document"); index >= 0 {
+		if start := strings.Index(body, "<?xml"); start >= 0 && start < index {
+			return body[start:]
+		}
+		if start := strings.LastIndex(body[:index], "<"); start >= 0 {
+			return body[start:]
+		}
+		return ""
+	}
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(trimmed), ""))
+	if err == nil && strings.Contains(string(decoded), "IODEF-Document") {
+		return string(decoded)
+	}
+	return ""
+}
+
+// parseIncident creates events for the source systems of all (nested) EventData
+func parseIncident(inc incident) []*events.Event {
+	caseID := strings.TrimSpace(inc.IncidentID.Value)
+	incidentTypes, severity := assessTypes(inc.Assessments)
+
+	start := firstDate(inc.StartTime, inc.DetectTime, inc.ReportTime)
+	end := common.ParseDate(inc.EndTime)
+
+	var eventsList []*events.Event
+	var walk func(data []eventData, types []events.EventType, severity string, start, end *time.Time)
+	walk = func(data []eventData, types []events.EventType, severity string, start, end *time.Time) {
+		for _, ed := range data {
+			edTypes, edSeverity := assessTypes(ed.Assessments)
+			if len(edTypes) == 0 {
+				edTypes = types
+			}
+			if edSeverity == "" {
+				edSeverity = severity
+			}
+			edStart := firstDate(ed.StartTime, ed.DetectTime)
+			if edStart == nil {
+				edStart = start
+			}
+			edEnd := common.ParseDate(ed.EndTime)
+			if edEnd == nil {
+				edEnd = end
+			}
+
+			for _, f := range ed.Flows {
+				target := flowTarget(f)
+				for _, sys := range f.Systems {
+					if sys.Category != "source" {
+						continue
+					}
+					for _, event := range systemEvents(sys) {
+						event.EventTypes = edTypes
+						if len(event.EventTypes) == 0 {
+							event.EventTypes = []events.EventType{events.NewUnknown()}
+						}
+						event.EventDate = edStart
+						if edEnd != nil {
+							event.AddEventDetailSimple("end_date", edEnd.Format(time.RFC3339))
+						}
+						if target != nil {
+							event.AddEventDetail(target)
+						}
+						if caseID != "" {
+							event.AddEventDetail(&events.ExternalCaseInformation{
+								CaseID:   caseID,
+								Status:   inc.Purpose,
+								Severity: edSeverity,
+							})
+						}
+						eventsList = append(eventsList, event)
+					}
+				}
+			}
+
+			walk(ed.EventData, edTypes, edSeverity, edStart, edEnd)
+		}
+	}
+	walk(inc.EventData, incidentTypes, severity, start, end)
+
+	return eventsList
+}
+
+// assessTypes maps the impacts of the assessments to event types and the highest severity
+func assessTypes(assessments []assessment) ([]events.EventType, string) {
+	var types []events.EventType
+	seen := make(map[string]bool)
+	severity := ""
+	severityRank := map[string]int{"low": 1, "medium": 2, "high": 3}
+
+	for _, a := range assessments {
+		for _, imp := range append(a.Impacts, a.SystemImpacts...) {
+			if severityRank[imp.Severity] > severityRank[severity] {
+				severity = imp.Severity
+			}
+
+			var eventType events.EventType
+			if constructor, ok := impactTypes[imp.Type]; ok {
+				eventType = constructor()
+			} else if imp.Type == "ext-value" && imp.ExtType != "" {
+				if constructor, ok := extTypes[common.IncidentTypeToEventType(imp.ExtType)]; ok {
+					eventType = constructor()
+				}
+			}
+			if eventType != nil && !seen[eventType.GetName()] {
+				seen[eventType.GetName()] = true
+				types = append(types, eventType)
+			}
+		}
+	}
+	return types, severity
+}
+
+// systemEvents creates an event for every address and domain of a system
+func systemEvents(sys system) []*events.Event {
+	port, protocol := systemService(sys)
+
+	newEvent := func() *events.Event {
+		event := events.NewEvent("iodef")
+		event.Port = port
+		if protocol != "" {
+			event.AddEventDetail(&events.TransportProtocol{Protocol: protocol})
+		}
+		return event
+	}
+
+	var eventsList []*events.Event
+	for _, addr := range sys.Node.Addresses {
+		value := strings.TrimSpace(addr.Value)
+		if value == "" {
+			continue
+		}
+		event := newEvent()
+		switch {
+		case strings.Contains(addr.Category, "uri") || strings.Contains(addr.Category, "url"):
+			event.URL = common.CleanURL(value)
+		case strings.HasPrefix(addr.Category, "ipv4") || strings.HasPrefix(addr.Category, "ipv6") || addr.Category == "":
+			// Networks are reported by their first address
+			event.IP = strings.SplitN(value, "/", 2)[0]
+		default:
+			continue
+		}
+		eventsList = append(eventsList, event)
+	}
+
+	if len(eventsList) == 0 {
+		for _, name := range append(sys.Node.NodeNames, sys.Node.DomainNames...) {
+			if name = strings.TrimSpace(name); name != "" {
+				event := newEvent()
+				event.Domain = name
+				eventsList = append(eventsList, event)
+			}
+		}
+	}
+	return eventsList
+}
+
+// systemService returns the first single port and the protocol of a system
+func systemService(sys system) (int, string) {
+	port := 0
+	protocol := ""
+	for _, svc := range sys.Services {
+		number := svc.IPProtocol
+		if number == "" {
+			number = svc.IPProtocolV2
+		}
+		if protocol == "" {
+			protocol = ipProtocols[number]
+		}
+		if port == 0 {
+			if p, err := strconv.Atoi(strings.TrimSpace(svc.Port)); err == nil {
+				port = p
+			} else if p, err := strconv.Atoi(strings.TrimSpace(svc.Portlist)); err == nil {
+				port = p
+			}
+		}
+	}
+	return port, protocol
+}
+
+// flowTarget returns the first target system of a flow as event detail
+func flowTarget(f flow) *events.Target {
+	for _, sys := range f.Systems {
+		if sys.Category != "target" {
+			continue
+		}
+		target := &events.Target{}
+		for _, addr := range sys.Node.Addresses {
+			if value := strings.TrimSpace(addr.Value); value != "" {
+				target.IP = value
+				break
+			}
+		}
+		if port, _ := systemService(sys); port != 0 {
+			target.Port = strconv.Itoa(port)
+		}
+		if target.IP != "" || target.Port != "" {
+			return target
+		}
+	}
+	return nil
+}
+
+// firstDate parses the first non-empty, valid date
+func firstDate(values ...string) *time.Time {
+	for _, value := range values {
+		if t := common.ParseDate(value); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityFormat
+}
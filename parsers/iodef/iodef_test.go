@@ -0,0 +1,133 @@
+package iodef
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+const iodefV1 = `<?xml version="1.0" encoding="UTF-8"?>
+<IODEF-Document version="1.00" xmlns="urn:ietf:params:xml:ns:iodef-1.0">
+  <Incident purpose="reporting">
+    <IncidentID name="csirt.example.com">CSIRT-2025-0042</IncidentID>
+    <ReportTime>2025-10-18T12:00:00+00:00</ReportTime>
+    <Assessment>
+      <Impact type="recon" severity="medium" completion="succeeded"/>
+    </Assessment>
+    <EventData>
+      <StartTime>2025-10-18T10:00:00+02:00</StartTime>
+      <EndTime>2025-10-18T11:00:00+02:00</EndTime>
+      <Flow>
+        <System category="source">
+          <Node><Address category="ipv4-addr">192.0.2.10</Address></Node>
+          <Service ip_protocol="6"><Port>4444</Port></Service>
+        </System>
+        <System category="target">
+          <Node><Address category="ipv4-addr">198.51.100.1</Address></Node>
+          <Service ip_protocol="6"><Port>22</Port></Service>
+        </System>
+      </Flow>
+    </EventData>
+  </Incident>
+</IODEF-Document>`
+
+const iodefV2 = `<?xml version="1.0" encoding="UTF-8"?>
+<iodef:IODEF-Document version="2.00" xmlns:iodef="urn:ietf:params:xml:ns:iodef-2.0">
+  <iodef:Incident purpose="mitigation">
+    <iodef:IncidentID name="cert.example.org">INC-7</iodef:IncidentID>
+    <iodef:GenerationTime>2025-10-18T12:00:00Z</iodef:GenerationTime>
+    <iodef:DetectTime>2025-10-18T09:00:00Z</iodef:DetectTime>
+    <iodef:Assessment>
+      <iodef:SystemImpact type="availability-service" severity="high"/>
+    </iodef:Assessment>
+    <iodef:EventData>
+      <iodef:Flow>
+        <iodef:System category="source">
+          <iodef:Node>
+            <iodef:Address category="ipv6-addr">2001:db8::1</iodef:Address>
+            <iodef:Address category="ipv4-addr">203.0.113.5</iodef:Address>
+          </iodef:Node>
+          <iodef:Service ip-protocol="17"><iodef:Port>123</iodef:Port></iodef:Service>
+        </iodef:System>
+      </iodef:Flow>
+    </iodef:EventData>
+  </iodef:Incident>
+</iodef:IODEF-Document>`
+
+func TestParser_IODEF1Body(t *testing.T) {
+	result, err := NewParser().Parse(&email.SerializedEmail{Body: iodefV1})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(result))
+	}
+
+	event := result[0]
+	if event.IP != "192.0.2.10" || event.Port != 4444 {
+		t.Errorf("Unexpected source: %s:%d", event.IP, event.Port)
+	}
+	if event.EventTypes[0].GetName() != "port_scan" {
+		t.Errorf("Expected port_scan, got %s", event.EventTypes[0].GetName())
+	}
+	if event.EventDate == nil || event.EventDate.Hour() != 8 {
+		t.Errorf("Expected start time 08:00 UTC, got %v", event.EventDate)
+	}
+
+	var caseInfo *events.ExternalCaseInformation
+	var target *events.Target
+	var protocol *events.TransportProtocol
+	for _, detail := range event.EventDetails {
+		switch d := detail.(type) {
+		case *events.ExternalCaseInformation:
+			caseInfo = d
+		case *events.Target:
+			target = d
+		case *events.TransportProtocol:
+			protocol = d
+		}
+	}
+	if caseInfo == nil || caseInfo.CaseID != "CSIRT-2025-0042" || caseInfo.Severity != "medium" {
+		t.Errorf("Unexpected case information: %+v", caseInfo)
+	}
+	if target == nil || target.IP != "198.51.100.1" || target.Port != "22" {
+		t.Errorf("Unexpected target: %+v", target)
+	}
+	if protocol == nil || protocol.Protocol != "tcp" {
+		t.Errorf("Unexpected protocol: %+v", protocol)
+	}
+}
+
+func TestParser_IODEF2Attachment(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Body: "See the attached IODEF report.",
+		Parts: []email.EmailPart{
+			{ContentType: "application/iodef+xml", Body: base64.StdEncoding.EncodeToString([]byte(iodefV2))},
+		},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(result))
+	}
+	if result[0].IP != "2001:db8::1" || result[1].IP != "203.0.113.5" || result[1].Port != 123 {
+		t.Errorf("Unexpected events: %+v %+v", result[0], result[1])
+	}
+	if result[0].EventTypes[0].GetName() != "ddos" {
+		t.Errorf("Expected ddos, got %s", result[0].EventTypes[0].GetName())
+	}
+	if result[0].EventDate == nil || result[0].EventDate.Hour() != 9 {
+		t.Errorf("Expected detect time as event date, got %v", result[0].EventDate)
+	}
+}
+
+func TestParser_NotIODEF(t *testing.T) {
+	if _, err := NewParser().Parse(&email.SerializedEmail{Body: "plain text report"}); err == nil {
+		t.Error("Expected error for non-IODEF email")
+	}
+}
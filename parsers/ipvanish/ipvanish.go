@@ -148,18 +148,12 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, err
 	}
 
-	// Extract new body and headers
-	newBody, newHeaders, err := getNewHeaderAndBody(body, serializedEmail)
-	if err != nil {
+	// The ticket is checked for a forwarded message but never rewritten;
+	// parsers must leave the email as they found it.
+	if _, _, err := getNewHeaderAndBody(body, serializedEmail); err != nil {
 		return nil, err
 	}
 
-	// Modify the serialized email in place (rewrite pattern)
-	serializedEmail.Body = newBody
-	serializedEmail.Headers = newHeaders
-
-	// Create a simple event - the actual parsing will be done by another parser
-	// after this rewrite. This is a pass-through to indicate the email was rewritten.
 	event := events.NewEvent("ipvanish")
 	event.EventTypes = []events.EventType{events.NewSpam()}
 
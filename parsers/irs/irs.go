@@ -23,6 +23,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "irs.gov") {
+		return nil, common.NewParserError("not from irs.gov")
+	}
+
 	// Get body and subject with throws=True to match Python behavior
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
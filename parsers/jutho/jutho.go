@@ -19,6 +19,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from @jutho.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "jutho.com") {
+		return nil, common.NewParserError("not from jutho.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -21,6 +21,10 @@ var (
 )
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "klingler.net") {
+		return nil, common.NewParserError("not from klingler.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -1,7 +1,6 @@
 package latam
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/abusix/inbound-parsers/events"
@@ -15,14 +14,6 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-// stripHTML removes HTML tags from a string (mimics BeautifulSoup's text extraction)
-func stripHTML(html string) string {
-	// Remove all HTML tags
-	re := regexp.MustCompile(`<[^>]*>`)
-	text := re.ReplaceAllString(html, "")
-	return text
-}
-
 // parsePhishing handles phishing reports from LATAM
 func parsePhishing(body string, event *events.Event, dateFallback string) ([]*events.Event, error) {
 	// Set event date from fallback
@@ -39,9 +30,7 @@ func parsePhishing(body string, event *events.Event, dateFallback string) ([]*ev
 	}
 
 	// Extract phishing URL
-	url := common.GetNonEmptyLineAfter(body, "phishing website hosted at:")
-	url = strings.ReplaceAll(url, " ", "")
-	event.URL = url
+	event.URL = common.RefangURL(common.GetNonEmptyLineAfter(body, "phishing website hosted at:"))
 
 	// Extract IP address
 	ip := common.FindStringWithoutMarkers(body, "IP:", "")
@@ -91,10 +80,14 @@ func parseTrademark(body string, event *events.Event, dateFallback string) ([]*e
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "latam.com") {
+		return nil, common.NewParserError("not from latam.com")
+	}
+
 	event := events.NewEvent("latam")
 
-	// Get email body
-	body, err := common.GetBody(serializedEmail, true)
+	// Get email body as plain text
+	parsedBody, err := common.GetTextBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +98,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, err
 	}
 
-	// Strip HTML tags from body to get plain text (mimics BeautifulSoup)
-	parsedBody := stripHTML(body)
-
 	// Extract external ID from subject if present
 	extID := common.FindStringWithoutMarkers(subject, "(Tracking:", ")")
 	if extID != "" {
@@ -25,6 +25,10 @@ func New() *Parser {
 
 // Parse parses emails from legal@leakserv.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "leakserv.com") {
+		return nil, common.NewParserError("not from leakserv.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || strings.TrimSpace(body) == "" {
 		// Try to get body from parts[0]
@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "leaseweb.com") {
+		return nil, common.NewParserError("not from leaseweb.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
 		return nil, common.NewParserError("email body is empty")
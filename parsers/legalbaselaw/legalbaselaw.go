@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @legalbaselaw.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "legalbaselaw.com") {
+		return nil, common.NewParserError("not from legalbaselaw.com")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -20,6 +20,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from Limestone Networks
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "limestonenetworks.com") {
+		return nil, common.NewParserError("not from limestonenetworks.com")
+	}
+
 	// Get body and subject with throws=true
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
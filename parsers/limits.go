@@ -0,0 +1,332 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+)
+
+// Limits bound the work the registry spends on one email
+type Limits struct {
+	// ParserTimeout is the time one parser may take; a parser running longer
+	// is abandoned and the next one tried. 0 disables the timeout.
+	ParserTimeout time.Duration
+	// MaxEmailBytes is the size of the headers and bodies of an email above
+	// which it is refused before any parser runs. 0 disables the limit.
+	MaxEmailBytes int
+	// MaxDecodedBytes limits the decompressed size of one zip or gzip
+	// attachment (see common.SetMaxDecodedBytes). 0 disables the limit.
+	MaxDecodedBytes int64
+	// QuarantineAfter is the number of panics and timeouts in a row after
+	// which a parser is skipped for QuarantineFor. 0 disables the quarantine.
+	QuarantineAfter int
+	QuarantineFor   time.Duration
+}
+
+// DefaultLimits returns the limits the registry starts with
+func DefaultLimits() Limits {
+	return Limits{
+		ParserTimeout:   10 * time.Second,
+		MaxEmailBytes:   50 << 20,
+		MaxDecodedBytes: common.DefaultMaxDecodedBytes,
+		QuarantineAfter: 3,
+		QuarantineFor:   15 * time.Minute,
+	}
+}
+
+var limits atomic.Pointer[Limits]
+
+func init() {
+	defaults := DefaultLimits()
+	limits.Store(&defaults)
+}
+
+// SetLimits replaces the limits of the registry
+func SetLimits(l Limits) {
+	limits.Store(&l)
+	common.SetMaxDecodedBytes(l.MaxDecodedBytes)
+}
+
+// CurrentLimits returns the limits of the registry
+func CurrentLimits() Limits {
+	return *limits.Load()
+}
+
+// OnParserPanic, if set, is called with every recovered parser panic, in
+// addition to the registry logging it with its stack trace
+var OnParserPanic func(p *ParserPanic)
+
+// ParserPanic is the error of a parser that panicked
+type ParserPanic struct {
+	Parser string
+	Value  interface{}
+	Stack  []byte
+}
+
+func (e *ParserPanic) Error() string {
+	return fmt.Sprintf("parser %s panicked: %v", e.Parser, e.Value)
+}
+
+// ParserTimeoutError is the error of a parser that ran past the timeout
+type ParserTimeoutError struct {
+	Parser  string
+	Timeout time.Duration
+}
+
+func (e *ParserTimeoutError) Error() string {
+	return fmt.Sprintf("parser %s timed out after %s", e.Parser, e.Timeout)
+}
+
+// EmailTooLargeError is returned for emails above Limits.MaxEmailBytes
+type EmailTooLargeError struct {
+	Size  int
+	Limit int
+}
+
+func (e *EmailTooLargeError) Error() string {
+	return fmt.Sprintf("email of %d bytes exceeds the limit of %d bytes", e.Size, e.Limit)
+}
+
+// errQuarantined marks the attempt of a quarantined parser, which is skipped
+var errQuarantined = errors.New("parser is quarantined after repeated panics or timeouts")
+
+// RunParser runs a single parser on an email under the registry limits,
+// ignoring the quarantine; a panic is returned as a *ParserPanic
+func RunParser(ctx context.Context, pw ParserWrapper, serializedEmail *email.SerializedEmail, emit base.EmitFunc) error {
+	l := CurrentLimits()
+	if err := checkEmailSize(serializedEmail, l); err != nil {
+		return err
+	}
+	runner := newAttemptRunner(serializedEmail, l)
+	defer runner.close()
+	return runner.run(ctx, pw, true, emit)
+}
+
+// attemptRunner runs the parsers tried on one email. With a timeout or a
+// context that can be done, they run one after another on a worker
+// goroutine, so that a parser running too long can be abandoned. An
+// abandoned parser keeps running on its worker, so the following parsers
+// get a new one; they share the email with it, which is safe because
+// parsers don't modify the email (see base.Parser).
+type attemptRunner struct {
+	l     Limits
+	email *email.SerializedEmail
+	work  chan func()
+}
+
+func newAttemptRunner(serializedEmail *email.SerializedEmail, l Limits) *attemptRunner {
+	return &attemptRunner{l: l, email: serializedEmail}
+}
+
+// close stops the worker once its parser returns
+func (r *attemptRunner) close() {
+	if r.work != nil {
+		close(r.work)
+		r.work = nil
+	}
+}
+
+// run runs one parser: with stream set, streaming parsers emit as they go;
+// otherwise the parser's Parse result is emitted if it succeeds. A panic
+// is recovered into a *ParserPanic and a parser running past the timeout is
+// abandoned with a *ParserTimeoutError; events it emits afterwards are
+// dropped.
+func (r *attemptRunner) run(ctx context.Context, pw ParserWrapper, stream bool, emit base.EmitFunc) error {
+	if r.l.ParserTimeout <= 0 && ctx.Done() == nil {
+		return callParser(pw, r.email, stream, emit)
+	}
+	parent := ctx
+	if r.l.ParserTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.l.ParserTimeout)
+		defer cancel()
+	}
+	if r.work == nil {
+		r.work = make(chan func())
+		go func(work <-chan func()) {
+			for job := range work {
+				job()
+			}
+		}(r.work)
+	}
+
+	// The mutex keeps the parser's emits from overlapping with the return
+	// after a timeout
+	var mu sync.Mutex
+	abandoned := false
+	guarded := func(event *events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if abandoned {
+			return ctx.Err()
+		}
+		return emit(event)
+	}
+	done := make(chan error, 1)
+	serializedEmail := r.email
+	r.work <- func() {
+		done <- callParser(pw, serializedEmail, stream, guarded)
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		mu.Lock()
+		abandoned = true
+		mu.Unlock()
+		r.close()
+		if parent.Err() != nil {
+			return parent.Err()
+		}
+		return &ParserTimeoutError{Parser: pw.Name(), Timeout: r.l.ParserTimeout}
+	}
+}
+
+func callParser(pw ParserWrapper, serializedEmail *email.SerializedEmail, stream bool, emit base.EmitFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &ParserPanic{Parser: pw.Name(), Value: r, Stack: debug.Stack()}
+		}
+	}()
+	if streaming, ok := pw.Parser.(base.StreamingParser); ok && stream {
+		return streaming.ParseStream(serializedEmail, emit)
+	}
+	evs, err := pw.Parser.Parse(serializedEmail)
+	if err != nil || len(evs) == 0 {
+		return err
+	}
+	return base.EmitAll(evs, emit)
+}
+
+// emailSize is the size of the headers and bodies of an email
+func emailSize(serializedEmail *email.SerializedEmail) int {
+	size := headerSize(serializedEmail.Headers) + bodySize(serializedEmail.Body)
+	var parts func([]email.EmailPart)
+	parts = func(list []email.EmailPart) {
+		for _, part := range list {
+			size += headerSize(part.Headers) + bodySize(part.Body)
+			parts(part.Parts)
+		}
+	}
+	parts(serializedEmail.Parts)
+	return size
+}
+
+func headerSize(headers map[string][]string) int {
+	size := 0
+	for name, values := range headers {
+		for _, value := range values {
+			size += len(name) + len(value)
+		}
+	}
+	return size
+}
+
+func bodySize(body interface{}) int {
+	switch body := body.(type) {
+	case string:
+		return len(body)
+	case []byte:
+		return len(body)
+	}
+	return 0
+}
+
+// quarantine counts the panics and timeouts in a row of each parser and
+// skips parsers that failed Limits.QuarantineAfter times for
+// Limits.QuarantineFor
+var quarantine = struct {
+	sync.Mutex
+	failures map[string]int
+	until    map[string]time.Time
+}{failures: make(map[string]int), until: make(map[string]time.Time)}
+
+// quarantined reports whether a parser is skipped
+func quarantined(name string) bool {
+	quarantine.Lock()
+	defer quarantine.Unlock()
+	until, ok := quarantine.until[name]
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+	delete(quarantine.until, name)
+	return false
+}
+
+// recordOutcome counts the attempt of a parser toward the quarantine if err
+// is a panic or a timeout and resets its count otherwise; panics are logged
+// with their stack
+func recordOutcome(ctx context.Context, parser string, err error, l Limits) {
+	var parserPanic *ParserPanic
+	var timeoutErr *ParserTimeoutError
+	switch {
+	case errors.As(err, &parserPanic):
+		slog.ErrorContext(ctx, "Parser panicked", logging.KeyParser, parserPanic.Parser,
+			"panic", fmt.Sprint(parserPanic.Value), "stack", string(parserPanic.Stack))
+		if OnParserPanic != nil {
+			OnParserPanic(parserPanic)
+		}
+		countFailure(ctx, parserPanic.Parser, l)
+	case errors.As(err, &timeoutErr):
+		// logAttempt already logged the timeout
+		countFailure(ctx, timeoutErr.Parser, l)
+	default:
+		quarantine.Lock()
+		delete(quarantine.failures, parser)
+		quarantine.Unlock()
+	}
+}
+
+// countFailure counts a panic or timeout and quarantines the parser at the
+// limit
+func countFailure(ctx context.Context, parser string, l Limits) {
+	if l.QuarantineAfter <= 0 {
+		return
+	}
+	quarantine.Lock()
+	defer quarantine.Unlock()
+	quarantine.failures[parser]++
+	if quarantine.failures[parser] >= l.QuarantineAfter {
+		quarantine.until[parser] = time.Now().Add(l.QuarantineFor)
+		quarantine.failures[parser] = 0
+		slog.WarnContext(ctx, "Quarantining parser", logging.KeyParser, parser,
+			"for", l.QuarantineFor.String(), "failures", l.QuarantineAfter)
+	}
+}
+
+// QuarantinedParsers returns the parsers currently skipped and until when
+func QuarantinedParsers() map[string]time.Time {
+	quarantine.Lock()
+	defer quarantine.Unlock()
+	now := time.Now()
+	result := make(map[string]time.Time)
+	for name, until := range quarantine.until {
+		if now.Before(until) {
+			result[name] = until
+		}
+	}
+	return result
+}
+
+// ResetQuarantine releases all parsers and clears the failure counts
+func ResetQuarantine() {
+	quarantine.Lock()
+	defer quarantine.Unlock()
+	quarantine.failures = make(map[string]int)
+	quarantine.until = make(map[string]time.Time)
+}
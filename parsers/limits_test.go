@@ -0,0 +1,183 @@
+package parsers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// panicParser panics on every email
+type panicParser struct{ stubParser }
+
+func (p *panicParser) Parse(*email.SerializedEmail) ([]*events.Event, error) {
+	var parts []email.EmailPart
+	_ = parts[1]
+	return nil, nil
+}
+
+// slowParser blocks until released
+type slowParser struct {
+	stubParser
+	release chan struct{}
+}
+
+func (p *slowParser) Parse(*email.SerializedEmail) ([]*events.Event, error) {
+	<-p.release
+	return []*events.Event{events.NewEvent("slow")}, nil
+}
+
+// flakyParser panics on every other email
+type flakyParser struct {
+	stubParser
+	calls int
+}
+
+func (p *flakyParser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	p.calls++
+	if p.calls%2 == 1 {
+		return (&panicParser{}).Parse(serializedEmail)
+	}
+	return []*events.Event{events.NewEvent("flaky")}, nil
+}
+
+func withLimits(t *testing.T, l Limits) {
+	t.Helper()
+	previous, onPanic := CurrentLimits(), OnParserPanic
+	SetLimits(l)
+	OnParserPanic = nil
+	ResetQuarantine()
+	t.Cleanup(func() {
+		SetLimits(previous)
+		OnParserPanic = onPanic
+		ResetQuarantine()
+	})
+}
+
+func collectStream(ctx context.Context, candidates []ParserWrapper, serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	var evs []*events.Event
+	_, err := parseEmailStream(ctx, candidates, serializedEmail, func(event *events.Event) error {
+		evs = append(evs, event)
+		return nil
+	}, nil)
+	return evs, err
+}
+
+func TestParseEmailStream_RecoversPanics(t *testing.T) {
+	withLimits(t, Limits{})
+	var recovered []*ParserPanic
+	OnParserPanic = func(p *ParserPanic) { recovered = append(recovered, p) }
+
+	fallback := events.NewEvent("fallback")
+	candidates := []ParserWrapper{
+		{Parser: &panicParser{}, Priority: 1},
+		{Parser: &stubParser{events: []*events.Event{fallback}}, Priority: 100},
+	}
+	evs, err := collectStream(context.Background(), candidates, &email.SerializedEmail{})
+	if err != nil || len(evs) != 1 || evs[0] != fallback {
+		t.Fatalf("Expected the fallback event, got %v, %v", evs, err)
+	}
+	if len(recovered) != 1 {
+		t.Fatalf("Expected 1 recovered panic, got %d", len(recovered))
+	}
+	if recovered[0].Parser != "parsers" || !strings.Contains(string(recovered[0].Stack), "panicParser") {
+		t.Errorf("Expected the parser name and stack, got %q\n%s", recovered[0].Parser, recovered[0].Stack)
+	}
+}
+
+func TestParseEmailStream_Quarantine(t *testing.T) {
+	withLimits(t, Limits{QuarantineAfter: 2, QuarantineFor: time.Hour})
+	panics := 0
+	OnParserPanic = func(*ParserPanic) { panics++ }
+
+	// The test parsers all share the package name, so only one is used
+	candidates := []ParserWrapper{{Parser: &panicParser{}, Priority: 1}}
+	for i := 0; i < 2; i++ {
+		if _, explanation, _ := Explain(&email.SerializedEmail{}, nil, candidates, nil); explanation.Attempts[0].Outcome != OutcomePanicked {
+			t.Fatalf("Run %d: expected a panic, got %+v", i, explanation.Attempts[0])
+		}
+	}
+	if _, ok := QuarantinedParsers()["parsers"]; !ok {
+		t.Fatalf("Expected the parser to be quarantined, got %v", QuarantinedParsers())
+	}
+
+	_, explanation, _ := Explain(&email.SerializedEmail{}, nil, candidates, nil)
+	if explanation.Attempts[0].Outcome != OutcomeQuarantined || panics != 2 {
+		t.Errorf("Expected the quarantined parser to be skipped, got %+v after %d panics", explanation.Attempts[0], panics)
+	}
+}
+
+func TestParseEmailStream_Timeout(t *testing.T) {
+	withLimits(t, Limits{ParserTimeout: 20 * time.Millisecond})
+	slow := &slowParser{release: make(chan struct{})}
+	defer close(slow.release)
+
+	fallback := events.NewEvent("fallback")
+	candidates := []ParserWrapper{
+		{Parser: slow, Priority: 1},
+		{Parser: &stubParser{events: []*events.Event{fallback}}, Priority: 100},
+	}
+	count, explanation, err := Explain(&email.SerializedEmail{}, nil, candidates, nil)
+	if err != nil || count != 1 {
+		t.Fatalf("Expected the fallback event, got %d, %v", count, err)
+	}
+	if explanation.Attempts[0].Outcome != OutcomeTimedOut {
+		t.Errorf("Expected the slow parser to time out, got %+v", explanation.Attempts[0])
+	}
+
+	// A done context stops parsing
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := collectStream(ctx, candidates, &email.SerializedEmail{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParseEmailStream_TimeoutQuarantine(t *testing.T) {
+	withLimits(t, Limits{ParserTimeout: 20 * time.Millisecond, QuarantineAfter: 2, QuarantineFor: time.Hour})
+	slow := &slowParser{release: make(chan struct{})}
+	defer close(slow.release)
+
+	candidates := []ParserWrapper{{Parser: slow, Priority: 1}}
+	for i := 0; i < 2; i++ {
+		if _, explanation, _ := Explain(&email.SerializedEmail{}, nil, candidates, nil); explanation.Attempts[0].Outcome != OutcomeTimedOut {
+			t.Fatalf("Run %d: expected a timeout, got %+v", i, explanation.Attempts[0])
+		}
+	}
+	if _, ok := QuarantinedParsers()["parsers"]; !ok {
+		t.Errorf("Expected the parser to be quarantined after 2 timeouts, got %v", QuarantinedParsers())
+	}
+}
+
+func TestParseEmailStream_QuarantineResets(t *testing.T) {
+	withLimits(t, Limits{QuarantineAfter: 2, QuarantineFor: time.Hour})
+
+	// Panics separated by a successful run are not counted together
+	candidates := []ParserWrapper{{Parser: &flakyParser{}, Priority: 1}}
+	for i := 0; i < 4; i++ {
+		if _, _, err := Explain(&email.SerializedEmail{}, nil, candidates, nil); err != nil {
+			t.Fatalf("Run %d: %v", i, err)
+		}
+	}
+	if quarantined := QuarantinedParsers(); len(quarantined) != 0 {
+		t.Errorf("Expected no quarantined parser, got %v", quarantined)
+	}
+}
+
+func TestParseEmailStream_MaxEmailBytes(t *testing.T) {
+	withLimits(t, Limits{MaxEmailBytes: 10})
+	candidates := []ParserWrapper{{Parser: &stubParser{events: []*events.Event{events.NewEvent("stub")}}}}
+
+	_, err := collectStream(context.Background(), candidates, &email.SerializedEmail{Body: strings.Repeat("x", 11)})
+	var tooLarge *EmailTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Size != 11 {
+		t.Errorf("Expected EmailTooLargeError for 11 bytes, got %v", err)
+	}
+	if evs, err := collectStream(context.Background(), candidates, &email.SerializedEmail{Body: "small"}); err != nil || len(evs) != 1 {
+		t.Errorf("Expected the small email to parse, got %v, %v", evs, err)
+	}
+}
@@ -21,6 +21,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from Magazine Luiza (takedown.efc@magazineluiza.com.br)
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "magazineluiza.com.br") {
+		return nil, common.NewParserError("not from magazineluiza.com.br")
+	}
+
 	// Get body and strip HTML
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -20,7 +20,7 @@ func NewParser() *Parser {
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
 	fromAddr, err := common.GetFrom(serializedEmail, false)
 	if err != nil || fromAddr == "" {
-		return nil, common.NewIgnoreError("no from address")
+		return nil, common.NewParserError("no from address")
 	}
 
 	subject, _ := common.GetSubject(serializedEmail, false)
@@ -101,7 +101,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		if headers := serializedEmail.Headers; headers != nil {
 			if xOrigSender, ok := headers["x-original-sender"]; ok && len(xOrigSender) > 0 {
 				if !strings.Contains(xOrigSender[0], "@linode.com") {
-					return nil, common.NewIgnoreError("linode forwarded email")
+					// Reports relayed through a Linode mailing list are left
+					// to the other parsers
+					return nil, common.NewParserError("linode forwarded email")
 				}
 			}
 		}
@@ -350,8 +352,8 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, common.NewRejectError("google registry")
 	}
 
-	// If no rule matched, return IgnoreError (continue processing)
-	return nil, common.NewIgnoreError("no rejection rule matched")
+	// If no rule matched, let the other parsers handle the email
+	return nil, common.NewParserError("no rejection rule matched")
 }
 
 // containsAny checks if s contains any of the substrings in needles
@@ -31,8 +31,8 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// Get the machine-readable report (part 1)
 	reportPart := serializedEmail.Parts[1]
-	if reportPart.Headers == nil {
-		return nil, common.NewParserError("report part has no headers")
+	if reportPart.ContentType != "message/feedback-report" {
+		return nil, common.NewParserError("second part is not a message/feedback-report")
 	}
 
 	// Extract report body
@@ -23,6 +23,10 @@ var (
 )
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "markscan.in") {
+		return nil, common.NewParserError("not from markscan.in")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
 		return nil, common.NewParserError("email body is empty")
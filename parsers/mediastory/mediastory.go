@@ -22,6 +22,10 @@ func New() *Parser {
 
 // Parse parses emails from mediastory.co.kr and mediastory.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "mediastory.co.kr", "mediastory.com") {
+		return nil, common.NewParserError("not from mediastory.co.kr or mediastory.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -24,24 +24,23 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, fmt.Errorf("unexpected subject format: %s", subject)
 	}
 
-	// Extract HTML table as CSV
-	rows, err := common.ExtractHTMLTableAsCSV(body)
+	// Extract the report table
+	table, err := common.ExtractHTMLTable(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract HTML table: %w", err)
 	}
 
 	var result []*events.Event
 
-	for _, row := range rows {
-		// Split CSV row into columns
-		parts := strings.Split(row, ",")
-		if len(parts) < 3 {
+	for _, row := range table.Rows {
+		// Columns: URL, IP, date
+		if len(row) < 3 {
 			continue
 		}
 
-		url := strings.TrimSpace(parts[0])
-		ip := strings.TrimSpace(parts[1])
-		dateStr := strings.TrimSpace(parts[2])
+		url := row[0]
+		ip := row[1]
+		dateStr := row[2]
 
 		// Skip empty rows
 		if url == "" && ip == "" && dateStr == "" {
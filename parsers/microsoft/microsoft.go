@@ -3,7 +3,6 @@ package microsoft
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/abusix/inbound-parsers/events"
 	"github.com/abusix/inbound-parsers/parsers/common"
@@ -50,7 +49,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	if eventDateStr != "" {
 		// Parse date in format: "Oct 18, 2025 14:30 UTC"
 		// Python format: '%b %d, %Y %H:%M %Z'
-		eventDate := parseEventDate(eventDateStr)
+		eventDate := common.ParseDate(eventDateStr)
 		if eventDate != nil {
 			event.EventDate = eventDate
 		}
@@ -75,26 +74,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	return nil, fmt.Errorf("new malware type detected in subject: %s", subject)
 }
 
-// parseEventDate parses date string in format: "Oct 18, 2025 14:30 UTC"
-// Matching Python's: datetime.strptime(event_date, '%b %d, %Y %H:%M %Z')
-func parseEventDate(dateStr string) *time.Time {
-	dateStr = strings.TrimSpace(dateStr)
-	if dateStr == "" {
-		return nil
-	}
-
-	// Convert Python strftime format to Go time format
-	// Python: '%b %d, %Y %H:%M %Z'
-	// Go: 'Jan 2, 2006 15:04 MST'
-	format := "Jan 2, 2006 15:04 MST"
-
-	if t, err := time.Parse(format, dateStr); err == nil {
-		return &t
-	}
-
-	return nil
-}
-
 // GetPriority returns the parser priority (lower numbers run first)
 func (p *Parser) GetPriority() int {
 	return 100 // Default vendor parser priority
@@ -39,6 +39,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from prosus@mih-brandprotection.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "mih-brandprotection.com") {
+		return nil, common.NewParserError("not from mih-brandprotection.com")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
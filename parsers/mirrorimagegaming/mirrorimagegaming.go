@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse converts a mirrorimagegaming email into abuse events
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "mirrorimagegaming.com") {
+		return nil, common.NewParserError("not from mirrorimagegaming.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -19,6 +19,10 @@ var (
 
 // Parse parses emails from @myloc.de
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "myloc.de") {
+		return nil, common.NewParserError("not from myloc.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -20,6 +20,10 @@ var (
 
 // Parse parses emails from @names.co.uk
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "names.co.uk") {
+		return nil, common.NewParserError("not from names.co.uk")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
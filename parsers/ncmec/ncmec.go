@@ -89,6 +89,10 @@ func extractHTMLURLs(body string) []string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ncmec.org") {
+		return nil, common.NewParserError("not from ncmec.org")
+	}
+
 	var eventList []*events.Event
 
 	body, err := common.GetBody(serializedEmail, false)
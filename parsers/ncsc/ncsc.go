@@ -236,6 +236,10 @@ func parseCSV(rawCSV string, delimiter rune) ([]map[string]string, error) {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ncsc.nl") {
+		return nil, common.NewParserError("not from ncsc.nl")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
 
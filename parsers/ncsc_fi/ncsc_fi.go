@@ -25,6 +25,10 @@ func (e *NewTypeError) Error() string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ncsc.fi") {
+		return nil, common.NewParserError("not from ncsc.fi")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
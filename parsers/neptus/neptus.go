@@ -20,6 +20,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from monitoring@neptus.co.id
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "neptus.co.id") {
+		return nil, common.NewParserError("not from neptus.co.id")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -355,6 +355,10 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	}
 
 	bodyLower := strings.ToLower(body)
+	// Netcraft reports are often forwarded by the hosters that got them
+	if !strings.Contains(bodyLower, "netcraft") && !common.SentFrom(serializedEmail, "netcraft.com") {
+		return nil, common.NewParserError("no Netcraft report")
+	}
 	subject, err := common.GetSubject(serializedEmail, false)
 	if err != nil {
 		return nil, err
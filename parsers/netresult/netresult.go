@@ -20,6 +20,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from @notices.nr-online.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "nr-online.com") {
+		return nil, common.NewParserError("not from nr-online.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "nksc.lt") {
+		return nil, common.NewParserError("not from nksc.lt")
+	}
+
 	var allEvents []*events.Event
 
 	// Check for CSV attachments in parts first
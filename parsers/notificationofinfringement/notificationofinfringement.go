@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "removeyourmedia.com", "funimation.com") {
+		return nil, common.NewParserError("not from removeyourmedia.com or funimation.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -49,7 +53,7 @@ func parseBrokenVersion(body, headerDate, subject string) ([]*events.Event, erro
 
 	// Extract external ID from subject
 	var externalID string
-	if idx := strings.Index(subject, "Notice"); idx != -1 {
+	if idx := strings.Index(subject, "Notice"); idx != -1 && idx+7 <= len(subject) {
 		externalID = strings.TrimSpace(subject[idx+7:])
 	}
 
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "sk.com") {
+		return nil, common.NewParserError("not from sk.com")
+	}
+
 	var result []*events.Event
 
 	// Get body using helper - throws error if body is empty
@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @nwf.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "nwf.com") {
+		return nil, common.NewParserError("not from nwf.com")
+	}
+
 	// Get email body
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
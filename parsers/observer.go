@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// Observer is told what the streaming registry does, e.g. to export metrics.
+// Its methods are called on the parsing goroutine and must not block.
+type Observer interface {
+	// ParserAttempted is called after each parser run on an email
+	ParserAttempted(attempt Attempt)
+	// EventEmitted is called for each event handed to the emit callback
+	EventEmitted(event *events.Event)
+	// EmailParsed is called once per email when parsing is done
+	EmailParsed(result EmailResult)
+}
+
+// EmailResult is the outcome of one email
+type EmailResult struct {
+	// Size is the size of the headers and bodies of the email
+	Size int
+	// Parser is the parser that emitted the events or ignored or rejected the
+	// email, empty if none matched
+	Parser   string
+	Events   int
+	Duration time.Duration
+	Err      error
+}
+
+type observerBox struct{ Observer }
+
+var observer atomic.Pointer[observerBox]
+
+// SetObserver sets the observer of ParseEmailStream, ParseEmailStreamContext
+// and Explain; nil removes it
+func SetObserver(o Observer) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&observerBox{o})
+}
+
+func currentObserver() Observer {
+	if box := observer.Load(); box != nil {
+		return box.Observer
+	}
+	return nil
+}
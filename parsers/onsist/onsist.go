@@ -19,6 +19,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from @onsist.net
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "onsist.net") {
+		return nil, common.NewParserError("not from onsist.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse parses emails from takedown@oplium.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "oplium.com") {
+		return nil, common.NewParserError("not from oplium.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
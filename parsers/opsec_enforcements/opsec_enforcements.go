@@ -23,6 +23,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "enforcements.opsecsecurity.com") {
+		return nil, common.NewParserError("not from enforcements.opsecsecurity.com")
+	}
+
 	bodyLower, _ := common.GetBody(serializedEmail, false)
 	bodyLower = strings.ToLower(bodyLower)
 
@@ -30,7 +34,10 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	subjectLower = strings.ToLower(subjectLower)
 
 	// Get date from headers
-	date := email.ParseDate(serializedEmail.Headers["date"][0])
+	var date *time.Time
+	if dateHeaders, ok := serializedEmail.Headers["date"]; ok && len(dateHeaders) > 0 {
+		date = email.ParseDate(dateHeaders[0])
+	}
 
 	// Determine type based on subject and body
 	if strings.Contains(subjectLower, "copyright") {
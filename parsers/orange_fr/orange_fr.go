@@ -27,6 +27,10 @@ func parseIP(body string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "orange.fr") {
+		return nil, common.NewParserError("not from orange.fr")
+	}
+
 	body, _ := common.GetBody(serializedEmail, false)
 	body = strings.ToLower(body)
 
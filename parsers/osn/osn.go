@@ -20,6 +20,10 @@ func NewParser() *Parser {
 var urlPattern = regexp.MustCompile(`^https?://\w+`)
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "osn.com") {
+		return nil, common.NewParserError("not from osn.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, common.NewParserError("failed to get email body: " + err.Error())
@@ -23,6 +23,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from paramount
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "copyright-notice.com") {
+		return nil, common.NewParserError("not from copyright-notice.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
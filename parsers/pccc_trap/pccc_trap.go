@@ -22,6 +22,10 @@ func NewParser() *Parser {
 // Parse parses emails from pccc.com spam trap
 // Python equivalent: parsers/parser/pccc_trap.py
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !sentToTrap(serializedEmail) {
+		return nil, common.NewParserError("not sent to the pccc.com trap")
+	}
+
 	event := events.NewEvent("pccc_trap")
 
 	// Set event types: Spam and Trap (trap is a subtype of spam)
@@ -93,6 +97,19 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	return []*events.Event{event}, nil
 }
 
+// sentToTrap reports whether an email was addressed to or received by the
+// pccc.com trap
+func sentToTrap(serializedEmail *email.SerializedEmail) bool {
+	for _, header := range []string{"to", "delivered-to", "x-original-to", "received"} {
+		for _, value := range serializedEmail.Headers[header] {
+			if strings.Contains(strings.ToLower(value), "pccc.com") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetPriority returns the parser priority (lower numbers run first)
 func (p *Parser) GetPriority() int {
 	return 100 // Default vendor parser priority
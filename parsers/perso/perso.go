@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from pgeens@perso.be
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "perso.be") {
+		return nil, common.NewParserError("not from perso.be")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
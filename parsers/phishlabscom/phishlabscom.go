@@ -80,13 +80,13 @@ func fillEventWithGenericData(serializedEmail *email.SerializedEmail, body strin
 		}
 
 		if !firstSeen && strings.Contains(line, "First detection of malicious activity") {
-			eventDate = parseMagicDateTime(line)
+			eventDate = common.ParseDate(line)
 			firstSeen = true
 			if eventDate != nil {
 				event.AddEventDetailSimple("first_seen", eventDate)
 			}
 		} else if event.EventDate == nil && strings.Contains(line, "Most recent observation of malicious activity") {
-			eventDate = parseMagicDateTime(line)
+			eventDate = common.ParseDate(line)
 			event.EventDate = eventDate
 		} else if event.IP == "" && strings.Contains(line, "Associated IP Address") {
 			// Merge current line with next line
@@ -295,7 +295,7 @@ func parseMalwareInfrastructure(serializedEmail *email.SerializedEmail, body, su
 	lines := strings.Split(body, "\n")
 	for number, line := range lines {
 		if oldDate == nil && strings.Contains(line, "via the abuse webform") {
-			oldDate = parseMagicDateTime(line)
+			oldDate = common.ParseDate(line)
 		}
 
 		// Get IP and source URL
@@ -424,7 +424,7 @@ func parseMobileApplication(serializedEmail *email.SerializedEmail, body string)
 	var eventDate *time.Time
 	if headers := serializedEmail.Headers; headers != nil {
 		if dateHeaders := headers["date"]; len(dateHeaders) > 0 {
-			eventDate = parseMagicDateTime(dateHeaders[0])
+			eventDate = common.ParseDate(dateHeaders[0])
 		}
 	}
 
@@ -453,7 +453,7 @@ func parseFraudulentUse(serializedEmail *email.SerializedEmail, body string) []*
 	var eventDate *time.Time
 	if headers := serializedEmail.Headers; headers != nil {
 		if dateHeaders := headers["date"]; len(dateHeaders) > 0 {
-			eventDate = parseMagicDateTime(dateHeaders[0])
+			eventDate = common.ParseDate(dateHeaders[0])
 		}
 	}
 
@@ -608,48 +608,6 @@ func parsePhishlabsTMS(serializedEmail *email.SerializedEmail, body, subject str
 	return nil // No URL found
 }
 
-// parseMagicDateTime attempts to parse datetime in various formats
-func parseMagicDateTime(dateStr string) *time.Time {
-	if dateStr == "" {
-		return nil
-	}
-
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common formats to try
-	formats := []string{
-		time.RFC3339,
-		time.RFC1123Z,
-		time.RFC1123,
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05.999999999",
-		"2006-01-02T15:04:05.999999999",
-		"Jan 2 2006 15:04:05",
-		"Jan 02 2006 15:04:05",
-		"2 Jan 2006 15:04:05",
-		"02 Jan 2006 15:04:05",
-		"Mon Jan 2 15:04:05 2006",
-		"Mon Jan 02 15:04:05 2006",
-		"2006-01-02",
-		"Mon, 02 Jan 2006 15:04:05 -0700",
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t
-		}
-	}
-
-	// Try email.ParseDate for RFC 5322 formats
-	if t := email.ParseDate(dateStr); t != nil {
-		return t
-	}
-
-	return nil
-}
-
 // extractDomain extracts a domain from a URL or domain string
 func extractDomain(urlStr string) string {
 	urlStr = strings.TrimSpace(urlStr)
@@ -104,6 +104,10 @@ func parseTrademark(body string, eventTemplate *events.Event, dateFallback strin
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "puigbrandprotection.com") {
+		return nil, common.NewParserError("not from puigbrandprotection.com")
+	}
+
 	event := events.NewEvent("puig")
 	body, _ := common.GetBody(serializedEmail, false)
 	subject, _ := common.GetSubject(serializedEmail, false)
@@ -226,6 +226,10 @@ func parseListedFormat(body string, serializedEmail *email.SerializedEmail) ([]*
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "react.org") {
+		return nil, common.NewParserError("not from react.org")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -21,6 +21,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "redfish-solutions.com") {
+		return nil, common.NewParserError("not from redfish-solutions.com")
+	}
+
 	event := events.NewEvent("redfish")
 
 	// Get email body
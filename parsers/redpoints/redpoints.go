@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @redpoints.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "redpoints.com") {
+		return nil, common.NewParserError("not from redpoints.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
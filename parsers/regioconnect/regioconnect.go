@@ -14,6 +14,10 @@ type Parser struct{}
 
 // Parse parses emails from @regioconnect.net
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "regioconnect.net") {
+		return nil, common.NewParserError("not from regioconnect.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
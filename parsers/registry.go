@@ -1,11 +1,20 @@
 package parsers
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"reflect"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/abusix/inbound-parsers/events"
 	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
 	"github.com/abusix/inbound-parsers/pkg/email"
+	"github.com/abusix/inbound-parsers/pkg/logging"
 	"github.com/abusix/inbound-parsers/parsers/abuse_oneprovider"
 	"github.com/abusix/inbound-parsers/parsers/abusehub_nl"
 	"github.com/abusix/inbound-parsers/parsers/abusetrue_nl"
@@ -34,6 +43,7 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/att"
 	"github.com/abusix/inbound-parsers/parsers/attributor"
 	"github.com/abusix/inbound-parsers/parsers/autofusion"
+	"github.com/abusix/inbound-parsers/parsers/autoreply"
 	"github.com/abusix/inbound-parsers/parsers/avoxi"
 	"github.com/abusix/inbound-parsers/parsers/axghouse"
 	"github.com/abusix/inbound-parsers/parsers/axur"
@@ -156,6 +166,7 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/doppel"
 	"github.com/abusix/inbound-parsers/parsers/dreamworldpartners"
 	"github.com/abusix/inbound-parsers/parsers/dreyfus"
+	"github.com/abusix/inbound-parsers/parsers/dsn"
 	"github.com/abusix/inbound-parsers/parsers/easysol"
 	"github.com/abusix/inbound-parsers/parsers/ebay"
 	"github.com/abusix/inbound-parsers/parsers/ebrand"
@@ -187,6 +198,7 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/flyhosting"
 	"github.com/abusix/inbound-parsers/parsers/fmtsoperation"
 	"github.com/abusix/inbound-parsers/parsers/fondia"
+	"github.com/abusix/inbound-parsers/parsers/forwarded"
 	"github.com/abusix/inbound-parsers/parsers/fraudwatch"
 	"github.com/abusix/inbound-parsers/parsers/fraudwatchinternational"
 	"github.com/abusix/inbound-parsers/parsers/freedomtech"
@@ -232,11 +244,13 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/incopro"
 	"github.com/abusix/inbound-parsers/parsers/infringements_cc"
 	"github.com/abusix/inbound-parsers/parsers/innotec"
+	"github.com/abusix/inbound-parsers/parsers/intelmq"
 	"github.com/abusix/inbound-parsers/parsers/interconnect"
 	"github.com/abusix/inbound-parsers/parsers/interhost"
 	"github.com/abusix/inbound-parsers/parsers/interieur_gouv_fr"
 	"github.com/abusix/inbound-parsers/parsers/internet2"
 	"github.com/abusix/inbound-parsers/parsers/intsights"
+	"github.com/abusix/inbound-parsers/parsers/iodef"
 	"github.com/abusix/inbound-parsers/parsers/ionos"
 	"github.com/abusix/inbound-parsers/parsers/ipvanish"
 	"github.com/abusix/inbound-parsers/parsers/ipxo"
@@ -400,6 +414,7 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/squarespace"
 	"github.com/abusix/inbound-parsers/parsers/stackpath"
 	"github.com/abusix/inbound-parsers/parsers/staxogroup"
+	"github.com/abusix/inbound-parsers/parsers/stix"
 	"github.com/abusix/inbound-parsers/parsers/stockpile"
 	"github.com/abusix/inbound-parsers/parsers/stop_or_kr"
 	"github.com/abusix/inbound-parsers/parsers/storage_base"
@@ -432,6 +447,7 @@ import (
 	"github.com/abusix/inbound-parsers/parsers/threeantsds"
 	"github.com/abusix/inbound-parsers/parsers/tikaj"
 	"github.com/abusix/inbound-parsers/parsers/timbrasil"
+	"github.com/abusix/inbound-parsers/parsers/tlsrpt"
 	"github.com/abusix/inbound-parsers/parsers/tmclo"
 	"github.com/abusix/inbound-parsers/parsers/tntelecom"
 	"github.com/abusix/inbound-parsers/parsers/torrent_markmonitor"
@@ -491,8 +507,67 @@ type ParserWrapper struct {
 	Priority int
 }
 
-// AllParsers returns all available parsers sorted by priority
+// Name returns the package name of the parser, which names it on the command line
+func (pw ParserWrapper) Name() string {
+	t := reflect.TypeOf(pw.Parser)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return path.Base(t.PkgPath())
+}
+
+// ParserByName returns the registered parser of a package name
+func ParserByName(name string) (ParserWrapper, bool) {
+	for _, pw := range AllParsers() {
+		if pw.Name() == name {
+			return pw, true
+		}
+	}
+	return ParserWrapper{}, false
+}
+
+// AllParsers returns all available parsers sorted by priority, and by name
+// within a priority so that the routing does not depend on the order of
+// registration
 func AllParsers() []ParserWrapper {
+	parsers := registeredParsers()
+	order := parserOrder(parsers)
+	sorted := make([]ParserWrapper, len(parsers))
+	for i, index := range order {
+		sorted[i] = parsers[index]
+	}
+	return sorted
+}
+
+// runOrder holds the indexes of the registered parsers in run order.
+// Priorities and names are fixed, so it is computed once.
+var runOrder struct {
+	once    sync.Once
+	indexes []int
+}
+
+func parserOrder(parsers []ParserWrapper) []int {
+	runOrder.once.Do(func() {
+		names := make([]string, len(parsers))
+		indexes := make([]int, len(parsers))
+		for i, pw := range parsers {
+			names[i] = pw.Name()
+			indexes[i] = i
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			a, b := indexes[i], indexes[j]
+			if parsers[a].Priority != parsers[b].Priority {
+				return parsers[a].Priority < parsers[b].Priority
+			}
+			return names[a] < names[b]
+		})
+		runOrder.indexes = indexes
+	})
+	return runOrder.indexes
+}
+
+// registeredParsers returns a new instance of every parser with its priority
+func registeredParsers() []ParserWrapper {
 	parsers := []ParserWrapper{
 		{Parser: &abuse_oneprovider.Parser{}},
 		{Parser: &abusehub_nl.Parser{}},
@@ -522,6 +597,7 @@ func AllParsers() []ParserWrapper {
 		{Parser: &att.Parser{}},
 		{Parser: &attributor.Parser{}},
 		{Parser: &autofusion.Parser{}},
+		{Parser: &autoreply.Parser{}},
 		{Parser: &avoxi.Parser{}},
 		{Parser: &axghouse.Parser{}},
 		{Parser: &axur.Parser{}},
@@ -644,6 +720,7 @@ func AllParsers() []ParserWrapper {
 		{Parser: &doppel.Parser{}},
 		{Parser: &dreamworldpartners.Parser{}},
 		{Parser: &dreyfus.Parser{}},
+		{Parser: &dsn.Parser{}},
 		{Parser: &easysol.Parser{}},
 		{Parser: &ebay.Parser{}},
 		{Parser: &ebrand.Parser{}},
@@ -675,6 +752,7 @@ func AllParsers() []ParserWrapper {
 		{Parser: &flyhosting.Parser{}},
 		{Parser: &fmtsoperation.Parser{}},
 		{Parser: &fondia.Parser{}},
+		{Parser: &forwarded.Parser{}},
 		{Parser: &fraudwatch.Parser{}},
 		{Parser: &fraudwatchinternational.Parser{}},
 		{Parser: &freedomtech.Parser{}},
@@ -720,11 +798,13 @@ func AllParsers() []ParserWrapper {
 		{Parser: &incopro.Parser{}},
 		{Parser: &infringements_cc.Parser{}},
 		{Parser: &innotec.Parser{}},
+		{Parser: &intelmq.Parser{}},
 		{Parser: &interconnect.Parser{}},
 		{Parser: &interhost.Parser{}},
 		{Parser: &interieur_gouv_fr.Parser{}},
 		{Parser: &internet2.Parser{}},
 		{Parser: &intsights.Parser{}},
+		{Parser: &iodef.Parser{}},
 		{Parser: &ionos.Parser{}},
 		{Parser: &ipvanish.Parser{}},
 		{Parser: &ipxo.Parser{}},
@@ -888,6 +968,7 @@ func AllParsers() []ParserWrapper {
 		{Parser: &squarespace.Parser{}},
 		{Parser: &stackpath.Parser{}},
 		{Parser: &staxogroup.Parser{}},
+		{Parser: &stix.Parser{}},
 		{Parser: &stockpile.Parser{}},
 		{Parser: &stop_or_kr.Parser{}},
 		{Parser: &storage_base.Parser{}},
@@ -920,6 +1001,7 @@ func AllParsers() []ParserWrapper {
 		{Parser: &threeantsds.Parser{}},
 		{Parser: &tikaj.Parser{}},
 		{Parser: &timbrasil.Parser{}},
+		{Parser: &tlsrpt.Parser{}},
 		{Parser: &tmclo.Parser{}},
 		{Parser: &tntelecom.Parser{}},
 		{Parser: &torrent_markmonitor.Parser{}},
@@ -978,25 +1060,238 @@ func AllParsers() []ParserWrapper {
 		parsers[i].Priority = parsers[i].Parser.GetPriority()
 	}
 
-	// Sort by priority (lower number = higher priority)
-	sort.Slice(parsers, func(i, j int) bool {
-		return parsers[i].Priority < parsers[j].Priority
-	})
-
 	return parsers
 }
 
-// ParseEmail parses an email using all registered parsers in priority order
+func init() {
+	forwarded.Reparse = parseForwarded
+}
+
+// ClassifiedError is returned when a preprocessor or format parser ignored or
+// rejected the email, which ends parsing without events; Err is the
+// parser's common.IgnoreError or common.RejectError
+type ClassifiedError struct {
+	Parser string
+	Err    error
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.Parser + ": " + e.Err.Error()
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// classified returns the ClassifiedError of a parser error that decides the
+// outcome of the email, or nil. Only preprocessors and format parsers
+// classify emails: they run first to keep auto-replies, bounces and the like
+// from the vendor parsers.
+func classified(pw ParserWrapper, err error) error {
+	if err == nil || pw.Priority >= base.PriorityVendor {
+		return nil
+	}
+	var ignoreErr *common.IgnoreError
+	var rejectErr *common.RejectError
+	if errors.As(err, &ignoreErr) || errors.As(err, &rejectErr) {
+		return &ClassifiedError{Parser: pw.Name(), Err: err}
+	}
+	return nil
+}
+
+// parseForwarded parses a message unwrapped by the forwarded preprocessor
+// under the registry limits and quarantine, with the forwarded parser
+// continuing chain. Fallback parsers are skipped: a forwarded message must be
+// a recognised report.
+func parseForwarded(serializedEmail *email.SerializedEmail, chain []forwarded.Hop) ([]*events.Event, error) {
+	ctx := context.Background()
+	l := CurrentLimits()
+	runner := newAttemptRunner(serializedEmail, l)
+	defer runner.close()
+
+	for _, pw := range AllParsers() {
+		if pw.Priority >= base.PriorityFallbackZX {
+			break
+		}
+		if _, ok := pw.Parser.(*forwarded.Parser); ok {
+			pw.Parser = forwarded.Nested(chain)
+		}
+		if quarantined(pw.Name()) {
+			continue
+		}
+		var collected []*events.Event
+		err := runner.run(ctx, pw, false, func(event *events.Event) error {
+			collected = append(collected, event)
+			return nil
+		})
+		recordOutcome(ctx, pw.Name(), err, l)
+		if err == nil && len(collected) > 0 {
+			return collected, nil
+		}
+		if classifiedErr := classified(pw, err); classifiedErr != nil {
+			return nil, classifiedErr
+		}
+	}
+
+	return nil, nil
+}
+
+// ParseEmail parses an email using all registered parsers in priority order.
+// Parsers run under the registry Limits: a parser that panics or times out
+// is skipped like one that does not match. An email ignored or rejected by a
+// preprocessor or format parser returns no events and a ClassifiedError.
 func ParseEmail(serializedEmail *email.SerializedEmail, metadata map[string]interface{}) ([]*events.Event, error) {
-	parsers := AllParsers()
+	l := CurrentLimits()
+	if err := checkEmailSize(serializedEmail, l); err != nil {
+		return nil, err
+	}
 
-	for _, pw := range parsers {
-		events, err := pw.Parser.Parse(serializedEmail)
-		if err == nil && len(events) > 0 {
-			return events, nil
+	attempts := &attemptRecorder{discard: true}
+	runner := newAttemptRunner(serializedEmail, l)
+	defer runner.close()
+	for _, pw := range AllParsers() {
+		if quarantined(pw.Name()) {
+			continue
+		}
+		var collected []*events.Event
+		attempts.start()
+		err := runner.run(context.Background(), pw, false, func(event *events.Event) error {
+			collected = append(collected, event)
+			return nil
+		})
+		logAttempt(context.Background(), attempts.finish(pw, len(collected), err), err)
+		recordOutcome(context.Background(), pw.Name(), err, l)
+		if err == nil && len(collected) > 0 {
+			return collected, nil
+		}
+		if classifiedErr := classified(pw, err); classifiedErr != nil {
+			return nil, classifiedErr
 		}
 		// Continue to next parser if this one failed or returned no events
 	}
 
 	return nil, nil // No parser matched
 }
+
+// ParseEmailStream parses an email like ParseEmail but hands events to emit one
+// at a time. Parsers implementing base.StreamingParser stream their events as
+// they are produced; other parsers are run to completion and their slice is
+// emitted. The first parser that emits at least one event wins. If it fails
+// after emitting, the error is returned since emitted events cannot be taken
+// back. Errors returned by emit abort parsing immediately, and emails ignored
+// or rejected by a preprocessor or format parser end it with a
+// ClassifiedError. Returns the number of events emitted (0 if no parser
+// matched).
+func ParseEmailStream(serializedEmail *email.SerializedEmail, metadata map[string]interface{}, emit base.EmitFunc) (int, error) {
+	return ParseEmailStreamContext(context.Background(), serializedEmail, metadata, emit)
+}
+
+// ParseEmailStreamContext is ParseEmailStream stopping when ctx is done
+func ParseEmailStreamContext(ctx context.Context, serializedEmail *email.SerializedEmail, metadata map[string]interface{}, emit base.EmitFunc) (int, error) {
+	return parseEmailStream(ctx, AllParsers(), serializedEmail, emit, nil)
+}
+
+// parseEmailStream runs parsers in order like ParseEmailStream; attempts, if
+// not nil, records each parser tried
+func parseEmailStream(ctx context.Context, parsers []ParserWrapper, serializedEmail *email.SerializedEmail, emit base.EmitFunc, attempts *attemptRecorder) (emitted int, err error) {
+	if attempts == nil {
+		attempts = &attemptRecorder{discard: true}
+	}
+	observer := currentObserver()
+	if observer != nil {
+		attempts.observer = observer
+		started := time.Now()
+		size := emailSize(serializedEmail)
+		defer func() {
+			result := EmailResult{Size: size, Events: emitted, Duration: time.Since(started), Err: err}
+			var classifiedErr *ClassifiedError
+			if emitted > 0 || errors.As(err, &classifiedErr) {
+				result.Parser = attempts.last
+			}
+			observer.EmailParsed(result)
+		}()
+	}
+
+	l := CurrentLimits()
+	if err := checkEmailSize(serializedEmail, l); err != nil {
+		return 0, err
+	}
+
+	var emitErr error
+	counted := func(event *events.Event) error {
+		if emitErr = emit(event); emitErr != nil {
+			return emitErr
+		}
+		emitted++
+		if observer != nil {
+			observer.EventEmitted(event)
+		}
+		return nil
+	}
+
+	runner := newAttemptRunner(serializedEmail, l)
+	defer runner.close()
+	for _, pw := range parsers {
+		if quarantined(pw.Name()) {
+			logAttempt(ctx, attempts.skip(pw, errQuarantined), nil)
+			continue
+		}
+		attempts.start()
+		err := runner.run(ctx, pw, true, counted)
+		logAttempt(ctx, attempts.finish(pw, emitted, err), err)
+		recordOutcome(ctx, pw.Name(), err, l)
+		if emitErr != nil {
+			return emitted, emitErr
+		}
+		if emitted > 0 {
+			return emitted, err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return 0, ctxErr
+		}
+		if classifiedErr := classified(pw, err); classifiedErr != nil {
+			return 0, classifiedErr
+		}
+		// Nothing emitted: continue to next parser like ParseEmail does
+	}
+
+	return 0, nil // No parser matched
+}
+
+// logAttempt logs a parser run: parsers that failed after emitting events or
+// timed out at warning level, the rest at debug level since most parsers
+// decline foreign emails with an error. Panics are logged with their stack
+// by recordOutcome.
+func logAttempt(ctx context.Context, attempt Attempt, err error) {
+	level := slog.LevelDebug
+	switch attempt.Outcome {
+	case OutcomePanicked:
+		return
+	case OutcomeMatchedWithError, OutcomeTimedOut:
+		level = slog.LevelWarn
+	}
+	logger := slog.Default()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String(logging.KeyParser, attempt.Parser),
+		slog.String(logging.KeyOutcome, attempt.Outcome),
+		slog.Int(logging.KeyEvents, attempt.Events),
+		slog.Float64(logging.KeyDuration, attempt.DurationMS),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any(logging.KeyError, err))
+	}
+	logger.LogAttrs(ctx, level, "Parser attempted", attrs...)
+}
+
+func checkEmailSize(serializedEmail *email.SerializedEmail, l Limits) error {
+	if l.MaxEmailBytes <= 0 {
+		return nil
+	}
+	if size := emailSize(serializedEmail); size > l.MaxEmailBytes {
+		return &EmailTooLargeError{Size: size, Limit: l.MaxEmailBytes}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package parsers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// ownBounce is a bounce of one of our notifications, as Exim sends it
+const ownBounce = "From: Mail Delivery System <Mailer-Daemon@mx.customer.example>\r\n" +
+	"To: reports@abusix.com\r\n" +
+	"Subject: Mail delivery failed: returning message to sender\r\n" +
+	"Auto-Submitted: auto-replied\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"This message was created automatically by mail delivery software.\r\n" +
+	"\r\n" +
+	"A message that you sent could not be delivered to one or more of its\r\n" +
+	"recipients. This is a permanent error. The following address(es) failed:\r\n" +
+	"\r\n" +
+	"  abuse@customer.example\r\n" +
+	"    host mx.customer.example [198.51.100.4]\r\n" +
+	"    SMTP error from remote mail server after RCPT TO:<abuse@customer.example>:\r\n" +
+	"    550 5.1.1 mailbox unavailable\r\n" +
+	"\r\n" +
+	"------ This is a copy of the message, including all the headers. ------\r\n" +
+	"\r\n" +
+	"From: Abuse Reports <reports@abusix.com>\r\n" +
+	"To: abuse@customer.example\r\n" +
+	"Subject: Abuse report for 192.0.2.1\r\n" +
+	"\r\n" +
+	"We received spam from 192.0.2.1.\r\n"
+
+func parseRaw(t *testing.T, raw []byte) *email.SerializedEmail {
+	t.Helper()
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serializedEmail
+}
+
+func TestParseEmail_AutoReplyIgnored(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join(sampleDir, "ybrandprotection.rejected.0.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ParseEmail(parseRaw(t, raw), nil)
+	if len(result) != 0 {
+		t.Errorf("Expected no events, got %d from %s", len(result), result[0].Parser)
+	}
+	var ignoreErr *common.IgnoreError
+	var classifiedErr *ClassifiedError
+	if !errors.As(err, &ignoreErr) || !errors.As(err, &classifiedErr) || classifiedErr.Parser != "autoreply" {
+		t.Fatalf("Expected autoreply to ignore the email, got %v", err)
+	}
+}
+
+func TestParseEmail_OwnBounceRejected(t *testing.T) {
+	for name, parse := range map[string]func(*email.SerializedEmail) (int, error){
+		"ParseEmail": func(serializedEmail *email.SerializedEmail) (int, error) {
+			result, err := ParseEmail(serializedEmail, nil)
+			return len(result), err
+		},
+		"ParseEmailStream": func(serializedEmail *email.SerializedEmail) (int, error) {
+			return ParseEmailStream(serializedEmail, nil, func(*events.Event) error { return nil })
+		},
+	} {
+		count, err := parse(parseRaw(t, []byte(ownBounce)))
+		if count != 0 {
+			t.Errorf("%s: expected no events, got %d", name, count)
+		}
+		var rejectErr *common.RejectError
+		var classifiedErr *ClassifiedError
+		if !errors.As(err, &rejectErr) || !errors.As(err, &classifiedErr) || classifiedErr.Parser != "dsn" {
+			t.Errorf("%s: expected dsn to reject the bounce, got %v", name, err)
+		}
+	}
+}
+
+func TestParseEmailStream_VendorIgnoreContinues(t *testing.T) {
+	candidates := []ParserWrapper{
+		{Parser: &stubParser{err: common.NewIgnoreError("not for us")}, Priority: 100},
+		{Parser: &stubParser{events: []*events.Event{events.NewEvent("stub")}}, Priority: 1000},
+	}
+	result, err := collectStream(t.Context(), candidates, &email.SerializedEmail{})
+	if err != nil || len(result) != 1 {
+		t.Errorf("Expected the fallback to parse the email, got %d events, %v", len(result), err)
+	}
+}
@@ -17,6 +17,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "removal-request.com") {
+		return nil, common.NewParserError("not from removal-request.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -27,7 +31,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	// Try to get event_date from headers first
 	if serializedEmail.Headers != nil {
 		if dateHeader, ok := serializedEmail.Headers["date"]; ok && len(dateHeader) > 0 {
-			if parsedDate := email.ParseDate(dateHeader[0]); parsedDate != nil {
+			if parsedDate := common.ParseDate(dateHeader[0]); parsedDate != nil {
 				event.EventDate = parsedDate
 			}
 		}
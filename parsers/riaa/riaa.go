@@ -28,6 +28,10 @@ func removeHTML(s string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "riaa.com") {
+		return nil, common.NewParserError("not from riaa.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -22,6 +22,10 @@ var (
 
 // Parse parses emails from RiskIQ
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "riskiq.net") {
+		return nil, common.NewParserError("not from riskiq.net")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, common.NewParserError("subject not found")
@@ -15,6 +15,10 @@ type Parser struct{}
 
 // Parse parses emails from @serverplan addresses
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "serverplan.com") {
+		return nil, common.NewParserError("not from serverplan.com")
+	}
+
 	var eventsList []*events.Event
 
 	// Get the email body
@@ -101,6 +101,10 @@ func parseBruteForce(body, dateStr string, externalCaseInfo *events.ExternalCase
 
 // Parse parses emails from @serverstack
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "serverstack.com") {
+		return nil, common.NewParserError("not from serverstack.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -32,6 +32,10 @@ func (p *Parser) Match(serializedEmail *email.SerializedEmail) bool {
 
 // Parse extracts phishing URLs from SIA email reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !p.Match(serializedEmail) {
+		return nil, common.NewParserError("not a SIA report")
+	}
+
 	// Get email body
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
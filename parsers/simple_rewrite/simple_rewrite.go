@@ -118,7 +118,7 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	
 	// Check if this email should be rewritten
 	if !Match(serializedEmail, fromAddr) {
-		return nil, common.NewIgnoreError("simple_rewrite: email does not need rewriting")
+		return nil, common.NewParserError("simple_rewrite: email does not need rewriting")
 	}
 	
 	// Rewrite the email
@@ -127,6 +127,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, err
 	}
 	
-	// Return ignore error to let other parsers handle it
-	return nil, common.NewIgnoreError("simple_rewrite: email rewritten, continuing to other parsers")
+	// Return a parser error to let other parsers handle it
+	return nil, common.NewParserError("simple_rewrite: email rewritten, continuing to other parsers")
 }
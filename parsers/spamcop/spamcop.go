@@ -40,6 +40,10 @@ func NewParser() *Parser {
 
 // Parse parses a SpamCop email
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "spamcop.net", "acedatacenter.com") {
+		return nil, common.NewParserError("not from spamcop.net or acedatacenter.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -212,7 +216,7 @@ func parseAdminAlternate(serializedEmail *email.SerializedEmail, fromAddr, body
 		hour := strings.TrimSuffix(timeParts[2], "h")
 
 		dateTimeStr := fmt.Sprintf("%s %s %d %s:00:00", day, month, year, hour)
-		datetime := parseMagicDateTime(dateTimeStr)
+		datetime := common.ParseDate(dateTimeStr)
 
 		event := events.NewEvent("spamcop")
 		event.IP = ip
@@ -232,7 +236,7 @@ func parseAdminSummary(serializedEmail *email.SerializedEmail, fromAddr, body st
 		return nil, common.NewParserError("time of report not found")
 	}
 
-	timeOfReport := parseMagicDateTime(timeOfReportStr)
+	timeOfReport := common.ParseDate(timeOfReportStr)
 	if timeOfReport == nil {
 		return nil, common.NewParserError("failed to parse time of report")
 	}
@@ -273,13 +277,13 @@ func parseAdminSummary(serializedEmail *email.SerializedEmail, fromAddr, body st
 			year := timeOfReport.Year()
 
 			dateStr := fmt.Sprintf("%s %s %d %s:00:00", day, month, year, hourStr)
-			date := parseMagicDateTime(dateStr)
+			date := common.ParseDate(dateStr)
 
 			// Check if date is in future
 			if date != nil && date.After(*timeOfReport) {
 				year--
 				dateStr = fmt.Sprintf("%s %s %d %s:00:00", day, month, year, hourStr)
-				date = parseMagicDateTime(dateStr)
+				date = common.ParseDate(dateStr)
 			}
 
 			eventDate = date
@@ -329,7 +333,7 @@ func createEvent(line string, year int, fromAddr string) *events.Event {
 	event.EventTypes = []events.EventType{events.NewSpam()}
 
 	dateStr := fmt.Sprintf("%s %s %d %s:00:00", month, day, year, hour)
-	event.EventDate = parseMagicDateTime(dateStr)
+	event.EventDate = common.ParseDate(dateStr)
 	if event.EventDate == nil {
 		return nil
 	}
@@ -379,7 +383,7 @@ func parseReports(serializedEmail *email.SerializedEmail, fromAddr string) ([]*e
 
 				event.URL = url
 				event.IP = ip
-				event.EventDate = parseMagicDateTime(dateStr)
+				event.EventDate = common.ParseDate(dateStr)
 
 				if w3m != "" {
 					evidence := &events.Evidence{}
@@ -413,7 +417,7 @@ func parseReports(serializedEmail *email.SerializedEmail, fromAddr string) ([]*e
 
 				var eventDate *time.Time
 				if dateStr != "" {
-					eventDate = parseMagicDateTime(dateStr)
+					eventDate = common.ParseDate(dateStr)
 				} else {
 					eventDate = extractOldestDate(serializedEmail, body)
 				}
@@ -496,7 +500,7 @@ func extractOldestDate(serializedEmail *email.SerializedEmail, body string) *tim
 	matches := oldestDatePattern.FindAllString(bodyLower, -1)
 
 	if len(matches) > 0 {
-		oldestDate := parseMagicDateTime(matches[0])
+		oldestDate := common.ParseDate(matches[0])
 		if oldestDate == nil {
 			// Fall back to date header
 			if dateHeader, ok := serializedEmail.Headers["date"]; ok && len(dateHeader) > 0 {
@@ -506,7 +510,7 @@ func extractOldestDate(serializedEmail *email.SerializedEmail, body string) *tim
 		}
 
 		for _, dateStr := range matches[1:] {
-			date := parseMagicDateTime(dateStr)
+			date := common.ParseDate(dateStr)
 			if date != nil && date.Before(*oldestDate) {
 				oldestDate = date
 			}
@@ -590,49 +594,6 @@ func addEvidenceHeaders(eventsList []*events.Event, serializedEmail *email.Seria
 	}
 }
 
-// parseMagicDateTime attempts to parse datetime in various formats
-func parseMagicDateTime(dateStr string) *time.Time {
-	if dateStr == "" {
-		return nil
-	}
-
-	dateStr = strings.TrimSpace(dateStr)
-
-	// Common formats to try
-	formats := []string{
-		time.RFC3339,
-		time.RFC1123Z,
-		time.RFC1123,
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05.999999999",
-		"2006-01-02T15:04:05.999999999",
-		"Jan 2 2006 15:04:05",
-		"Jan 02 2006 15:04:05",
-		"2 Jan 2006 15:04:05",
-		"02 Jan 2006 15:04:05",
-		"Mon Jan 2 15:04:05 2006",
-		"Mon Jan 02 15:04:05 2006",
-		"2006-01-02",
-		// SpamCop specific formats
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-		"Mon, 02 Jan 2006 15:04:05 -0700",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t
-		}
-	}
-
-	// Try email.ParseDate for RFC 5322 formats
-	if t := email.ParseDate(dateStr); t != nil {
-		return t
-	}
-
-	return nil
-}
-
 // GetPriority returns the parser priority (lower numbers run first)
 func (p *Parser) GetPriority() int {
 	return 100 // Default vendor parser priority
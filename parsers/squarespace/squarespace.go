@@ -16,6 +16,10 @@ type Parser struct{}
 // Parse parses emails from @squarespace.com
 // Handles phishing, deceptive site/domain, and webshell reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "squarespace.com") {
+		return nil, common.NewParserError("not from squarespace.com")
+	}
+
 	// Get subject and body - both are required
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
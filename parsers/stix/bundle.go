@@ -0,0 +1,270 @@
+package stix
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+)
+
+// object holds the fields of the STIX 2.0/2.1 objects we map; the type
+// decides which of them are set
+type object struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Revoked bool   `json:"revoked"`
+	Created string `json:"created"`
+
+	// indicator
+	Pattern        string   `json:"pattern"`
+	PatternType    string   `json:"pattern_type"`
+	IndicatorTypes []string `json:"indicator_types"`
+	Labels         []string `json:"labels"`
+	ValidFrom      string   `json:"valid_from"`
+	ValidUntil     string   `json:"valid_until"`
+
+	// malware and sighting
+	FirstSeen     string   `json:"first_seen"`
+	LastSeen      string   `json:"last_seen"`
+	SightingOfRef string   `json:"sighting_of_ref"`
+	MalwareTypes  []string `json:"malware_types"`
+
+	// observed-data: STIX 2.1 references cyber observables, 2.0 embeds them
+	FirstObserved string            `json:"first_observed"`
+	LastObserved  string            `json:"last_observed"`
+	ObjectRefs    []string          `json:"object_refs"`
+	Objects       map[string]object `json:"objects"`
+
+	// relationship
+	RelationshipType string `json:"relationship_type"`
+	SourceRef        string `json:"source_ref"`
+	TargetRef        string `json:"target_ref"`
+
+	// cyber observables (ipv4-addr, ipv6-addr, url, domain-name)
+	Value string `json:"value"`
+
+	ExternalReferences []externalReference `json:"external_references"`
+}
+
+type bundle struct {
+	Type    string   `json:"type"`
+	Objects []object `json:"objects"`
+}
+
+var (
+	// patternComparisonPattern matches the equality comparisons of a STIX
+	// pattern, e.g. [ipv4-addr:value = '198.51.100.1' OR url:value = 'http://x/']
+	patternComparisonPattern = regexp.MustCompile(`(ipv4-addr|ipv6-addr|url|domain-name):value\s*=\s*'((?:[^'\\]|\\.)*)'`)
+	patternUnescaper         = strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+
+	// indicatorTypes maps the indicator-type vocabulary (labels in STIX 2.0)
+	// and common provider labels to event types; "benign" indicators are skipped
+	indicatorTypes = map[string]func() events.EventType{
+		"malicious-activity": func() events.EventType { return events.NewMaliciousActivity() },
+		"anomalous-activity": func() events.EventType { return events.NewMaliciousActivity() },
+		"anonymization":      func() events.EventType { return events.NewOpen("proxy") },
+		"attribution":        func() events.EventType { return events.NewMaliciousActivity() },
+		"compromised":        func() events.EventType { return events.NewCompromisedServer() },
+		"phishing":           func() events.EventType { return events.NewPhishing() },
+		"spam":               func() events.EventType { return events.NewSpam() },
+		"scanner":            func() events.EventType { return events.NewPortScan() },
+		"c2":                 func() events.EventType { return events.NewMalwareHosting() },
+		"unknown":            func() events.EventType { return events.NewUnknown() },
+	}
+
+	// c2Relationships link malware to the infrastructure it talks to
+	c2Relationships = map[string]bool{"communicates-with": true, "beacons-to": true, "exfiltrates-to": true, "downloads": true}
+)
+
+// bundleIndex resolves references between the objects of a bundle
+type bundleIndex struct {
+	objects       map[string]*object
+	relationships map[string][]*object
+	sightings     map[string][]*object
+}
+
+// parseBundle maps the indicators, observed data and malware of a STIX bundle to events
+func parseBundle(parserName string, data []byte) ([]*events.Event, error) {
+	var bundles []bundle
+	if err := json.Unmarshal(data, &bundles); err != nil {
+		var single bundle
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("failed to parse STIX bundle: %w", err)
+		}
+		bundles = []bundle{single}
+	}
+
+	var eventsList []*events.Event
+	for _, b := range bundles {
+		index := newBundleIndex(b.Objects)
+		for i := range b.Objects {
+			obj := &b.Objects[i]
+			if obj.Revoked {
+				continue
+			}
+			switch obj.Type {
+			case "indicator":
+				eventsList = append(eventsList, index.indicatorEvents(parserName, obj)...)
+			case "observed-data":
+				eventsList = append(eventsList, index.observedDataEvents(parserName, obj)...)
+			case "malware":
+				eventsList = append(eventsList, index.malwareEvents(parserName, obj)...)
+			}
+		}
+	}
+	return eventsList, nil
+}
+
+func newBundleIndex(objects []object) *bundleIndex {
+	index := &bundleIndex{
+		objects:       make(map[string]*object),
+		relationships: make(map[string][]*object),
+		sightings:     make(map[string][]*object),
+	}
+	for i := range objects {
+		obj := &objects[i]
+		index.objects[obj.ID] = obj
+		switch obj.Type {
+		case "relationship":
+			index.relationships[obj.SourceRef] = append(index.relationships[obj.SourceRef], obj)
+		case "sighting":
+			index.sightings[obj.SightingOfRef] = append(index.sightings[obj.SightingOfRef], obj)
+		}
+	}
+	return index
+}
+
+// indicatorEvents creates one event per observable of the indicator pattern.
+// An "indicates" relationship to malware makes the events Malware events.
+func (b *bundleIndex) indicatorEvents(parserName string, indicator *object) []*events.Event {
+	if indicator.PatternType != "" && indicator.PatternType != "stix" {
+		return nil
+	}
+	labels := append(append([]string{}, indicator.IndicatorTypes...), indicator.Labels...)
+	for _, label := range labels {
+		if strings.EqualFold(label, "benign") {
+			return nil
+		}
+	}
+
+	var eventType func() events.EventType
+	references := indicator.ExternalReferences
+	for _, relationship := range b.relationships[indicator.ID] {
+		if malware := b.objects[relationship.TargetRef]; relationship.RelationshipType == "indicates" && malware != nil && malware.Type == "malware" {
+			name := malware.Name
+			eventType = func() events.EventType { return events.NewMalware(name) }
+			references = append(references, malware.ExternalReferences...)
+			break
+		}
+	}
+	if eventType == nil {
+		eventType = typeForLabels(labels)
+	}
+
+	firstSeen := common.ParseDate(indicator.ValidFrom)
+	lastSeen := common.ParseDate(indicator.ValidUntil)
+	if sightingFirst, sightingLast := b.sightingTimes(indicator.ID); sightingFirst != nil {
+		firstSeen, lastSeen = sightingFirst, sightingLast
+	}
+
+	var eventsList []*events.Event
+	for _, obs := range patternObservables(indicator.Pattern) {
+		if event := newEvent(parserName, obs, eventType(), firstSeen, lastSeen, references); event != nil {
+			event.Headers["stix_id"] = indicator.ID
+			if indicator.Name != "" {
+				event.AddEventDetailSimple("indicator", indicator.Name)
+			}
+			eventsList = append(eventsList, event)
+		}
+	}
+	return eventsList
+}
+
+// observedDataEvents creates one event per referenced or embedded observable
+func (b *bundleIndex) observedDataEvents(parserName string, observed *object) []*events.Event {
+	var observables []observable
+	for _, ref := range observed.ObjectRefs {
+		if sco := b.objects[ref]; sco != nil {
+			observables = append(observables, observable{kind: sco.Type, value: sco.Value})
+		}
+	}
+	for _, sco := range observed.Objects {
+		observables = append(observables, observable{kind: sco.Type, value: sco.Value})
+	}
+
+	eventType := typeForLabels(observed.Labels)
+	firstSeen := common.ParseDate(observed.FirstObserved)
+	lastSeen := common.ParseDate(observed.LastObserved)
+
+	var eventsList []*events.Event
+	for _, obs := range observables {
+		if event := newEvent(parserName, obs, eventType(), firstSeen, lastSeen, observed.ExternalReferences); event != nil {
+			event.Headers["stix_id"] = observed.ID
+			eventsList = append(eventsList, event)
+		}
+	}
+	return eventsList
+}
+
+// malwareEvents creates MalwareHosting events for the observables the malware
+// communicates with, i.e. its command and control infrastructure
+func (b *bundleIndex) malwareEvents(parserName string, malware *object) []*events.Event {
+	firstSeen := common.ParseDate(malware.FirstSeen)
+	lastSeen := common.ParseDate(malware.LastSeen)
+
+	var eventsList []*events.Event
+	for _, relationship := range b.relationships[malware.ID] {
+		target := b.objects[relationship.TargetRef]
+		if !c2Relationships[relationship.RelationshipType] || target == nil {
+			continue
+		}
+		obs := observable{kind: target.Type, value: target.Value}
+		if event := newEvent(parserName, obs, events.NewMalwareHosting(), firstSeen, lastSeen, malware.ExternalReferences); event != nil {
+			event.Headers["stix_id"] = malware.ID
+			event.AddEventDetailSimple("malware", malware.Name)
+			event.AddEventDetailSimple("relationship", relationship.RelationshipType)
+			eventsList = append(eventsList, event)
+		}
+	}
+	return eventsList
+}
+
+// sightingTimes returns the earliest first_seen and latest last_seen of the
+// sightings of an object
+func (b *bundleIndex) sightingTimes(id string) (*time.Time, *time.Time) {
+	var first, last *time.Time
+	for _, sighting := range b.sightings[id] {
+		if seen := common.ParseDate(sighting.FirstSeen); seen != nil && (first == nil || seen.Before(*first)) {
+			first = seen
+		}
+		if seen := common.ParseDate(sighting.LastSeen); seen != nil && (last == nil || seen.After(*last)) {
+			last = seen
+		}
+	}
+	return first, last
+}
+
+// patternObservables returns the address, URL and domain comparisons of a STIX pattern
+func patternObservables(pattern string) []observable {
+	var observables []observable
+	for _, match := range patternComparisonPattern.FindAllStringSubmatch(pattern, -1) {
+		observables = append(observables, observable{kind: match[1], value: patternUnescaper.Replace(match[2])})
+	}
+	return observables
+}
+
+// typeForLabels returns the event type of the first known label, defaulting
+// to MaliciousActivity
+func typeForLabels(labels []string) func() events.EventType {
+	for _, label := range labels {
+		if constructor, ok := indicatorTypes[strings.ToLower(label)]; ok {
+			return constructor
+		}
+	}
+	return func() events.EventType { return events.NewMaliciousActivity() }
+}
@@ -0,0 +1,207 @@
+package stix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/parsers/intelmq"
+)
+
+// flexString accepts JSON strings, numbers and booleans; MISP versions differ
+// in whether timestamps and flags are quoted
+type flexString string
+
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*f = ""
+	case string:
+		*f = flexString(v)
+	default:
+		*f = flexString(fmt.Sprint(v))
+	}
+	return nil
+}
+
+func (f flexString) bool() bool {
+	value, _ := strconv.ParseBool(string(f))
+	return value
+}
+
+type mispDocument struct {
+	Event    *mispEvent `json:"Event"`
+	Response []struct {
+		Event *mispEvent `json:"Event"`
+	} `json:"response"`
+}
+
+type mispEvent struct {
+	UUID      string          `json:"uuid"`
+	Info      string          `json:"info"`
+	Date      flexString      `json:"date"`
+	Attribute []mispAttribute `json:"Attribute"`
+	Object    []struct {
+		Name      string          `json:"name"`
+		Attribute []mispAttribute `json:"Attribute"`
+	} `json:"Object"`
+	Tag []mispTag `json:"Tag"`
+}
+
+type mispAttribute struct {
+	Type      string     `json:"type"`
+	Category  string     `json:"category"`
+	Value     string     `json:"value"`
+	ToIDs     flexString `json:"to_ids"`
+	Comment   string     `json:"comment"`
+	FirstSeen flexString `json:"first_seen"`
+	LastSeen  flexString `json:"last_seen"`
+	Timestamp flexString `json:"timestamp"`
+	Tag       []mispTag  `json:"Tag"`
+}
+
+type mispTag struct {
+	Name string `json:"name"`
+}
+
+// mispObservableTypes maps MISP attribute types to observable kinds; the
+// "|port" and "domain|ip" composites carry the observable first
+var mispObservableTypes = map[string]string{
+	"ip-src":      "ipv4-addr",
+	"ip-dst":      "ipv4-addr",
+	"ip-src|port": "ipv4-addr",
+	"ip-dst|port": "ipv4-addr",
+	"domain":      "domain-name",
+	"hostname":    "domain-name",
+	"domain|ip":   "domain-name",
+	"url":         "url",
+}
+
+// mispSkippedCategories describe victims or internal data, not the source of abuse
+var mispSkippedCategories = map[string]bool{"targeting data": true, "internal reference": true}
+
+// parseMISP maps the network attributes of MISP events to events
+func parseMISP(parserName string, data []byte) ([]*events.Event, error) {
+	var documents []mispDocument
+	if err := json.Unmarshal(data, &documents); err != nil {
+		var single mispDocument
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("failed to parse MISP event: %w", err)
+		}
+		documents = []mispDocument{single}
+	}
+
+	var mispEvents []*mispEvent
+	for _, document := range documents {
+		if document.Event != nil {
+			mispEvents = append(mispEvents, document.Event)
+		}
+		for _, item := range document.Response {
+			if item.Event != nil {
+				mispEvents = append(mispEvents, item.Event)
+			}
+		}
+	}
+
+	var eventsList []*events.Event
+	for _, mispEvent := range mispEvents {
+		eventsList = append(eventsList, mispEvent.events(parserName)...)
+	}
+	return eventsList, nil
+}
+
+// events creates one event per network attribute, including the attributes of objects
+func (m *mispEvent) events(parserName string) []*events.Event {
+	attributes := append([]mispAttribute{}, m.Attribute...)
+	for _, object := range m.Object {
+		attributes = append(attributes, object.Attribute...)
+	}
+
+	references := []externalReference{{ExternalID: m.UUID}}
+	for _, attribute := range attributes {
+		if attribute.Type == "link" {
+			references = append(references, externalReference{SourceName: attribute.Comment, Description: "link", URL: attribute.Value})
+		}
+	}
+
+	var eventsList []*events.Event
+	for _, attribute := range attributes {
+		kind, ok := mispObservableTypes[attribute.Type]
+		if !ok || mispSkippedCategories[strings.ToLower(attribute.Category)] {
+			continue
+		}
+
+		value, extra, _ := strings.Cut(attribute.Value, "|")
+		firstSeen := common.ParseDate(firstNonEmpty(string(attribute.FirstSeen), string(attribute.Timestamp), string(m.Date)))
+		lastSeen := common.ParseDate(string(attribute.LastSeen))
+
+		event := newEvent(parserName, observable{kind: kind, value: value}, m.eventType(attribute), firstSeen, lastSeen, references)
+		if event == nil {
+			continue
+		}
+		switch attribute.Type {
+		case "ip-src|port", "ip-dst|port":
+			event.Port, _ = strconv.Atoi(extra)
+		case "domain|ip":
+			event.IP = common.IsIP(extra)
+		}
+
+		event.Headers["misp_event"] = m.UUID
+		event.Headers["misp_category"] = attribute.Category
+		event.Headers["misp_type"] = attribute.Type
+		if m.Info != "" {
+			event.AddEventDetailSimple("misp_info", m.Info)
+		}
+		if attribute.Comment != "" {
+			event.AddEventDetailSimple("comment", attribute.Comment)
+		}
+		event.AddEventDetailSimple("to_ids", attribute.ToIDs.bool())
+		eventsList = append(eventsList, event)
+	}
+	return eventsList
+}
+
+// eventType maps an attribute to an event type. RSIT tags (rsit:fraud="phishing")
+// classify like IntelMQ exports; otherwise the category decides, with a
+// malware galaxy tag naming the malware.
+func (m *mispEvent) eventType(attribute mispAttribute) events.EventType {
+	tags := append(append([]mispTag{}, attribute.Tag...), m.Tag...)
+	malware := ""
+	for _, tag := range tags {
+		namespace, value, _ := strings.Cut(tag.Name, "=")
+		value = strings.Trim(value, `"`)
+		switch {
+		case strings.HasPrefix(namespace, "rsit:"):
+			record := intelmq.Record{"classification.taxonomy": strings.TrimPrefix(namespace, "rsit:"), "classification.type": value}
+			if eventType := intelmq.EventType(record); eventType != nil {
+				return eventType
+			}
+		case malware == "" && (namespace == "misp-galaxy:malpedia" || namespace == "misp-galaxy:ransomware" || namespace == "misp-galaxy:botnet"):
+			malware = value
+		}
+	}
+
+	switch strings.ToLower(attribute.Category) {
+	case "payload delivery":
+		return events.NewMalwareHosting()
+	case "payload installation", "artifacts dropped", "persistence mechanism":
+		return events.NewMalware(malware)
+	case "financial fraud":
+		return events.NewFraud()
+	case "network activity":
+		if malware != "" && attribute.Type != "ip-src" && attribute.Type != "ip-src|port" {
+			return events.NewMalwareHosting()
+		}
+	}
+	if malware != "" {
+		return events.NewMalware(malware)
+	}
+	return events.NewMaliciousActivity()
+}
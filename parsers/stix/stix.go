@@ -0,0 +1,229 @@
+// Package stix parses threat-intel attachments in STIX 2.x bundle or MISP
+// event JSON format, as sent by threat-intel providers instead of prose
+package stix
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// Provider attributes the events of a threat-intel sender to its parser name
+type Provider struct {
+	// Parser is the parser name set on the events
+	Parser string
+	// Senders are From addresses or "@domain" suffixes
+	Senders []string
+}
+
+// Providers are the known senders of STIX and MISP attachments; documents
+// from other senders are attributed to the "stix" or "misp" parser
+var Providers = []Provider{
+	{Parser: "group_ib", Senders: []string{"@group-ib.com", "@group-ib.ru"}},
+	{Parser: "crowdstrike", Senders: []string{"@crowdstrike.com"}},
+	{Parser: "intsights", Senders: []string{"@intsights.com"}},
+	{Parser: "cyble", Senders: []string{"@cyble.com", "@cyble.io"}},
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse finds a STIX bundle or MISP event in the attachments or the body
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	from, _ := common.GetFrom(serializedEmail, false)
+	provider := providerForSender(from)
+
+	for _, data := range candidates(serializedEmail) {
+		var eventsList []*events.Event
+		var err error
+		switch detectFormat(data) {
+		case "stix":
+			eventsList, err = parseBundle(firstNonEmpty(provider, "stix"), data)
+		case "misp":
+			eventsList, err = parseMISP(firstNonEmpty(provider, "misp"), data)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(eventsList) == 0 {
+			return nil, common.NewParserError("threat-intel document contains no network observables")
+		}
+		return eventsList, nil
+	}
+	return nil, common.NewParserError("no STIX bundle or MISP event found")
+}
+
+// providerForSender returns the parser name of a known provider
+func providerForSender(from string) string {
+	from = strings.ToLower(from)
+	for _, provider := range Providers {
+		for _, sender := range provider.Senders {
+			if from == sender || (strings.HasPrefix(sender, "@") && strings.HasSuffix(from, sender)) {
+				return provider.Parser
+			}
+		}
+	}
+	return ""
+}
+
+// candidates returns the JSON-looking attachments and the body
+func candidates(serializedEmail *email.SerializedEmail) [][]byte {
+	var result [][]byte
+	var walk func(parts []email.EmailPart)
+	walk = func(parts []email.EmailPart) {
+		for _, part := range parts {
+			if data := partData(part.Body); data != nil {
+				result = append(result, data)
+			}
+			walk(part.Parts)
+		}
+	}
+	walk(serializedEmail.Parts)
+	if data := partData(serializedEmail.Body); data != nil {
+		result = append(result, data)
+	}
+	return result
+}
+
+// partData returns a body that is a JSON document, decoding base64 attachments
+func partData(body interface{}) []byte {
+	var data []byte
+	switch b := body.(type) {
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		return nil
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' && data[0] != '[' {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(data), nil)))
+		if err != nil {
+			return nil
+		}
+		data = bytes.TrimSpace(decoded)
+	}
+	if len(data) == 0 || (data[0] != '{' && data[0] != '[') {
+		return nil
+	}
+	return data
+}
+
+// detectFormat returns "stix" for STIX bundles, "misp" for MISP events
+// (single, REST search response or list) and "" otherwise
+func detectFormat(data []byte) string {
+	var probe interface{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return ""
+	}
+	if list, ok := probe.([]interface{}); ok && len(list) > 0 {
+		probe = list[0]
+	}
+	object, ok := probe.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if object["type"] == "bundle" {
+		return "stix"
+	}
+	if _, ok := object["Event"]; ok {
+		return "misp"
+	}
+	if _, ok := object["response"]; ok {
+		return "misp"
+	}
+	return ""
+}
+
+// observable is a network observable of a threat-intel document
+type observable struct {
+	kind  string
+	value string
+}
+
+// apply sets the observable on the event; false means it is not an
+// address, URL or domain (CIDR ranges, hashes, email addresses)
+func (o observable) apply(event *events.Event) bool {
+	switch o.kind {
+	case "ipv4-addr", "ipv6-addr":
+		event.IP = common.IsIP(strings.TrimSuffix(strings.TrimSuffix(o.value, "/32"), "/128"))
+		return event.IP != ""
+	case "url":
+		event.URL = o.value
+		return event.URL != ""
+	case "domain-name":
+		event.Domain = strings.ToLower(strings.TrimSuffix(o.value, "."))
+		return event.Domain != ""
+	}
+	return false
+}
+
+// externalReference is a reference to a report or database entry
+type externalReference struct {
+	SourceName  string `json:"source_name"`
+	URL         string `json:"url"`
+	ExternalID  string `json:"external_id"`
+	Description string `json:"description"`
+}
+
+// newEvent creates the event of an observable with its seen times and references
+func newEvent(parserName string, obs observable, eventType events.EventType, firstSeen, lastSeen *time.Time, references []externalReference) *events.Event {
+	event := events.NewEvent(parserName)
+	if !obs.apply(event) {
+		return nil
+	}
+	event.EventTypes = []events.EventType{eventType}
+	event.EventDate = firstSeen
+
+	if firstSeen != nil {
+		event.AddEventDetailSimple("first_seen", firstSeen.Format(time.RFC3339))
+	}
+	if lastSeen != nil {
+		event.AddEventDetailSimple("last_seen", lastSeen.Format(time.RFC3339))
+	}
+
+	evidence := &events.Evidence{}
+	for _, reference := range references {
+		if reference.ExternalID != "" {
+			event.AddEventDetail(&events.ExternalID{ID: reference.ExternalID})
+		}
+		if reference.URL != "" {
+			evidence.AddEvidence(events.UrlStore{
+				Description: firstNonEmpty(reference.SourceName, reference.Description),
+				URL:         reference.URL,
+			})
+		}
+	}
+	if len(evidence.URLs) > 0 {
+		event.AddEventDetail(evidence)
+	}
+	return event
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityFormat
+}
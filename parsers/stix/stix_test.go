@@ -0,0 +1,143 @@
+package stix
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+const bundleJSON = `{
+  "type": "bundle",
+  "id": "bundle--1",
+  "objects": [
+    {
+      "type": "indicator", "spec_version": "2.1", "id": "indicator--1",
+      "name": "Emotet C2", "pattern_type": "stix",
+      "pattern": "[ipv4-addr:value = '198.51.100.10'] OR [url:value = 'http://evil.example/it\\'s']",
+      "indicator_types": ["malicious-activity"],
+      "valid_from": "2025-10-01T00:00:00Z",
+      "external_references": [{"source_name": "provider", "external_id": "IOC-42", "url": "https://intel.example/ioc/42"}]
+    },
+    {"type": "malware", "id": "malware--1", "name": "Emotet", "is_family": true, "first_seen": "2025-09-01T00:00:00Z", "last_seen": "2025-10-10T00:00:00Z"},
+    {"type": "relationship", "id": "relationship--1", "relationship_type": "indicates", "source_ref": "indicator--1", "target_ref": "malware--1"},
+    {"type": "relationship", "id": "relationship--2", "relationship_type": "communicates-with", "source_ref": "malware--1", "target_ref": "domain-name--1"},
+    {"type": "domain-name", "id": "domain-name--1", "value": "c2.example"},
+    {"type": "sighting", "id": "sighting--1", "sighting_of_ref": "indicator--1", "first_seen": "2025-10-05T00:00:00Z", "last_seen": "2025-10-06T00:00:00Z"},
+    {"type": "indicator", "id": "indicator--2", "pattern": "[ipv4-addr:value = '192.0.2.1']", "indicator_types": ["benign"], "valid_from": "2025-10-01T00:00:00Z"},
+    {"type": "ipv6-addr", "id": "ipv6-addr--1", "value": "2001:db8::1"},
+    {"type": "observed-data", "id": "observed-data--1", "first_observed": "2025-10-07T00:00:00Z", "last_observed": "2025-10-08T00:00:00Z", "number_observed": 3, "object_refs": ["ipv6-addr--1"]}
+  ]
+}`
+
+func TestParser_STIXBundle(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"Intel <intel@crowdstrike.com>"}},
+		Body:    "Please see the attached bundle.",
+		Parts: []email.EmailPart{
+			{ContentType: "application/json", Body: base64.StdEncoding.EncodeToString([]byte(bundleJSON))},
+		},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(result))
+	}
+
+	ip := result[0]
+	if ip.Parser != "crowdstrike" || ip.IP != "198.51.100.10" {
+		t.Errorf("Unexpected indicator event: %+v", ip)
+	}
+	if malware, ok := ip.EventTypes[0].(*events.Malware); !ok || malware.Infection != "Emotet" {
+		t.Errorf("Expected Emotet malware, got %+v", ip.EventTypes[0])
+	}
+	if ip.EventDate == nil || ip.EventDate.Day() != 5 {
+		t.Errorf("Expected sighting first_seen as event date, got %v", ip.EventDate)
+	}
+	var externalID string
+	var evidence *events.Evidence
+	for _, detail := range ip.EventDetails {
+		switch d := detail.(type) {
+		case *events.ExternalID:
+			externalID = d.ID
+		case *events.Evidence:
+			evidence = d
+		}
+	}
+	if externalID != "IOC-42" || evidence == nil || evidence.URLs[0].URL != "https://intel.example/ioc/42" {
+		t.Errorf("Expected external reference, got %q / %+v", externalID, evidence)
+	}
+
+	if result[1].URL != "http://evil.example/it's" {
+		t.Errorf("Expected unescaped URL, got %q", result[1].URL)
+	}
+	if result[2].Domain != "c2.example" || result[2].EventTypes[0].GetName() != "malware_hosting" {
+		t.Errorf("Expected C2 domain as malware hosting, got %+v", result[2])
+	}
+	if result[3].IP != "2001:db8::1" || result[3].EventTypes[0].GetName() != "malicious_activity" {
+		t.Errorf("Expected observed IPv6 address, got %+v", result[3])
+	}
+}
+
+func TestParser_MISPEvent(t *testing.T) {
+	mispJSON := `{"response": [{"Event": {
+  "uuid": "5f0b1c2d-0000-4000-8000-000000000001",
+  "info": "Phishing campaign",
+  "date": "2025-10-01",
+  "Tag": [{"name": "tlp:amber"}],
+  "Attribute": [
+    {"type": "url", "category": "Network activity", "value": "http://phish.example/login", "to_ids": true, "timestamp": "1760000000",
+     "Tag": [{"name": "rsit:fraud=\"phishing\""}]},
+    {"type": "ip-dst|port", "category": "Network activity", "value": "198.51.100.20|8080", "to_ids": true, "first_seen": "2025-10-02T10:00:00Z"},
+    {"type": "ip-src", "category": "Targeting data", "value": "192.0.2.99", "to_ids": false},
+    {"type": "sha256", "category": "Payload delivery", "value": "abcd", "to_ids": true},
+    {"type": "link", "category": "External analysis", "value": "https://blog.example/analysis", "comment": "write-up"}
+  ],
+  "Object": [{"name": "domain-ip", "Attribute": [
+    {"type": "domain", "category": "Payload delivery", "value": "dropper.example", "to_ids": "1"}
+  ]}]
+}}]}`
+
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{"from": {"feeds@misp.example"}},
+		Body:    mispJSON,
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(result))
+	}
+
+	if result[0].Parser != "misp" || result[0].URL != "http://phish.example/login" || result[0].EventTypes[0].GetName() != "phishing" {
+		t.Errorf("Unexpected phishing event: %+v", result[0])
+	}
+	if result[0].EventDate == nil || result[0].EventDate.Unix() != 1760000000 {
+		t.Errorf("Expected timestamp as event date, got %v", result[0].EventDate)
+	}
+	if result[1].IP != "198.51.100.20" || result[1].Port != 8080 || result[1].EventTypes[0].GetName() != "malicious_activity" {
+		t.Errorf("Unexpected ip-dst|port event: %+v", result[1])
+	}
+	if result[2].Domain != "dropper.example" || result[2].EventTypes[0].GetName() != "malware_hosting" {
+		t.Errorf("Unexpected payload delivery event: %+v", result[2])
+	}
+	if result[2].Headers["misp_event"] != "5f0b1c2d-0000-4000-8000-000000000001" {
+		t.Errorf("Expected MISP event header, got %v", result[2].Headers)
+	}
+}
+
+func TestParser_NoThreatIntelDocument(t *testing.T) {
+	_, err := NewParser().Parse(&email.SerializedEmail{
+		Headers: map[string][]string{"from": {"someone@example.com"}},
+		Body:    `{"type": "report", "ip": "192.0.2.1"}`,
+	})
+	if err == nil {
+		t.Error("Expected error for JSON without STIX bundle or MISP event")
+	}
+}
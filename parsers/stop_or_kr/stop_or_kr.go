@@ -19,6 +19,9 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
+// beforeHTTPPattern matches the text in front of each "http"
+var beforeHTTPPattern = regexp.MustCompile(`(.*?)(http)`)
+
 // getURLs extracts URLs from HTML body, excluding reporter URLs
 func getURLs(doc *goquery.Document) []string {
 	// Reporter URLs to exclude
@@ -38,9 +41,12 @@ func getURLs(doc *goquery.Document) []string {
 		lines := strings.Split(text, "\n")
 
 		for _, line := range lines {
+			// Lines without "http" cannot hold a URL
+			if !strings.Contains(line, "http") {
+				continue
+			}
 			// Remove everything before "http"
-			re := regexp.MustCompile(`(.*?)(http)`)
-			line = re.ReplaceAllString(line, "")
+			line = beforeHTTPPattern.ReplaceAllString(line, "")
 			line = strings.TrimSpace(line)
 
 			// Check if line starts with http
@@ -27,6 +27,10 @@ var (
 )
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "storage-base.de") {
+		return nil, common.NewParserError("not from storage-base.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "studiobarbero.com") {
+		return nil, common.NewParserError("not from studiobarbero.com")
+	}
+
 	// Get body and subject (both required)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
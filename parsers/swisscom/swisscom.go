@@ -23,6 +23,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "swisscom.com") {
+		return nil, common.NewParserError("not from swisscom.com")
+	}
+
 	var eventList []*events.Event
 
 	// Try to get body from main body or first part
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ipxo.com") {
+		return nil, common.NewParserError("not from ipxo.com")
+	}
+
 	// Get subject - throws error if not found (throws=True in Python)
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
@@ -223,6 +223,10 @@ func parsePhishing(body string, serializedEmail *email.SerializedEmail) ([]*even
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "takedownreporting.com", "akamai.com") {
+		return nil, common.NewParserError("not from takedownreporting.com or akamai.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
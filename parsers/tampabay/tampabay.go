@@ -26,6 +26,10 @@ func NewParser() *Parser {
 
 // Parse parses emails from lindroth@tampabay.rr.com
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "rr.com") {
+		return nil, common.NewParserError("not from rr.com")
+	}
+
 	// Get subject and body
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
@@ -16,6 +16,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "techspace.cc") {
+		return nil, common.NewParserError("not from techspace.cc")
+	}
+
 	// Get email body (throws=True in Python)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -38,7 +38,8 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// Handle forwarded emails
 	if strings.Contains(subjectLower, "fw:") && strings.Contains(body, "-----Original Message-----") {
-		if err := rewriteForwardedEmail(serializedEmail, body); err != nil {
+		serializedEmail, err = rewriteForwardedEmail(serializedEmail, body)
+		if err != nil {
 			return nil, err
 		}
 		// Re-get body after rewrite
@@ -59,8 +60,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	return nil, common.NewNewTypeError(subject)
 }
 
-// rewriteForwardedEmail extracts the original message from a forwarded email
-func rewriteForwardedEmail(serializedEmail *email.SerializedEmail, body string) error {
+// rewriteForwardedEmail returns a copy of a forwarded email that carries the
+// original message's headers and body
+func rewriteForwardedEmail(serializedEmail *email.SerializedEmail, body string) (*email.SerializedEmail, error) {
 	marker := "-----Original Message-----"
 
 	// Add newline after marker for consistent parsing
@@ -69,7 +71,7 @@ func rewriteForwardedEmail(serializedEmail *email.SerializedEmail, body string)
 	// Extract header lines after the marker
 	headerLines := common.GetBlockAfterWithStop(body, marker, "")
 	if len(headerLines) == 0 {
-		return common.NewParserError("no header lines found in forwarded message")
+		return nil, common.NewParserError("no header lines found in forwarded message")
 	}
 
 	// Find where the headers end (last header line)
@@ -78,17 +80,13 @@ func rewriteForwardedEmail(serializedEmail *email.SerializedEmail, body string)
 	// Split body to get the actual message content
 	parts := strings.SplitN(body, lastHeaderLine, 2)
 	if len(parts) != 2 {
-		return common.NewParserError("could not split forwarded message")
+		return nil, common.NewParserError("could not split forwarded message")
 	}
 
-	// Build new headers from the forwarded message
-	newHeaders := buildHeadersFromLines(headerLines, serializedEmail)
-
-	// Update the serialized email
-	serializedEmail.Body = parts[1]
-	serializedEmail.Headers = newHeaders
-
-	return nil
+	rewritten := *serializedEmail
+	rewritten.Body = parts[1]
+	rewritten.Headers = buildHeadersFromLines(headerLines, serializedEmail)
+	return &rewritten, nil
 }
 
 // buildHeadersFromLines parses header lines and creates a header map
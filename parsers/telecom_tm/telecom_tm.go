@@ -16,6 +16,10 @@ type Parser struct{}
 // Parse parses emails from @telecom.tm reporting DDoS attacks
 // This parser extracts DDoS attack information including ISP, IP, target port, and attack date
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "acedatacenter.com", "telecom.tm") {
+		return nil, common.NewParserError("not from acedatacenter.com or telecom.tm")
+	}
+
 	// Get email body
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
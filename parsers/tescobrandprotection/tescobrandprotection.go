@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "tescobrandprotection.com") {
+		return nil, common.NewParserError("not from tescobrandprotection.com")
+	}
+
 	// Get body and subject (throws error if not available)
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
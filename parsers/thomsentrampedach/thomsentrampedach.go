@@ -24,6 +24,10 @@ func NewParser() *Parser {
 // 2. "phishing email" in subject - reports phishing IPs extracted from body
 // 3. "trademark" in subject - reports trademark infringement URLs
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "thomsentrampedach.legal") {
+		return nil, common.NewParserError("not from thomsentrampedach.legal")
+	}
+
 	// Get body and subject with error handling
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -22,6 +22,10 @@ type Parser struct{}
 
 // Parse parses copyright infringement emails from 3antsds
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "3antsds.com") {
+		return nil, common.NewParserError("not from 3antsds.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,270 @@
+// Package tlsrpt parses SMTP TLS reports (RFC 8460), the aggregate reports
+// sending MTAs deliver for failed STARTTLS and MTA-STS/DANE policy checks
+package tlsrpt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// Parser handles TLS-RPT aggregate reports
+type Parser struct{}
+
+// NewParser creates a new TLS-RPT parser
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Report is the JSON report format of RFC 8460 section 4
+type Report struct {
+	OrganizationName string         `json:"organization-name"`
+	DateRange        DateRange      `json:"date-range"`
+	ContactInfo      string         `json:"contact-info"`
+	ReportID         string         `json:"report-id"`
+	Policies         []PolicyResult `json:"policies"`
+}
+
+type DateRange struct {
+	StartDatetime string `json:"start-datetime"`
+	EndDatetime   string `json:"end-datetime"`
+}
+
+type PolicyResult struct {
+	Policy         Policy          `json:"policy"`
+	Summary        Summary         `json:"summary"`
+	FailureDetails []FailureDetail `json:"failure-details"`
+}
+
+type Policy struct {
+	PolicyType   string   `json:"policy-type"`
+	PolicyString []string `json:"policy-string"`
+	PolicyDomain string   `json:"policy-domain"`
+	MXHost       []string `json:"mx-host"`
+}
+
+type Summary struct {
+	TotalSuccessfulSessionCount int `json:"total-successful-session-count"`
+	TotalFailureSessionCount    int `json:"total-failure-session-count"`
+}
+
+type FailureDetail struct {
+	// ResultType is e.g. starttls-not-supported, certificate-expired or sts-policy-fetch-error
+	ResultType            string `json:"result-type"`
+	SendingMTAIP          string `json:"sending-mta-ip"`
+	ReceivingMXHostname   string `json:"receiving-mx-hostname"`
+	ReceivingMXHelo       string `json:"receiving-mx-helo"`
+	ReceivingIP           string `json:"receiving-ip"`
+	FailedSessionCount    int    `json:"failed-session-count"`
+	AdditionalInformation string `json:"additional-information"`
+	FailureReasonCode     string `json:"failure-reason-code"`
+}
+
+// Media types of RFC 8460 section 5.3; gzip attachments are also sent as plain application/gzip
+var reportContentTypes = []string{"application/tlsrpt+gzip", "application/tlsrpt+json", "application/gzip", "application/x-gzip", "application/json"}
+
+// Parse implements the Parser interface
+func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	body, ok := findReport(serializedEmail)
+	if !ok {
+		return nil, common.NewParserError("not a TLS-RPT report")
+	}
+
+	data, err := decodeReport(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse TLS-RPT JSON: %w", err)
+	}
+	if len(report.Policies) == 0 {
+		return nil, common.NewParserError("TLS-RPT report without policies")
+	}
+
+	eventsList := reportEvents(&report)
+	if len(eventsList) == 0 {
+		return nil, common.NewIgnoreError("TLS-RPT report without failures")
+	}
+	return eventsList, nil
+}
+
+// findReport returns the report attachment. Generic gzip and JSON
+// attachments only count when the TLS-Report-Domain header (RFC 8460
+// section 5.3) marks the message as TLS report.
+func findReport(serializedEmail *email.SerializedEmail) (interface{}, bool) {
+	tlsReport := len(serializedEmail.Headers["tls-report-domain"]) > 0
+	accept := func(contentType string) bool {
+		mediaType := mediaType(contentType)
+		if strings.HasPrefix(mediaType, "application/tlsrpt+") {
+			return true
+		}
+		if !tlsReport {
+			return false
+		}
+		for _, reportType := range reportContentTypes {
+			if mediaType == reportType {
+				return true
+			}
+		}
+		return false
+	}
+
+	if contentTypes := serializedEmail.Headers["content-type"]; len(contentTypes) > 0 && accept(contentTypes[0]) {
+		return serializedEmail.Body, true
+	}
+	return findPart(serializedEmail.Parts, accept)
+}
+
+func findPart(parts []email.EmailPart, accept func(string) bool) (interface{}, bool) {
+	for _, part := range parts {
+		contentType := part.ContentType
+		if values := part.Headers["content-type"]; len(values) > 0 {
+			contentType = values[0]
+		}
+		if accept(contentType) {
+			return part.Body, true
+		}
+		if body, ok := findPart(part.Parts, accept); ok {
+			return body, true
+		}
+	}
+	return nil, false
+}
+
+func mediaType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// decodeReport returns the report JSON of a gzip or plain JSON attachment.
+// Bodies serialized by the Python pipeline are still base64 encoded.
+func decodeReport(body interface{}) ([]byte, error) {
+	var data []byte
+	switch b := body.(type) {
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		return nil, common.NewParserError("invalid body type for TLS-RPT report")
+	}
+
+	if !isGzip(data) && !isJSON(data) {
+		if decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(data)), "")); err == nil {
+			data = decoded
+		}
+	}
+	if !isGzip(data) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip: %w", err)
+	}
+	defer reader.Close()
+
+	content, err := common.ReadDecoded(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip content: %w", err)
+	}
+	return content, nil
+}
+
+func isGzip(data []byte) bool {
+	return len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// reportEvents creates one event per failure detail. Policies that report
+// failed sessions without details get one event for the policy domain.
+func reportEvents(report *Report) []*events.Event {
+	dateBegin := common.ParseDate(report.DateRange.StartDatetime)
+	dateEnd := common.ParseDate(report.DateRange.EndDatetime)
+
+	var eventsList []*events.Event
+	for _, result := range report.Policies {
+		details := result.FailureDetails
+		if len(details) == 0 {
+			if result.Summary.TotalFailureSessionCount == 0 {
+				continue
+			}
+			details = []FailureDetail{{FailedSessionCount: result.Summary.TotalFailureSessionCount}}
+		}
+
+		for _, detail := range details {
+			event := events.NewEvent("tlsrpt")
+			event.IP = common.IsIP(detail.ReceivingIP)
+			event.Domain = result.Policy.PolicyDomain
+			event.ReportID = report.ReportID
+			event.EventDate = dateBegin
+			event.EventTypes = []events.EventType{events.NewAuthFailure()}
+
+			if dateEnd != nil {
+				evidence := &events.Evidence{}
+				evidence.AddEvidence(events.UrlStore{
+					Description: "date_end",
+					URL:         dateEnd.Format("2006-01-02 15:04:05"),
+				})
+				event.AddEventDetail(evidence)
+			}
+
+			if detail.ResultType != "" {
+				event.AddEventDetailSimple("result_type", detail.ResultType)
+			}
+			event.AddEventDetailSimple("policy_type", result.Policy.PolicyType)
+			if len(result.Policy.MXHost) > 0 {
+				event.AddEventDetailSimple("mx_host", result.Policy.MXHost)
+			}
+			if ip := common.IsIP(detail.SendingMTAIP); ip != "" {
+				event.AddEventDetailSimple("sending_mta_ip", ip)
+			}
+			if detail.ReceivingMXHostname != "" {
+				event.AddEventDetailSimple("receiving_mx_hostname", detail.ReceivingMXHostname)
+			}
+			if detail.FailureReasonCode != "" {
+				event.AddEventDetailSimple("failure_reason_code", detail.FailureReasonCode)
+			}
+			if detail.AdditionalInformation != "" {
+				event.AddEventDetailSimple("additional_information", detail.AdditionalInformation)
+			}
+
+			// TLS-RPT counts sessions, not packets
+			event.AddEventDetail(&events.TrafficStats{
+				PacketCount: detail.FailedSessionCount,
+			})
+
+			event.AddEventDetail(&events.Organisation{
+				Name:         "reporter",
+				Organisation: report.OrganizationName,
+				ContactEmail: strings.TrimPrefix(report.ContactInfo, "mailto:"),
+				URLOrDomain:  result.Policy.PolicyDomain,
+			})
+
+			eventsList = append(eventsList, event)
+		}
+	}
+	return eventsList
+}
+
+// GetPriority returns the parser priority (lower numbers run first)
+func (p *Parser) GetPriority() int {
+	return base.PriorityFormat
+}
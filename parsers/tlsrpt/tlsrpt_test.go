@@ -0,0 +1,150 @@
+package tlsrpt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/common"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+const reportJSON = `{
+  "organization-name": "Company-X",
+  "date-range": {
+    "start-datetime": "2025-10-17T00:00:00Z",
+    "end-datetime": "2025-10-17T23:59:59Z"
+  },
+  "contact-info": "sts-reporting@company-x.example",
+  "report-id": "5065427c-23d3-47ca-b6e0-946ea0e8c4be",
+  "policies": [{
+    "policy": {
+      "policy-type": "sts",
+      "policy-string": ["version: STSv1", "mode: testing", "mx: *.mail.company-y.example", "max_age: 86400"],
+      "policy-domain": "company-y.example",
+      "mx-host": ["*.mail.company-y.example"]
+    },
+    "summary": {
+      "total-successful-session-count": 5326,
+      "total-failure-session-count": 303
+    },
+    "failure-details": [{
+      "result-type": "certificate-expired",
+      "sending-mta-ip": "2001:db8:abcd:0012::1",
+      "receiving-mx-hostname": "mx1.mail.company-y.example",
+      "receiving-ip": "203.0.113.56",
+      "failed-session-count": 100
+    }, {
+      "result-type": "starttls-not-supported",
+      "sending-mta-ip": "2001:db8:abcd:0013::1",
+      "receiving-mx-hostname": "mx2.mail.company-y.example",
+      "receiving-ip": "203.0.113.57",
+      "additional-information": "https://reports.company-x.example/report_info?id=5065427c-23d3#StarttlsNotSupported",
+      "failed-session-count": 200
+    }, {
+      "result-type": "validation-failure",
+      "sending-mta-ip": "198.51.100.62",
+      "receiving-ip": "203.0.113.58",
+      "receiving-mx-hostname": "mx3.mail.company-y.example",
+      "failed-session-count": 3,
+      "failure-reason-code": "X509_V_ERR_PROXY_PATH_LENGTH_EXCEEDED"
+    }]
+  }, {
+    "policy": {"policy-type": "no-policy-found", "policy-domain": "company-z.example"},
+    "summary": {"total-successful-session-count": 10, "total-failure-session-count": 0}
+  }]
+}`
+
+func gzipped(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(data)); err != nil {
+		t.Fatalf("gzip failed: %v", err)
+	}
+	writer.Close()
+	return buf.Bytes()
+}
+
+func TestParser_GzipAttachment(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"subject":      {"Report Domain: company-y.example Submitter: company-x.example Report-ID: <5065427c-23d3>"},
+			"content-type": {`multipart/report; report-type="tlsrpt"; boundary="b"`},
+		},
+		Parts: []email.EmailPart{
+			{ContentType: "text/plain", Body: "This is an aggregate TLS report from company-x.example"},
+			{ContentType: "application/tlsrpt+gzip", Body: gzipped(t, reportJSON)},
+		},
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(result))
+	}
+
+	event := result[0]
+	if event.IP != "203.0.113.56" || event.Domain != "company-y.example" || event.ReportID != "5065427c-23d3-47ca-b6e0-946ea0e8c4be" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if event.EventDate == nil || event.EventDate.Day() != 17 {
+		t.Errorf("Expected start-datetime as event date, got %v", event.EventDate)
+	}
+
+	details := make(map[string]interface{})
+	var stats *events.TrafficStats
+	var reporter *events.Organisation
+	for _, detail := range event.EventDetails {
+		switch d := detail.(type) {
+		case *events.SimpleDetail:
+			details[d.Key] = d.Value
+		case *events.TrafficStats:
+			stats = d
+		case *events.Organisation:
+			reporter = d
+		}
+	}
+	if details["result_type"] != "certificate-expired" || details["sending_mta_ip"] != "2001:db8:abcd:0012::1" {
+		t.Errorf("Unexpected details: %v", details)
+	}
+	if stats == nil || stats.PacketCount != 100 {
+		t.Errorf("Expected 100 failed sessions, got %+v", stats)
+	}
+	if reporter == nil || reporter.Organisation != "Company-X" || reporter.ContactEmail != "sts-reporting@company-x.example" {
+		t.Errorf("Unexpected reporter: %+v", reporter)
+	}
+}
+
+func TestParser_Base64GzipBody(t *testing.T) {
+	serializedEmail := &email.SerializedEmail{
+		Headers: map[string][]string{
+			"content-type":      {"application/gzip"},
+			"tls-report-domain": {"company-y.example"},
+		},
+		Body: base64.StdEncoding.EncodeToString(gzipped(t, reportJSON)),
+	}
+
+	result, err := NewParser().Parse(serializedEmail)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result) != 3 || result[2].IP != "203.0.113.58" {
+		t.Fatalf("Unexpected events: %+v", result)
+	}
+}
+
+func TestParser_NotATLSReport(t *testing.T) {
+	_, err := NewParser().Parse(&email.SerializedEmail{
+		Headers: map[string][]string{"content-type": {"application/gzip"}},
+		Body:    gzipped(t, reportJSON),
+	})
+	var parserErr *common.ParserError
+	if !errors.As(err, &parserErr) {
+		t.Errorf("Expected ParserError for gzip attachment without TLS-Report-Domain, got %v", err)
+	}
+}
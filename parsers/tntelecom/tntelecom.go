@@ -81,6 +81,10 @@ func parseDate(attackDateStr string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "tntelecom.net") {
+		return nil, common.NewParserError("not from tntelecom.net")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
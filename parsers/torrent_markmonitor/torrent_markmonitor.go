@@ -168,6 +168,24 @@ func parseBrokenXML(serializedEmail *email.SerializedEmail) ([]*events.Event, er
 	return evts, nil
 }
 
+// parseACNS handles notices that embed ACNS XML instead of an InvestigationInfo document
+func parseACNS(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	notices, err := common.FindACNSNotices(serializedEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	var evts []*events.Event
+	for _, notice := range notices {
+		noticeEvents, err := notice.Events("torrent_markmonitor")
+		if err != nil {
+			return nil, err
+		}
+		evts = append(evts, noticeEvents...)
+	}
+	return evts, nil
+}
+
 // wrapXMLListOrNoList ensures a value is a slice
 func wrapXMLListOrNoList(val interface{}) []interface{} {
 	switch v := val.(type) {
@@ -212,9 +230,9 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 
 	// Check if this is an ACNS XML format (delegate to acns parser)
 	if strings.Contains(body, "Start ACNS XML") {
-		// Note: In the Python version, this calls acns_parse
-		// For now, we'll fall through to parseBrokenXML on error
-		// In production, you would import and call the acns parser here
+		if evts, err := parseACNS(serializedEmail); err == nil {
+			return evts, nil
+		}
 		return parseBrokenXML(serializedEmail)
 	}
 
@@ -15,6 +15,10 @@ type Parser struct{}
 // Parse parses emails from @ttp-law.com reporting illegal advertisements
 // The parser extracts URLs from Japanese or English format emails
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ttp-law.com") {
+		return nil, common.NewParserError("not from ttp-law.com")
+	}
+
 	// Get email body
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -47,6 +47,10 @@ func parseEventDate(body string) string {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "stuttgart.de") {
+		return nil, common.NewParserError("not from stuttgart.de")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
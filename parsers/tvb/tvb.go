@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "tvb.com.hk") {
+		return nil, common.NewParserError("not from tvb.com.hk")
+	}
+
 	// Get email body and subject
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil || body == "" {
@@ -18,6 +18,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "ucs.br") {
+		return nil, common.NewParserError("not from ucs.br")
+	}
+
 	// Extract body from parts[1]
 	if len(serializedEmail.Parts) < 2 {
 		return nil, fmt.Errorf("body not found - expected at least 2 parts")
@@ -15,6 +15,10 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "uni-koblenz.de") {
+		return nil, common.NewParserError("not from uni-koblenz.de")
+	}
+
 	// Get body and subject with error checking
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
@@ -28,6 +28,10 @@ func Match(fromAddr string) bool {
 
 // Parse parses US-CERT abuse reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "us-cert.gov") {
+		return nil, common.NewParserError("not from us-cert.gov")
+	}
+
 	// Check for CSV attachment first (Shadowserver data)
 	if csvPart, err := common.FindFirstAttachmentWithMimeType(serializedEmail, "csv"); err == nil {
 		return parseShadowserverCSV(serializedEmail, csvPart)
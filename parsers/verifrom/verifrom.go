@@ -15,9 +15,10 @@ func NewParser() *Parser {
 }
 
 // Parse implements a rewrite parser for verifrom.com emails
-// This parser checks if the email is from @verifrom.com and contains "x-arf" in the body
-// If so, it adds a "x-xarf: plain" header to the email
-// This is a rewrite-only parser, so it returns an empty events list
+// The Python parser tagged verifrom.com emails that contain "x-arf" in the body
+// with an "x-xarf: plain" header so the xarf parser would pick them up. The xarf
+// parser already runs before any vendor parser, so this one only recognizes the
+// email and returns an empty events list
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
 	// Get the From address
 	fromAddr, err := common.GetFrom(serializedEmail, false)
@@ -42,12 +43,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, nil // Ignore if no x-arf in body
 	}
 
-	// Rewrite: Add x-xarf header with value "plain"
-	if serializedEmail.Headers == nil {
-		serializedEmail.Headers = make(map[string][]string)
-	}
-	serializedEmail.Headers["x-xarf"] = []string{"plain"}
-
 	// Return empty events list (this is a rewrite-only parser)
 	return nil, nil
 }
@@ -15,6 +15,10 @@ type Parser struct{}
 // Parse parses emails from newell@weightechinc.com
 // This is a 100% exact Go translation of the Python weightechinc parser
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "weightechinc.com") {
+		return nil, common.NewParserError("not from weightechinc.com")
+	}
+
 	body, err := common.GetBody(serializedEmail, false)
 	if err != nil {
 		return nil, err
@@ -36,6 +36,10 @@ func Match(serializedEmail *email.SerializedEmail, fromAddr string) bool {
 
 // Parse processes the winvoice abuse email
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	if !common.SentFrom(serializedEmail, "winvoice.com") {
+		return nil, common.NewParserError("not from winvoice.com")
+	}
+
 	subject, err := common.GetSubject(serializedEmail, true)
 	if err != nil {
 		return nil, err
@@ -55,6 +55,23 @@ func getXARFVersion(serializedEmail *email.SerializedEmail, contentType string)
 	return ""
 }
 
+// xarfJSONPart returns the xarf.json attachment of an email, or nil
+func xarfJSONPart(serializedEmail *email.SerializedEmail) *email.EmailPart {
+	for i := range serializedEmail.Parts {
+		part := &serializedEmail.Parts[i]
+		if ct, ok := part.Headers["content-type"]; ok && len(ct) > 0 {
+			ctLower := strings.ToLower(ct[0])
+			ctLower = strings.ReplaceAll(ctLower, `"`, "")
+			ctLower = strings.ReplaceAll(ctLower, `'`, "")
+
+			if strings.Contains(ctLower, "application/json") && strings.Contains(ctLower, "name=xarf.json") {
+				return part
+			}
+		}
+	}
+	return nil
+}
+
 // getContentType extracts main content type from SerializedEmail
 func getContentType(serializedEmail *email.SerializedEmail) string {
 	if ct, ok := serializedEmail.Headers["content-type"]; ok && len(ct) > 0 {
@@ -228,6 +245,11 @@ func parseXARF(xarfReport email.EmailPart, xarfEvidence, subject string, fallbac
 	portKeys := []string{"source-port", "port", "ports"}
 	for _, key := range portKeys {
 		if portVal, ok := xarfContent[key]; ok {
+			// The X-ARF schemas define ports as integers
+			if port, ok := portVal.(int); ok {
+				event.Port = port
+				break
+			}
 			if portStr, ok := portVal.(string); ok {
 				// Take first port if multiple
 				ports := strings.Split(portStr, ", ")
@@ -333,12 +355,22 @@ func parseXARF(xarfReport email.EmailPart, xarfEvidence, subject string, fallbac
 }
 
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	// Get content type
+	contentType := getContentType(serializedEmail)
+
+	// Check for X-ARF version
+	xarfVersion := getXARFVersion(serializedEmail, contentType)
+	jsonPart := xarfJSONPart(serializedEmail)
+	if xarfVersion == "" && jsonPart == nil {
+		return nil, common.NewParserError("not an X-ARF report")
+	}
+
 	// Get From address
 	fromAddr, _ := common.GetFrom(serializedEmail, false)
 
-	// Ignore @p44.net emails
+	// @p44.net reports are parsed by the p44 parser
 	if strings.Contains(fromAddr, "@p44.net") {
-		return nil, common.NewIgnoreError("@p44.net emails are ignored")
+		return nil, common.NewParserError("@p44.net emails are parsed by p44")
 	}
 
 	// Check auto-submitted header
@@ -354,12 +386,6 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 		return nil, common.NewRejectError("subject starts with 're:'")
 	}
 
-	// Get content type
-	contentType := getContentType(serializedEmail)
-
-	// Check for X-ARF version
-	xarfVersion := getXARFVersion(serializedEmail, contentType)
-
 	var events []*events.Event
 
 	// Parse X-ARF v0.2:PLAIN or v0.1
@@ -416,34 +442,25 @@ func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event,
 	}
 
 	// Check for xarf.json attachment (alternative format)
-	for i := range serializedEmail.Parts {
-		part := &serializedEmail.Parts[i]
-		if ct, ok := part.Headers["content-type"]; ok && len(ct) > 0 {
-			ctLower := strings.ToLower(ct[0])
-			ctLower = strings.ReplaceAll(ctLower, `"`, "")
-			ctLower = strings.ReplaceAll(ctLower, `'`, "")
-
-			if strings.Contains(ctLower, "application/json") && strings.Contains(ctLower, "name=xarf.json") {
-				// Parse JSON
-				var xarfPart map[string]interface{}
-				bodyStr, ok := part.Body.(string)
-				if !ok {
-					if bodyBytes, ok := part.Body.([]byte); ok {
-						bodyStr = string(bodyBytes)
-					} else {
-						return nil, common.NewParserError("xarf.json body is not a string")
-					}
-				}
-
-				if err := json.Unmarshal([]byte(bodyStr), &xarfPart); err != nil {
-					return nil, common.NewParserError(fmt.Sprintf("failed to parse xarf.json: %v", err))
-				}
-
-				// Convert using xarf2event (would need implementation)
-				// For now, return error indicating this needs implementation
-				return nil, common.NewParserError("xarf.json format not yet supported - needs xarf2event conversion")
+	if jsonPart != nil {
+		// Parse JSON
+		var xarfPart map[string]interface{}
+		bodyStr, ok := jsonPart.Body.(string)
+		if !ok {
+			if bodyBytes, ok := jsonPart.Body.([]byte); ok {
+				bodyStr = string(bodyBytes)
+			} else {
+				return nil, common.NewParserError("xarf.json body is not a string")
 			}
 		}
+
+		if err := json.Unmarshal([]byte(bodyStr), &xarfPart); err != nil {
+			return nil, common.NewParserError(fmt.Sprintf("failed to parse xarf.json: %v", err))
+		}
+
+		// Convert using xarf2event (would need implementation)
+		// For now, return error indicating this needs implementation
+		return nil, common.NewParserError("xarf.json format not yet supported - needs xarf2event conversion")
 	}
 
 	// Handle netcraft.com special case
@@ -34,6 +34,11 @@ func Match(serializedEmail *email.SerializedEmail, fromAddr string) bool {
 
 // Parse processes the xtakedowns abuse email
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	from, _ := common.GetFrom(serializedEmail, false)
+	if !Match(serializedEmail, from) {
+		return nil, common.NewParserError("not a xtakedowns report")
+	}
+
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
 		return nil, err
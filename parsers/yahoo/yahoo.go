@@ -2,6 +2,7 @@ package yahoo
 
 import (
 	"fmt"
+	"maps"
 	"net/mail"
 	"strings"
 
@@ -95,19 +96,18 @@ func rewriteAudriusvaitiekunas(serializedEmail *email.SerializedEmail) (*email.S
 		actualFromName = addr.Name
 	}
 
-	// Update the email body
-	serializedEmail.Body = forwardedBody
+	// Rewrite a copy so the caller's email keeps its body and headers
+	rewritten := *serializedEmail
+	rewritten.Body = forwardedBody
 
 	// Update subject if it starts with "Fw:"
 	subject, _ := common.GetSubject(serializedEmail, false)
-	if strings.HasPrefix(subject, "Fw:") {
-		newSubject := strings.TrimSpace(subject[3:])
-		if serializedEmail.Headers != nil {
-			serializedEmail.Headers["subject"] = []string{newSubject}
-		}
+	if strings.HasPrefix(subject, "Fw:") && serializedEmail.Headers != nil {
+		rewritten.Headers = maps.Clone(serializedEmail.Headers)
+		rewritten.Headers["subject"] = []string{strings.TrimSpace(subject[3:])}
 	}
 
-	return serializedEmail, actualFromAddr, actualFromName, nil
+	return &rewritten, actualFromAddr, actualFromName, nil
 }
 
 // Parse parses emails from aroc725@yahoo.com
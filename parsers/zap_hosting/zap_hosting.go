@@ -40,6 +40,11 @@ func Match(serializedEmail *email.SerializedEmail, fromAddr string) bool {
 
 // Parse parses emails from ZAP-Hosting abuse reports
 func (p *Parser) Parse(serializedEmail *email.SerializedEmail) ([]*events.Event, error) {
+	from, _ := common.GetFrom(serializedEmail, false)
+	if !Match(serializedEmail, from) {
+		return nil, common.NewParserError("not a ZAP-Hosting abuse report")
+	}
+
 	// Get email body and subject
 	body, err := common.GetBody(serializedEmail, true)
 	if err != nil {
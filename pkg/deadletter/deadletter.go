@@ -0,0 +1,281 @@
+// Package deadletter keeps the emails no parser could handle, together with
+// their metadata, outcome and explain trace, so that they can be replayed
+// through the registry once a parser for them was written.
+package deadletter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+// Outcomes of dead letters
+const (
+	OutcomeUnmatched        = "unmatched"
+	OutcomeError            = "error"
+	OutcomeTooLarge         = "too_large"
+	OutcomeMatchedWithError = "matched_with_error"
+)
+
+// maxLine bounds a dead letter line of a stream file
+const maxLine = 256 << 20
+
+// Letter describes a dead email; the raw message is stored next to it
+type Letter struct {
+	Identifier string    `json:"identifier"`
+	Received   time.Time `json:"received"`
+	// Source is the command that received the email: process or serve
+	Source  string `json:"source"`
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+	// Events is the number of events emitted before a parser failed
+	Events     int                 `json:"events,omitempty"`
+	Metadata   email.EmailMetadata `json:"metadata"`
+	EnvelopeTo []string            `json:"envelope_to,omitempty"`
+	// Kafka is where the email was consumed from, if it came from Kafka
+	Kafka   interface{}          `json:"kafka,omitempty"`
+	Explain *parsers.Explanation `json:"explain,omitempty"`
+	// Raw is the message in stream files; directories keep it as .eml file
+	Raw []byte `json:"raw,omitempty"`
+}
+
+// Outcome returns the outcome of a parse that makes the email a dead
+// letter, or "" if the email was parsed, ignored or rejected, or parsing was
+// canceled
+func Outcome(events int, err error) string {
+	var tooLarge *parsers.EmailTooLargeError
+	var classified *parsers.ClassifiedError
+	switch {
+	case errors.Is(err, context.Canceled), errors.As(err, &classified):
+		return ""
+	case errors.As(err, &tooLarge):
+		return OutcomeTooLarge
+	case events > 0 && err != nil:
+		return OutcomeMatchedWithError
+	case events > 0:
+		return ""
+	case err != nil:
+		return OutcomeError
+	}
+	return OutcomeUnmatched
+}
+
+// New returns the letter of an email that was not parsed
+func New(serializedEmail *email.SerializedEmail, source string, events int, err error, explanation *parsers.Explanation) *Letter {
+	letter := &Letter{
+		Identifier: serializedEmail.Identifier,
+		Received:   time.Now().UTC(),
+		Source:     source,
+		Outcome:    Outcome(events, err),
+		Events:     events,
+		Metadata:   serializedEmail.Metadata,
+		EnvelopeTo: serializedEmail.EnvelopeTo,
+		Explain:    explanation,
+	}
+	if err != nil {
+		letter.Error = err.Error()
+	}
+	return letter
+}
+
+// Email parses the raw message of the letter and restores the identifier
+// and envelope, which are not part of the message
+func (l *Letter) Email(raw []byte) (*email.SerializedEmail, error) {
+	serializedEmail, err := email.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	serializedEmail.Identifier = l.Identifier
+	serializedEmail.Metadata = l.Metadata
+	serializedEmail.EnvelopeTo = l.EnvelopeTo
+	return serializedEmail, nil
+}
+
+// Sink stores dead letters
+type Sink interface {
+	Put(letter *Letter, raw []byte) error
+}
+
+// DirSink stores each letter as <name>.eml with the raw message and
+// <name>.json with the letter
+type DirSink struct {
+	dir string
+}
+
+// NewDirSink returns a sink writing to dir, which is created if missing
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirSink{dir: dir}, nil
+}
+
+// Put writes the message and then the letter, each through a temporary
+// file, so that a letter is only seen with its message
+func (d *DirSink) Put(letter *Letter, raw []byte) error {
+	sum := sha256.Sum256(raw)
+	name := letter.Received.Format("20060102T150405Z") + "-" + hex.EncodeToString(sum[:6])
+	sidecar, err := json.MarshalIndent(letter, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(d.dir, name+".eml"), raw); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(d.dir, name+".json"), append(sidecar, '\n'))
+}
+
+func writeFile(path string, data []byte) error {
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+// StreamSink writes each letter with its raw message as one JSON line, e.g.
+// for Bento to route to a dead-letter Kafka topic
+type StreamSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStreamSink returns a sink writing to w
+func NewStreamSink(w io.Writer) *StreamSink {
+	return &StreamSink{w: w}
+}
+
+// Put writes the letter line
+func (s *StreamSink) Put(letter *Letter, raw []byte) error {
+	withRaw := *letter
+	withRaw.Raw = raw
+	data, err := json.Marshal(struct {
+		DeadLetter *Letter `json:"dead_letter"`
+	}{&withRaw})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// Entry is a stored letter
+type Entry struct {
+	Letter *Letter
+	// Name identifies the letter: the sidecar path, or the stream file with
+	// the line number
+	Name string
+	// emlPath is the raw message of a directory letter
+	emlPath string
+}
+
+// Raw returns the raw message of the letter
+func (e *Entry) Raw() ([]byte, error) {
+	if e.emlPath == "" {
+		return e.Letter.Raw, nil
+	}
+	return os.ReadFile(e.emlPath)
+}
+
+// Remove deletes a directory letter and its message
+func (e *Entry) Remove() error {
+	if e.emlPath == "" {
+		return fmt.Errorf("%s is part of a stream file", e.Name)
+	}
+	if err := os.Remove(e.emlPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Remove(e.Name)
+}
+
+// Load reads the letters of a DirSink directory, of a single sidecar
+// (.json) or of a stream file with one letter per line (e.g. a dump of the
+// dead-letter topic or a process output)
+func Load(path string) ([]*Entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		sidecars, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(sidecars)
+		entries := make([]*Entry, 0, len(sidecars))
+		for _, sidecar := range sidecars {
+			entry, err := loadSidecar(sidecar)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+	if strings.HasSuffix(path, ".json") {
+		entry, err := loadSidecar(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*Entry{entry}, nil
+	}
+	return loadStream(path)
+}
+
+func loadSidecar(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	letter := &Letter{}
+	if err := json.Unmarshal(data, letter); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &Entry{Letter: letter, Name: path, emlPath: strings.TrimSuffix(path, ".json") + ".eml"}, nil
+}
+
+func loadStream(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), maxLine)
+	for line := 1; scanner.Scan(); line++ {
+		// Other lines, e.g. the event arrays of a process output, are skipped
+		if !bytes.HasPrefix(bytes.TrimSpace(scanner.Bytes()), []byte("{")) {
+			continue
+		}
+		var record struct {
+			DeadLetter *Letter `json:"dead_letter"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if record.DeadLetter == nil {
+			continue
+		}
+		entries = append(entries, &Entry{Letter: record.DeadLetter, Name: fmt.Sprintf("%s:%d", path, line)})
+	}
+	return entries, scanner.Err()
+}
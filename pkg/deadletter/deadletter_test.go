@@ -0,0 +1,118 @@
+package deadletter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/parsers"
+	"github.com/abusix/inbound-parsers/pkg/email"
+)
+
+const rawMail = "From: reporter@example.com\r\nSubject: Unknown report\r\n\r\nSomething bad happened.\r\n"
+
+func TestOutcome(t *testing.T) {
+	for _, tc := range []struct {
+		events   int
+		err      error
+		expected string
+	}{
+		{1, nil, ""},
+		{0, nil, OutcomeUnmatched},
+		{0, errors.New("boom"), OutcomeError},
+		{2, errors.New("boom"), OutcomeMatchedWithError},
+		{0, &parsers.EmailTooLargeError{Size: 10, Limit: 5}, OutcomeTooLarge},
+		{0, fmt.Errorf("parse: %w", context.Canceled), ""},
+		{0, &parsers.ClassifiedError{Parser: "autoreply", Err: errors.New("email ignored: auto-reply")}, ""},
+	} {
+		if got := Outcome(tc.events, tc.err); got != tc.expected {
+			t.Errorf("Outcome(%d, %v) = %q, expected %q", tc.events, tc.err, got, tc.expected)
+		}
+	}
+}
+
+func newLetter(t *testing.T) *Letter {
+	t.Helper()
+	serializedEmail, err := email.Parse([]byte(rawMail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serializedEmail.Identifier = "mail-1"
+	serializedEmail.Metadata.EnvelopeFrom = "bounce@example.com"
+	explanation := &parsers.Explanation{Attempts: []parsers.Attempt{{Parser: "spamcop", Outcome: parsers.OutcomeDeclined}}}
+	return New(serializedEmail, "process", 0, nil, explanation)
+}
+
+func TestDirSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "letters")
+	sink, err := NewDirSink(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Put(newLetter(t), []byte(rawMail)); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 letter, got %d", len(entries))
+	}
+	entry := entries[0]
+	letter := entry.Letter
+	if letter.Outcome != OutcomeUnmatched || letter.Source != "process" || len(letter.Explain.Attempts) != 1 {
+		t.Errorf("Unexpected letter %+v", letter)
+	}
+	raw, err := entry.Raw()
+	if err != nil || string(raw) != rawMail {
+		t.Fatalf("Unexpected message %q: %v", raw, err)
+	}
+	serializedEmail, err := letter.Email(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serializedEmail.Identifier != "mail-1" || serializedEmail.Metadata.EnvelopeFrom != "bounce@example.com" {
+		t.Errorf("Envelope not restored: %+v", serializedEmail)
+	}
+
+	if err := entry.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected an empty directory, got %d files", len(files))
+	}
+}
+
+func TestStreamSink(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("[{\"ip\":\"192.0.2.1\"}]\n")
+	if err := NewStreamSink(&buf).Put(newLetter(t), []byte(rawMail)); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString("[]\n")
+	path := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name != path+":2" {
+		t.Fatalf("Unexpected entries %+v", entries)
+	}
+	raw, err := entries[0].Raw()
+	if err != nil || string(raw) != rawMail {
+		t.Errorf("Unexpected message %q: %v", raw, err)
+	}
+	if err := entries[0].Remove(); err == nil {
+		t.Error("Expected an error removing a stream letter")
+	}
+}
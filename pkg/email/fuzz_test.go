@@ -0,0 +1,40 @@
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzParse checks that Parse does not panic on malformed mails and that
+// a successful parse always yields headers. It is seeded with the crashers
+// and every tenth sample mail.
+func FuzzParse(f *testing.F) {
+	for _, pattern := range []string{"../../testdata/crashers/*.eml", "../../testdata/sample_mails/*.eml"} {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for i, path := range paths {
+			if filepath.Base(filepath.Dir(path)) == "sample_mails" && i%10 != 0 {
+				continue
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(data)
+		}
+	}
+	f.Add([]byte("Subject: test\r\nContent-Type: multipart/mixed; boundary=x\r\n\r\n--x\r\n\r\nbody\r\n--x--\r\n"))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		serializedEmail, err := Parse(raw)
+		if err != nil {
+			return
+		}
+		if serializedEmail == nil || serializedEmail.Headers == nil {
+			t.Fatalf("Parse returned no headers without error")
+		}
+	})
+}
@@ -9,9 +9,13 @@ import (
 	"mime/quotedprintable"
 	"net/mail"
 	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
 )
 
-// Parse parses a raw email into a SerializedEmail struct
+// Parse parses a raw email into a SerializedEmail struct. Body is set to the
+// first plain text part that is not an attachment, or the first HTML one if
+// there is none.
 func Parse(rawEmail []byte) (*SerializedEmail, error) {
 	msg, err := mail.ReadMessage(bytes.NewReader(rawEmail))
 	if err != nil {
@@ -49,6 +53,11 @@ func Parse(rawEmail []byte) (*SerializedEmail, error) {
 			if err == nil {
 				serialized.Parts = parts
 			}
+			if body, ok := textBody(parts, "text/plain"); ok {
+				serialized.Body = body
+			} else if body, ok := textBody(parts, "text/html"); ok {
+				serialized.Body = body
+			}
 		}
 	} else {
 		// Single part email
@@ -59,6 +68,9 @@ func Parse(rawEmail []byte) (*SerializedEmail, error) {
 				Body:        decodeBody(body, msg.Header.Get("Content-Transfer-Encoding")),
 			}
 			serialized.Parts = []EmailPart{part}
+			if mediaType == "text/plain" || mediaType == "text/html" {
+				serialized.Body = decodeCharset(part.Body.([]byte), params["charset"])
+			}
 		}
 	}
 
@@ -94,9 +106,13 @@ func parseMultipart(body io.Reader, boundary string) ([]EmailPart, error) {
 		decodedBody := decodeBody(partBody, encoding)
 
 		emailPart := EmailPart{
+			Headers:     make(map[string][]string, len(part.Header)),
 			ContentType: mediaType,
 			Body:        decodedBody,
 		}
+		for key, values := range part.Header {
+			emailPart.Headers[strings.ToLower(key)] = values
+		}
 
 		// Handle nested multipart
 		if strings.HasPrefix(mediaType, "multipart/") {
@@ -114,6 +130,56 @@ func parseMultipart(body io.Reader, boundary string) ([]EmailPart, error) {
 	return parts, nil
 }
 
+// textBody returns the first part of mediaType that is not an attachment,
+// searching nested multiparts, decoded from its charset
+func textBody(parts []EmailPart, mediaType string) (string, bool) {
+	for _, part := range parts {
+		if len(part.Parts) > 0 {
+			if body, ok := textBody(part.Parts, mediaType); ok {
+				return body, true
+			}
+			continue
+		}
+		body, ok := part.Body.([]byte)
+		if !ok || part.ContentType != mediaType || isAttachment(part) {
+			continue
+		}
+		_, params, _ := mime.ParseMediaType(firstHeader(part.Headers, "content-type"))
+		return decodeCharset(body, params["charset"]), true
+	}
+	return "", false
+}
+
+func isAttachment(part EmailPart) bool {
+	disposition, _, _ := mime.ParseMediaType(firstHeader(part.Headers, "content-disposition"))
+	return disposition == "attachment"
+}
+
+func firstHeader(headers map[string][]string, key string) string {
+	if values := headers[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// decodeCharset converts a text body to UTF-8. Bodies in an unknown charset
+// or that fail to decode are returned as they are.
+func decodeCharset(body []byte, charset string) string {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return string(body)
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return string(body)
+	}
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return string(body)
+	}
+	return string(decoded)
+}
+
 func decodeBody(body []byte, encoding string) []byte {
 	encoding = strings.ToLower(strings.TrimSpace(encoding))
 
@@ -0,0 +1,70 @@
+package email
+
+import "testing"
+
+func TestParse_Body(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want interface{}
+	}{
+		{
+			name: "plain text",
+			raw:  "Subject: x\r\nContent-Type: text/plain\r\n\r\nhello\r\n",
+			want: "hello\r\n",
+		},
+		{
+			name: "latin-1",
+			raw:  "Subject: x\r\nContent-Type: text/plain; charset=iso-8859-1\r\n\r\nGr\xfc\xdfe\r\n",
+			want: "Grüße\r\n",
+		},
+		{
+			name: "html only",
+			raw:  "Subject: x\r\nContent-Type: text/html\r\n\r\n<p>hello</p>\r\n",
+			want: "<p>hello</p>\r\n",
+		},
+		{
+			name: "alternative prefers plain text",
+			raw: "Subject: x\r\nContent-Type: multipart/alternative; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: text/html\r\n\r\n<p>hello</p>\r\n" +
+				"--b\r\nContent-Type: text/plain\r\n\r\nhello\r\n" +
+				"--b--\r\n",
+			want: "hello",
+		},
+		{
+			name: "nested html",
+			raw: "Subject: x\r\nContent-Type: multipart/mixed; boundary=a\r\n\r\n" +
+				"--a\r\nContent-Type: multipart/related; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: text/html\r\n\r\n<p>hello</p>\r\n" +
+				"--b--\r\n" +
+				"--a--\r\n",
+			want: "<p>hello</p>",
+		},
+		{
+			name: "attachment skipped",
+			raw: "Subject: x\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: text/plain\r\nContent-Disposition: attachment; filename=log.txt\r\n\r\nlog\r\n" +
+				"--b\r\nContent-Type: text/plain\r\n\r\nhello\r\n" +
+				"--b--\r\n",
+			want: "hello",
+		},
+		{
+			name: "no text part",
+			raw: "Subject: x\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+				"--b\r\nContent-Type: application/zip\r\n\r\nPK\r\n" +
+				"--b--\r\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serializedEmail, err := Parse([]byte(tt.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if serializedEmail.Body != tt.want {
+				t.Errorf("Expected body %q, got %q", tt.want, serializedEmail.Body)
+			}
+		})
+	}
+}
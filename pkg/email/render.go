@@ -0,0 +1,209 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// Render writes a serialized email back as a raw message, e.g. to save it as
+// an .eml file. Parsing the result yields the headers and parts of the email;
+// bodies that are not plain ASCII text are written base64 encoded.
+func Render(serializedEmail *SerializedEmail) ([]byte, error) {
+	headers := make(map[string][]string, len(serializedEmail.Headers))
+	for key, values := range serializedEmail.Headers {
+		headers[strings.ToLower(key)] = values
+	}
+	delete(headers, "content-transfer-encoding")
+
+	var buf bytes.Buffer
+	parts := serializedEmail.Parts
+	mediaType, params := contentTypeOf(headers)
+	if !strings.HasPrefix(mediaType, "multipart/") && (len(parts) == 0 || singlePart(parts)) {
+		content := bodyBytes(serializedEmail.Body)
+		if len(parts) == 1 {
+			content = bodyBytes(parts[0].Body)
+			if len(headers["content-type"]) == 0 && parts[0].ContentType != "" {
+				headers["content-type"] = []string{parts[0].ContentType}
+			}
+		}
+		encoding := transferEncoding(content)
+		if encoding != "" {
+			headers["content-transfer-encoding"] = []string{encoding}
+		}
+		writeHeaders(&buf, headers)
+		buf.WriteString("\r\n")
+		if err := writeContent(&buf, content, encoding); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	boundary := params["boundary"]
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		mediaType = "multipart/mixed"
+	}
+	writer := multipart.NewWriter(io.Discard)
+	if boundary == "" || writer.SetBoundary(boundary) != nil {
+		boundary = newBoundary()
+		params["boundary"] = boundary
+		headers["content-type"] = []string{mime.FormatMediaType(mediaType, params)}
+	}
+	writeHeaders(&buf, headers)
+	buf.WriteString("\r\n")
+	if err := writeParts(&buf, parts, boundary); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// singlePart reports whether parts is the body of a single part email as
+// Parse returns it
+func singlePart(parts []EmailPart) bool {
+	return len(parts) == 1 && len(parts[0].Headers) == 0 && len(parts[0].Parts) == 0
+}
+
+func writeParts(w io.Writer, parts []EmailPart, boundary string) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		header := make(textproto.MIMEHeader, len(part.Headers)+2)
+		for key, values := range part.Headers {
+			header[textproto.CanonicalMIMEHeaderKey(key)] = sanitizeValues(values)
+		}
+		header.Del("Content-Transfer-Encoding")
+		if header.Get("Content-Type") == "" && part.ContentType != "" {
+			header.Set("Content-Type", part.ContentType)
+		}
+
+		if len(part.Parts) > 0 {
+			mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+			if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+				mediaType, params = "multipart/mixed", map[string]string{}
+			}
+			nested := params["boundary"]
+			if nested == "" || multipart.NewWriter(io.Discard).SetBoundary(nested) != nil {
+				nested = newBoundary()
+				params["boundary"] = nested
+				header.Set("Content-Type", mime.FormatMediaType(mediaType, params))
+			}
+			partWriter, err := writer.CreatePart(header)
+			if err != nil {
+				return err
+			}
+			if err := writeParts(partWriter, part.Parts, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		content := bodyBytes(part.Body)
+		encoding := transferEncoding(content)
+		if encoding != "" {
+			header.Set("Content-Transfer-Encoding", encoding)
+		}
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if err := writeContent(partWriter, content, encoding); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func writeHeaders(buf *bytes.Buffer, headers map[string][]string) {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range sanitizeValues(headers[key]) {
+			fmt.Fprintf(buf, "%s: %s\r\n", textproto.CanonicalMIMEHeaderKey(key), value)
+		}
+	}
+}
+
+// sanitizeValues unfolds header values so they cannot start new headers
+func sanitizeValues(values []string) []string {
+	sanitized := make([]string, len(values))
+	for i, value := range values {
+		sanitized[i] = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+	}
+	return sanitized
+}
+
+func contentTypeOf(headers map[string][]string) (string, map[string]string) {
+	if values := headers["content-type"]; len(values) > 0 {
+		if mediaType, params, err := mime.ParseMediaType(values[0]); err == nil {
+			return mediaType, params
+		}
+	}
+	return "text/plain", map[string]string{}
+}
+
+func bodyBytes(body interface{}) []byte {
+	switch body := body.(type) {
+	case nil:
+		return nil
+	case string:
+		return []byte(body)
+	case []byte:
+		return body
+	}
+	return []byte(fmt.Sprint(body))
+}
+
+// transferEncoding returns base64 for content that is not 7bit text with
+// lines of at most 998 characters, else "" for none
+func transferEncoding(content []byte) string {
+	lineLength := 0
+	for _, c := range content {
+		switch {
+		case c == '\n':
+			lineLength = 0
+			continue
+		case c == 0 || c >= 0x80:
+			return "base64"
+		}
+		lineLength++
+		if lineLength > 998 {
+			return "base64"
+		}
+	}
+	return ""
+}
+
+func writeContent(w io.Writer, content []byte, encoding string) error {
+	if encoding != "base64" {
+		_, err := w.Write(content)
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
+
+func newBoundary() string {
+	var random [16]byte
+	_, _ = rand.Read(random[:])
+	return hex.EncodeToString(random[:])
+}
@@ -0,0 +1,109 @@
+package email
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestRender_RoundTrip checks that parsing a rendered sample mail yields
+// the headers and bodies of the parsed sample
+func TestRender_RoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("../../testdata/sample_mails/*.eml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no sample mails")
+	}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		original, err := Parse(raw)
+		if err != nil {
+			continue
+		}
+		rendered, err := Render(original)
+		if err != nil {
+			t.Errorf("%s: %v", filepath.Base(path), err)
+			continue
+		}
+		parsed, err := Parse(rendered)
+		if err != nil {
+			t.Errorf("%s: rendered mail does not parse: %v", filepath.Base(path), err)
+			continue
+		}
+		if !equalHeaders(original.Headers, parsed.Headers) {
+			t.Errorf("%s: headers differ after rendering", filepath.Base(path))
+		}
+		if diff := diffParts(original.Parts, parsed.Parts); diff != "" {
+			t.Errorf("%s: %s", filepath.Base(path), diff)
+		}
+	}
+}
+
+func TestRender_SerializedJSON(t *testing.T) {
+	serializedEmail := &SerializedEmail{
+		Headers: map[string][]string{"subject": {"Report"}, "from": {"abuse@example.com"}},
+		Parts: []EmailPart{
+			{ContentType: "text/plain", Body: "Dear abuse team,\n"},
+			{ContentType: "application/zip", Headers: map[string][]string{"content-disposition": {`attachment; filename="r.zip"`}}, Body: "PK\x03\x04\x00\xff"},
+		},
+	}
+	rendered, err := Render(serializedEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Parts) != 2 || !bytes.Equal(parsed.Parts[1].Body.([]byte), []byte("PK\x03\x04\x00\xff")) {
+		t.Fatalf("Unexpected parts %+v", parsed.Parts)
+	}
+	if parsed.Parts[1].ContentType != "application/zip" || string(parsed.Parts[0].Body.([]byte)) != "Dear abuse team,\n" {
+		t.Errorf("Unexpected parts %+v", parsed.Parts)
+	}
+}
+
+// equalHeaders compares headers apart from the ones Render rewrites
+func equalHeaders(a, b map[string][]string) bool {
+	strip := func(headers map[string][]string) map[string][]string {
+		stripped := make(map[string][]string, len(headers))
+		for key, values := range headers {
+			if key != "content-type" && key != "content-transfer-encoding" {
+				stripped[key] = values
+			}
+		}
+		return stripped
+	}
+	return reflect.DeepEqual(strip(a), strip(b))
+}
+
+func diffParts(a, b []EmailPart) string {
+	if len(a) != len(b) {
+		return "number of parts differs after rendering"
+	}
+	for i := range a {
+		if a[i].ContentType != b[i].ContentType {
+			return "content type of part differs after rendering"
+		}
+		if len(a[i].Parts) > 0 {
+			if diff := diffParts(a[i].Parts, b[i].Parts); diff != "" {
+				return diff
+			}
+			continue
+		}
+		if !bytes.Equal(bodyBytes(a[i].Body), bodyBytes(b[i].Body)) {
+			return "body of part differs after rendering"
+		}
+		if !equalHeaders(a[i].Headers, b[i].Headers) {
+			return "headers of part differ after rendering"
+		}
+	}
+	return ""
+}
@@ -33,6 +33,46 @@ type EmailMetadata struct {
 	AuthHeader   string `json:"auth_header,omitempty"`
 }
 
+// Clone returns a copy of the email whose headers and parts can be changed
+// without changing the original. Bodies are shared.
+func (e *SerializedEmail) Clone() *SerializedEmail {
+	clone := *e
+	clone.Headers = cloneHeaders(e.Headers)
+	clone.Parts = cloneParts(e.Parts)
+	if e.Signature != nil {
+		clone.Signature = make(map[string]interface{}, len(e.Signature))
+		for key, value := range e.Signature {
+			clone.Signature[key] = value
+		}
+	}
+	clone.EnvelopeTo = append([]string(nil), e.EnvelopeTo...)
+	return &clone
+}
+
+func cloneHeaders(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+	clone := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		clone[name] = append([]string(nil), values...)
+	}
+	return clone
+}
+
+func cloneParts(parts []EmailPart) []EmailPart {
+	if parts == nil {
+		return nil
+	}
+	clone := make([]EmailPart, len(parts))
+	for i, part := range parts {
+		part.Headers = cloneHeaders(part.Headers)
+		part.Parts = cloneParts(part.Parts)
+		clone[i] = part
+	}
+	return clone
+}
+
 // ReceivedHeader represents a parsed Received header
 type ReceivedHeader struct {
 	Headers []string
@@ -0,0 +1,329 @@
+// Package enrich adds the ASN, AS name, ISP, country and city of event IPs
+// from local MaxMind DB files (GeoLite2, DB-IP or ip-to-asn databases).
+// Details the reporter supplied are never changed: looked up values are
+// added as separate details marked with Source, and only for the fields
+// the reporter left empty.
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/parsers/base"
+	"github.com/abusix/inbound-parsers/pkg/logging"
+	"github.com/abusix/inbound-parsers/pkg/mmdb"
+)
+
+// Source marks the details added by the enricher
+const Source = "mmdb"
+
+// record is what the databases know about a network
+type record struct {
+	asn     string
+	asName  string
+	isp     string
+	country string
+	city    string
+}
+
+// merge fills the empty fields of r from other
+func (r *record) merge(other record) {
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&r.asn, other.asn)
+	fill(&r.asName, other.asName)
+	fill(&r.isp, other.isp)
+	fill(&r.country, other.country)
+	fill(&r.city, other.city)
+}
+
+// loaded is a database read into memory with the records decoded so far,
+// keyed by their data offset. Networks share few distinct records, so the
+// cache stays far smaller than the database.
+type loaded struct {
+	reader *mmdb.Reader
+	cache  sync.Map
+}
+
+// Database is a database file that is read again when it changes
+type Database struct {
+	path    string
+	current atomic.Pointer[loaded]
+	// modTime and size identify the file version last read, successfully
+	// or not, so that a broken file is reported once
+	modTime time.Time
+	size    int64
+}
+
+// reload reads the file if it changed since the last call and reports
+// whether it did; on failure the previous version stays in use
+func (d *Database) reload() (bool, error) {
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(d.modTime) && info.Size() == d.size {
+		return false, nil
+	}
+	d.modTime, d.size = info.ModTime(), info.Size()
+	reader, err := mmdb.Open(d.path)
+	if err != nil {
+		return false, err
+	}
+	d.current.Store(&loaded{reader: reader})
+	return true, nil
+}
+
+// lookup returns the record of the network containing addr
+func (d *Database) lookup(addr netip.Addr) (record, error) {
+	db := d.current.Load()
+	if db == nil {
+		return record{}, nil
+	}
+	offset, found, err := db.reader.LookupOffset(addr)
+	if !found || err != nil {
+		return record{}, err
+	}
+	if cached, ok := db.cache.Load(offset); ok {
+		return cached.(record), nil
+	}
+	value, err := db.reader.Decode(offset)
+	if err != nil {
+		return record{}, err
+	}
+	r := extract(value)
+	db.cache.Store(offset, r)
+	return r, nil
+}
+
+// Enricher looks up event IPs in a set of databases; it is safe for
+// concurrent use
+type Enricher struct {
+	databases []*Database
+}
+
+// New reads the databases; their order decides which one wins when several
+// know a field
+func New(paths ...string) (*Enricher, error) {
+	e := &Enricher{}
+	for _, path := range paths {
+		db := &Database{path: path}
+		if _, err := db.reload(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		e.databases = append(e.databases, db)
+	}
+	return e, nil
+}
+
+// Reload reads the databases whose files changed; failures are logged and
+// keep the previous version
+func (e *Enricher) Reload(ctx context.Context) {
+	for _, db := range e.databases {
+		reloaded, err := db.reload()
+		switch {
+		case err != nil:
+			slog.WarnContext(ctx, "Failed to reload MMDB database", "path", db.path, logging.KeyError, err)
+		case reloaded:
+			metadata := db.current.Load().reader.Metadata
+			slog.InfoContext(ctx, "Reloaded MMDB database", "path", db.path, "type", metadata.DatabaseType,
+				"built", time.Unix(int64(metadata.BuildEpoch), 0).UTC())
+		}
+	}
+}
+
+// Watch reloads changed databases every interval until ctx is done
+func (e *Enricher) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Reload(ctx)
+		}
+	}
+}
+
+// Enrich adds the details of the event's IP. Events without a public IP and
+// events enriched before are left as they are.
+func (e *Enricher) Enrich(event *events.Event) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(event.IP))
+	if err != nil {
+		return
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || enriched(event) {
+		return
+	}
+
+	var found record
+	for _, db := range e.databases {
+		r, err := db.lookup(addr)
+		if err != nil {
+			slog.Debug("MMDB lookup failed", "path", db.path, logging.KeyError, err)
+			continue
+		}
+		found.merge(r)
+	}
+	apply(event, found)
+}
+
+// Wrap returns an emit callback enriching the events before passing them on
+func (e *Enricher) Wrap(emit base.EmitFunc) base.EmitFunc {
+	return func(event *events.Event) error {
+		e.Enrich(event)
+		return emit(event)
+	}
+}
+
+func enriched(event *events.Event) bool {
+	for _, detail := range event.EventDetails {
+		switch detail := detail.(type) {
+		case *events.ASN:
+			if detail.Source == Source {
+				return true
+			}
+		case *events.Location:
+			if detail.Source == Source {
+				return true
+			}
+		case *events.ISP:
+			if detail.Source == Source {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apply adds the found values the reporter did not supply. An AS name is
+// only added if the reporter gave no other AS number, and a city only if the
+// reporter gave no other country.
+func apply(event *events.Event, found record) {
+	var asn events.ASN
+	var location events.Location
+	var isp events.ISP
+	for _, detail := range event.EventDetails {
+		switch detail := detail.(type) {
+		case *events.ASN:
+			asn.ASN = first(asn.ASN, detail.ASN)
+			asn.ASName = first(asn.ASName, detail.ASName)
+		case *events.Location:
+			location.Country = first(location.Country, detail.Country)
+			location.City = first(location.City, detail.City)
+		case *events.ISP:
+			isp.ISPName = first(isp.ISPName, detail.ISPName)
+		}
+	}
+
+	added := events.ASN{Source: Source}
+	if asn.ASN == "" {
+		added.ASN = found.asn
+	}
+	if asn.ASName == "" && (asn.ASN == "" || sameASN(asn.ASN, found.asn)) {
+		added.ASName = found.asName
+	}
+	if added.ASN != "" || added.ASName != "" {
+		event.AddEventDetail(&added)
+	}
+
+	addedLocation := events.Location{Source: Source}
+	if location.Country == "" {
+		addedLocation.Country = found.country
+	}
+	if location.City == "" && (location.Country == "" || strings.EqualFold(location.Country, found.country)) {
+		addedLocation.City = found.city
+	}
+	if addedLocation.Country != "" || addedLocation.City != "" {
+		event.AddEventDetail(&addedLocation)
+	}
+
+	if isp.ISPName == "" && found.isp != "" {
+		event.AddEventDetail(&events.ISP{ISPName: found.isp, Source: Source})
+	}
+}
+
+func first(current, value string) string {
+	if current != "" {
+		return current
+	}
+	return strings.TrimSpace(value)
+}
+
+// sameASN compares AS numbers with or without the AS prefix
+func sameASN(a, b string) bool {
+	return normalizeASN(a) == normalizeASN(b)
+}
+
+func normalizeASN(asn string) string {
+	asn = strings.TrimSpace(asn)
+	if len(asn) > 2 && strings.EqualFold(asn[:2], "AS") {
+		asn = asn[2:]
+	}
+	return asn
+}
+
+// extract reads a record in the layouts of the common databases: GeoLite2
+// and DB-IP (autonomous_system_number, country.iso_code, city.names.en,
+// isp) and ip-to-asn or ipinfo style (asn, as_name, country, country_code)
+func extract(value interface{}) record {
+	fields, _ := value.(map[string]interface{})
+	var r record
+	if asn := text(fields["autonomous_system_number"]); asn != "" {
+		r.asn = asn
+	} else {
+		r.asn = normalizeASN(text(fields["asn"]))
+	}
+	r.asName = text(fields["autonomous_system_organization"])
+	if r.asName == "" {
+		r.asName = text(fields["as_name"])
+	}
+	r.isp = text(fields["isp"])
+
+	switch country := fields["country"].(type) {
+	case map[string]interface{}:
+		r.country = text(country["iso_code"])
+	case string:
+		r.country = country
+	}
+	if r.country == "" {
+		r.country = text(fields["country_code"])
+	}
+	r.country = strings.ToUpper(r.country)
+
+	switch city := fields["city"].(type) {
+	case map[string]interface{}:
+		names, _ := city["names"].(map[string]interface{})
+		r.city = text(names["en"])
+	case string:
+		r.city = city
+	}
+	return r
+}
+
+// text returns strings and numbers as string
+func text(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case uint64:
+		return strconv.FormatUint(value, 10)
+	case int32:
+		return strconv.FormatInt(int64(value), 10)
+	}
+	return ""
+}
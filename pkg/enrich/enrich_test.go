@@ -0,0 +1,182 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+	"github.com/abusix/inbound-parsers/pkg/mmdb/mmdbtest"
+)
+
+func writeDatabase(t *testing.T, path string, networks []mmdbtest.Network) {
+	t.Helper()
+	buffer, err := mmdbtest.Build(networks, mmdbtest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buffer, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func asnNetwork(prefix string, asn uint32, name string) mmdbtest.Network {
+	return mmdbtest.Network{Prefix: netip.MustParsePrefix(prefix), Data: map[string]interface{}{
+		"autonomous_system_number":       asn,
+		"autonomous_system_organization": name,
+	}}
+}
+
+func cityNetwork(prefix, country, city string) mmdbtest.Network {
+	return mmdbtest.Network{Prefix: netip.MustParsePrefix(prefix), Data: map[string]interface{}{
+		"country": map[string]interface{}{"iso_code": country, "names": map[string]interface{}{"en": "Germany"}},
+		"city":    map[string]interface{}{"names": map[string]interface{}{"en": city, "de": city}},
+	}}
+}
+
+func newEnricher(t *testing.T) *Enricher {
+	t.Helper()
+	dir := t.TempDir()
+	asnPath := filepath.Join(dir, "asn.mmdb")
+	cityPath := filepath.Join(dir, "city.mmdb")
+	writeDatabase(t, asnPath, []mmdbtest.Network{asnNetwork("192.0.2.0/24", 64500, "Example Net")})
+	writeDatabase(t, cityPath, []mmdbtest.Network{cityNetwork("192.0.2.0/24", "DE", "Berlin")})
+	enricher, err := New(asnPath, cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enricher
+}
+
+func TestEnrich(t *testing.T) {
+	enricher := newEnricher(t)
+	for _, tc := range []struct {
+		name     string
+		ip       string
+		details  []events.EventDetail
+		expected []events.EventDetail
+	}{
+		{
+			name: "no reporter details",
+			ip:   "192.0.2.10",
+			expected: []events.EventDetail{
+				&events.ASN{ASN: "64500", ASName: "Example Net", Source: Source},
+				&events.Location{Country: "DE", City: "Berlin", Source: Source},
+			},
+		},
+		{
+			name:    "reporter AS number",
+			ip:      "192.0.2.10",
+			details: []events.EventDetail{&events.ASN{ASN: "AS64500"}},
+			expected: []events.EventDetail{
+				&events.ASN{ASN: "AS64500"},
+				&events.ASN{ASName: "Example Net", Source: Source},
+				&events.Location{Country: "DE", City: "Berlin", Source: Source},
+			},
+		},
+		{
+			name: "reporter disagrees",
+			ip:   "192.0.2.10",
+			details: []events.EventDetail{
+				&events.ASN{ASN: "3303"},
+				&events.Location{Country: "CH"},
+			},
+			expected: []events.EventDetail{
+				&events.ASN{ASN: "3303"},
+				&events.Location{Country: "CH"},
+			},
+		},
+		{
+			name:    "IPv4-mapped",
+			ip:      "::ffff:192.0.2.10",
+			details: []events.EventDetail{&events.ASN{ASN: "64500", ASName: "Reporter Name"}, &events.Location{Country: "de"}},
+			expected: []events.EventDetail{
+				&events.ASN{ASN: "64500", ASName: "Reporter Name"},
+				&events.Location{Country: "de"},
+				&events.Location{City: "Berlin", Source: Source},
+			},
+		},
+		{name: "not in the databases", ip: "198.51.100.1"},
+		{name: "private", ip: "10.0.0.1"},
+		{name: "no IP", ip: ""},
+	} {
+		event := events.NewEvent("test")
+		event.IP = tc.ip
+		event.EventDetails = tc.details
+		enricher.Enrich(event)
+		if !reflect.DeepEqual(event.EventDetails, tc.expected) {
+			t.Errorf("%s: got %s, expected %s", tc.name, describe(event.EventDetails), describe(tc.expected))
+		}
+
+		// Enriching twice adds nothing
+		before := len(event.EventDetails)
+		enricher.Enrich(event)
+		if len(event.EventDetails) != before {
+			t.Errorf("%s: enriched twice", tc.name)
+		}
+	}
+}
+
+// describe shows the details with their types
+func describe(details []events.EventDetail) string {
+	var s string
+	for _, detail := range details {
+		data, _ := json.Marshal(detail)
+		s += " " + detail.GetType() + string(data)
+	}
+	return "[" + s + " ]"
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "asn.mmdb")
+	writeDatabase(t, path, []mmdbtest.Network{asnNetwork("192.0.2.0/24", 64500, "Old Net")})
+	enricher, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asName := func() string {
+		event := &events.Event{IP: "192.0.2.1"}
+		enricher.Enrich(event)
+		for _, detail := range event.EventDetails {
+			if asn, ok := detail.(*events.ASN); ok {
+				return asn.ASName
+			}
+		}
+		return ""
+	}
+	if name := asName(); name != "Old Net" {
+		t.Fatalf("Expected Old Net, got %q", name)
+	}
+
+	// A broken update keeps the previous version
+	if err := os.WriteFile(path, []byte("partial download"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	enricher.Reload(context.Background())
+	if name := asName(); name != "Old Net" {
+		t.Errorf("Expected the previous database after a broken update, got %q", name)
+	}
+
+	writeDatabase(t, path, []mmdbtest.Network{asnNetwork("192.0.2.0/24", 64501, "New Net")})
+	// File systems with coarse timestamps may keep the modification time
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	enricher.Reload(context.Background())
+	if name := asName(); name != "New Net" {
+		t.Errorf("Expected New Net after the update, got %q", name)
+	}
+}
+
+func TestNew_Missing(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.mmdb")); err == nil {
+		t.Error("Expected an error for a missing database")
+	}
+}
@@ -0,0 +1,203 @@
+// Package logging sets up structured log/slog logging: JSON or text output,
+// a level per parser, correlation attributes carried in contexts and
+// redaction of email addresses and bodies.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Attribute keys shared by the registry and the commands
+const (
+	KeyEmail     = "email_id"
+	KeyParser    = "parser"
+	KeyOutcome   = "outcome"
+	KeyKafka     = "kafka"
+	KeyError     = "error"
+	KeyDuration  = "duration_ms"
+	KeyEvents    = "events"
+	redactedMark = "[redacted]"
+)
+
+// bodyKeys are attribute keys whose values are replaced by their size
+var bodyKeys = map[string]bool{"body": true, "raw": true, "content": true}
+
+// Config selects the output and levels of New
+type Config struct {
+	// Format is json (the default) or text
+	Format string
+	// Level is the minimum level of records
+	Level slog.Level
+	// ParserLevels overrides Level for records with a parser attribute
+	ParserLevels map[string]slog.Level
+}
+
+// New returns a logger writing to w
+func New(w io.Writer, cfg Config) (*slog.Logger, error) {
+	minLevel := cfg.Level
+	for _, level := range cfg.ParserLevels {
+		minLevel = min(minLevel, level)
+	}
+	options := &slog.HandlerOptions{Level: minLevel, ReplaceAttr: redactAttr}
+	var inner slog.Handler
+	switch cfg.Format {
+	case "", "json":
+		inner = slog.NewJSONHandler(w, options)
+	case "text":
+		inner = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q (json, text)", cfg.Format)
+	}
+	return slog.New(&handler{inner: inner, level: cfg.Level, parserLevels: cfg.ParserLevels}), nil
+}
+
+// Setup makes a logger writing to w the default of slog and of the log
+// package
+func Setup(w io.Writer, cfg Config) error {
+	logger, err := New(w, cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// ParseParserLevels parses parser levels such as "spamcop=debug,abusix=warn"
+func ParseParserLevels(spec string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parser, name, ok := strings.Cut(entry, "=")
+		if !ok || parser == "" {
+			return nil, fmt.Errorf("invalid parser level %q, expected parser=level", entry)
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("invalid level of parser %s: %w", parser, err)
+		}
+		levels[parser] = level
+	}
+	return levels, nil
+}
+
+type contextKey struct{}
+
+// WithAttrs returns a context whose records carry attrs in addition to those
+// of ctx, e.g. the email identifier and Kafka position
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	previous, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	combined := make([]slog.Attr, 0, len(previous)+len(attrs))
+	combined = append(append(combined, previous...), attrs...)
+	return context.WithValue(ctx, contextKey{}, combined)
+}
+
+// handler adds the context attributes and applies the parser levels
+type handler struct {
+	inner        slog.Handler
+	level        slog.Level
+	parserLevels map[string]slog.Level
+	// parser is set by a parser attribute of With
+	parser string
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.parser != "" && level < h.levelOf(h.parser) {
+		return false
+	}
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	parser := h.parser
+	if parser == "" {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == KeyParser {
+				parser = attr.Value.String()
+				return false
+			}
+			return true
+		})
+	}
+	if record.Level < h.levelOf(parser) {
+		return nil
+	}
+	if attrs, _ := ctx.Value(contextKey{}).([]slog.Attr); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.inner.Handle(ctx, record)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithAttrs(attrs)
+	for _, attr := range attrs {
+		if attr.Key == KeyParser {
+			clone.parser = attr.Value.String()
+		}
+	}
+	return &clone
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithGroup(name)
+	return &clone
+}
+
+func (h *handler) levelOf(parser string) slog.Level {
+	if level, ok := h.parserLevels[parser]; ok && parser != "" {
+		return level
+	}
+	return h.level
+}
+
+// emailPattern matches email addresses; the domain is kept
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)`)
+
+// Redact replaces the local part of email addresses in s
+func Redact(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, redactedMark+"@$1")
+}
+
+// redactAttr redacts the email addresses of string, error and Stringer
+// values and replaces bodies by their size
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if bodyKeys[attr.Key] {
+		return slog.String(attr.Key, fmt.Sprintf("%s %d bytes", redactedMark, bodySize(attr.Value)))
+	}
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(Redact(attr.Value.String()))
+	case slog.KindAny:
+		switch v := attr.Value.Any().(type) {
+		case error:
+			attr.Value = slog.StringValue(Redact(v.Error()))
+		case fmt.Stringer:
+			attr.Value = slog.StringValue(Redact(v.String()))
+		case []byte:
+			attr.Value = slog.StringValue(Redact(string(v)))
+		}
+	}
+	return attr
+}
+
+func bodySize(value slog.Value) int {
+	if value.Kind() == slog.KindAny {
+		if b, ok := value.Any().([]byte); ok {
+			return len(b)
+		}
+	}
+	return len(value.String())
+}
@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("Report from abuse@example.com",
+		"from", "Jane <jane.doe+x@mail.example.org>",
+		KeyError, errors.New("no match for spam@example.net"),
+		"body", "Dear abuse team, ...")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	expected := map[string]string{
+		"msg":    "Report from [redacted]@example.com",
+		"from":   "Jane <[redacted]@mail.example.org>",
+		KeyError: "no match for [redacted]@example.net",
+		"body":   "[redacted] 20 bytes",
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("%s: got %q, expected %q", key, record[key], value)
+		}
+	}
+}
+
+func TestParserLevels(t *testing.T) {
+	var buf bytes.Buffer
+	levels, err := ParseParserLevels("spamcop=debug, abusix=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, err := New(&buf, Config{Level: slog.LevelInfo, ParserLevels: levels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("kept", KeyParser, "spamcop")
+	logger.Debug("dropped", KeyParser, "other")
+	logger.Debug("dropped")
+	logger.Warn("dropped", KeyParser, "abusix")
+	logger.With(KeyParser, "spamcop").Debug("kept")
+	logger.Info("kept")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d: %s", len(records), buf.String())
+	}
+	for _, record := range records {
+		if record["msg"] != "kept" {
+			t.Errorf("Unexpected record %v", record)
+		}
+	}
+
+	if _, err := ParseParserLevels("spamcop"); err == nil {
+		t.Error("Expected an error for a missing level")
+	}
+	if _, err := ParseParserLevels("spamcop=loud"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
+func TestWithAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{Format: "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithAttrs(context.Background(), slog.String(KeyEmail, "mail-1"))
+	ctx = WithAttrs(ctx, slog.Group(KeyKafka, slog.Int64("offset", 42)))
+	logger.InfoContext(ctx, "Parsed", KeyParser, "spamcop")
+	logger.Info("Uncorrelated")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0][KeyEmail] != "mail-1" || records[0][KeyParser] != "spamcop" {
+		t.Errorf("Missing correlation attributes: %v", records[0])
+	}
+	if kafka, _ := records[0][KeyKafka].(map[string]interface{}); kafka["offset"] != float64(42) {
+		t.Errorf("Missing Kafka offset: %v", records[0])
+	}
+	if _, ok := records[1][KeyEmail]; ok {
+		t.Errorf("Unexpected email attribute: %v", records[1])
+	}
+
+	if _, err := New(&buf, Config{Format: "xml"}); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,338 @@
+// Package metrics implements the Prometheus text exposition format for
+// counters, gauges and histograms with labels, without client dependencies.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefBuckets are histogram buckets for durations in seconds
+var DefBuckets = []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5, 10, 30}
+
+// ExponentialBuckets returns count buckets starting at start, each factor
+// times the previous one
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// collector is a metric family
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds metric families and writes them in registration order
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+	names      map[string]bool
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+func (r *Registry) register(name string, c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.names[name] = true
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteTo writes all metrics in the text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	counter := &countingWriter{w: w}
+	buffered := bufio.NewWriter(counter)
+	for _, c := range collectors {
+		c.write(buffered)
+	}
+	err := buffered.Flush()
+	return counter.n, err
+}
+
+// ServeHTTP serves the metrics for scraping
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = r.WriteTo(w)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// family holds the series of one metric keyed by their label values
+type family[T any] struct {
+	name, help, kind string
+	labels           []string
+	mu               sync.RWMutex
+	series           map[string]*labeled[T]
+	create           func() *T
+}
+
+type labeled[T any] struct {
+	values []string
+	metric *T
+}
+
+func newFamily[T any](name, help, kind string, labels []string, create func() *T) *family[T] {
+	return &family[T]{name: name, help: help, kind: kind, labels: labels, series: make(map[string]*labeled[T]), create: create}
+}
+
+func (f *family[T]) with(values []string) *T {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.RLock()
+	series, ok := f.series[key]
+	f.mu.RUnlock()
+	if ok {
+		return series.metric
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if series, ok := f.series[key]; ok {
+		return series.metric
+	}
+	series = &labeled[T]{values: append([]string(nil), values...), metric: f.create()}
+	f.series[key] = series
+	return series.metric
+}
+
+// sorted returns the series ordered by label values
+func (f *family[T]) sorted() []*labeled[T] {
+	f.mu.RLock()
+	list := make([]*labeled[T], 0, len(f.series))
+	for _, series := range f.series {
+		list = append(list, series)
+	}
+	f.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i].values, list[j].values
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return list
+}
+
+func (f *family[T]) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind)
+}
+
+// value is a float64 updated atomically
+type value struct{ bits atomic.Uint64 }
+
+func (v *value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		if v.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (v *value) get() float64 {
+	return math.Float64frombits(v.bits.Load())
+}
+
+// Counter is a monotonically increasing value
+type Counter struct{ v value }
+
+// Inc adds 1
+func (c *Counter) Inc() { c.v.add(1) }
+
+// Add adds a non-negative delta
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	c.v.add(delta)
+}
+
+// Value returns the current count
+func (c *Counter) Value() float64 { return c.v.get() }
+
+// CounterVec is a counter with labels
+type CounterVec struct{ f *family[Counter] }
+
+// NewCounterVec registers a counter; without labels use WithLabelValues()
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{f: newFamily(name, help, "counter", labels, func() *Counter { return &Counter{} })}
+	r.register(name, c)
+	return c
+}
+
+// WithLabelValues returns the counter of the label values, in label order
+func (c *CounterVec) WithLabelValues(values ...string) *Counter {
+	return c.f.with(values)
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.f.writeHeader(w)
+	for _, series := range c.f.sorted() {
+		writeSample(w, c.f.name, c.f.labels, series.values, "", "", series.metric.Value())
+	}
+}
+
+// GaugeFunc is a gauge read from a function at scrape time
+type GaugeFunc struct {
+	name, help string
+	fn         func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is fn's result
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help, fn: fn}
+	r.register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, escapeHelp(g.help), g.name)
+	writeSample(w, g.name, nil, nil, "", "", g.fn())
+}
+
+// Histogram counts observations in cumulative buckets
+type Histogram struct {
+	upper  []float64
+	counts []atomic.Uint64
+	count  atomic.Uint64
+	sum    value
+}
+
+// Observe adds an observation
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.upper, v)
+	if i < len(h.counts) {
+		h.counts[i].Add(1)
+	}
+	h.count.Add(1)
+	h.sum.add(v)
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() uint64 { return h.count.Load() }
+
+// HistogramVec is a histogram with labels
+type HistogramVec struct{ f *family[Histogram] }
+
+// NewHistogramVec registers a histogram with the given upper bucket bounds
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	upper := append([]float64(nil), buckets...)
+	sort.Float64s(upper)
+	h := &HistogramVec{f: newFamily(name, help, "histogram", labels, func() *Histogram {
+		return &Histogram{upper: upper, counts: make([]atomic.Uint64, len(upper))}
+	})}
+	r.register(name, h)
+	return h
+}
+
+// WithLabelValues returns the histogram of the label values, in label order
+func (h *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	return h.f.with(values)
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.f.writeHeader(w)
+	for _, series := range h.f.sorted() {
+		histogram := series.metric
+		var cumulative uint64
+		for i, upper := range histogram.upper {
+			cumulative += histogram.counts[i].Load()
+			writeSample(w, h.f.name+"_bucket", h.f.labels, series.values, "le", formatFloat(upper), float64(cumulative))
+		}
+		count := histogram.count.Load()
+		writeSample(w, h.f.name+"_bucket", h.f.labels, series.values, "le", "+Inf", float64(count))
+		writeSample(w, h.f.name+"_sum", h.f.labels, series.values, "", "", histogram.sum.get())
+		writeSample(w, h.f.name+"_count", h.f.labels, series.values, "", "", float64(count))
+	}
+}
+
+// writeSample writes one sample line; extraName adds a label such as le
+func writeSample(w *bufio.Writer, name string, labels, values []string, extraName, extraValue string, v float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label)
+			w.WriteString(`="`)
+			w.WriteString(escapeLabel(values[i]))
+			w.WriteByte('"')
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName)
+			w.WriteString(`="`)
+			w.WriteString(extraValue)
+			w.WriteByte('"')
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
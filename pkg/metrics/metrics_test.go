@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_Exposition(t *testing.T) {
+	registry := NewRegistry()
+	attempts := registry.NewCounterVec("parser_attempts_total", "Parser runs.", "parser")
+	attempts.WithLabelValues("spamcop").Add(2)
+	attempts.WithLabelValues("abusix").Inc()
+	attempts.WithLabelValues(`we"ird\`).Inc()
+	registry.NewGaugeFunc("quarantined_parsers", "Quarantined parsers.", func() float64 { return 1 })
+	durations := registry.NewHistogramVec("duration_seconds", "Parse duration.", []float64{1, 0.1}, "parser")
+	durations.WithLabelValues("spamcop").Observe(0.05)
+	durations.WithLabelValues("spamcop").Observe(0.5)
+	durations.WithLabelValues("spamcop").Observe(3)
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+
+	expected := `# HELP parser_attempts_total Parser runs.
+# TYPE parser_attempts_total counter
+parser_attempts_total{parser="abusix"} 1
+parser_attempts_total{parser="spamcop"} 2
+parser_attempts_total{parser="we\"ird\\"} 1
+# HELP quarantined_parsers Quarantined parsers.
+# TYPE quarantined_parsers gauge
+quarantined_parsers 1
+# HELP duration_seconds Parse duration.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{parser="spamcop",le="0.1"} 1
+duration_seconds_bucket{parser="spamcop",le="1"} 2
+duration_seconds_bucket{parser="spamcop",le="+Inf"} 3
+duration_seconds_sum{parser="spamcop"} 3.55
+duration_seconds_count{parser="spamcop"} 3
+`
+	if got := recorder.Body.String(); got != expected {
+		t.Errorf("Unexpected exposition:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRegistry_DuplicateAndLabelCount(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounterVec("events_total", "Events.", "parser", "type")
+	for _, fn := range []func(){
+		func() { registry.NewCounterVec("events_total", "Again.") },
+		func() { counter.WithLabelValues("spamcop") },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(r.(string), "metrics:") {
+					t.Errorf("Expected a metrics panic, got %v", r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,210 @@
+package mmdb
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Data section types
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+// maxDepth bounds the nesting of maps, arrays and pointers, so that
+// corrupt files with pointer loops fail instead of recursing forever
+const maxDepth = 64
+
+// decoder decodes values of a data section; pointers are offsets into it
+type decoder struct {
+	buffer []byte
+}
+
+func (d *decoder) decode(offset uint, depth int) (interface{}, uint, error) {
+	if depth > maxDepth {
+		return nil, 0, fmt.Errorf("%w: data nested too deeply", ErrInvalidDatabase)
+	}
+	if offset >= uint(len(d.buffer)) {
+		return nil, 0, fmt.Errorf("%w: data offset %d beyond the section", ErrInvalidDatabase, offset)
+	}
+	control := d.buffer[offset]
+	offset++
+	kind := uint(control >> 5)
+
+	if kind == typePointer {
+		pointer, next, err := d.pointer(control, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := d.decode(pointer, depth+1)
+		return value, next, err
+	}
+	if kind == typeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return nil, 0, fmt.Errorf("%w: truncated extended type", ErrInvalidDatabase)
+		}
+		kind = 7 + uint(d.buffer[offset])
+		offset++
+	}
+	size, offset, err := d.size(control, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch kind {
+	case typeMap:
+		return d.decodeMap(size, offset, depth)
+	case typeArray:
+		return d.decodeArray(size, offset, depth)
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	end := offset + size
+	if end > uint(len(d.buffer)) || end < offset {
+		return nil, 0, fmt.Errorf("%w: value of %d bytes beyond the section", ErrInvalidDatabase, size)
+	}
+	b := d.buffer[offset:end]
+	switch kind {
+	case typeString:
+		return string(b), end, nil
+	case typeBytes:
+		return append([]byte(nil), b...), end, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double of %d bytes", ErrInvalidDatabase, size)
+		}
+		return math.Float64frombits(uintOf(b)), end, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float of %d bytes", ErrInvalidDatabase, size)
+		}
+		return math.Float32frombits(uint32(uintOf(b))), end, nil
+	case typeUint16, typeUint32, typeUint64:
+		limit := uint(8)
+		switch kind {
+		case typeUint16:
+			limit = 2
+		case typeUint32:
+			limit = 4
+		}
+		if size > limit {
+			return nil, 0, fmt.Errorf("%w: unsigned integer of %d bytes", ErrInvalidDatabase, size)
+		}
+		return uintOf(b), end, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: int32 of %d bytes", ErrInvalidDatabase, size)
+		}
+		return int32(uint32(uintOf(b))), end, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("%w: uint128 of %d bytes", ErrInvalidDatabase, size)
+		}
+		return new(big.Int).SetBytes(b), end, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unknown data type %d", ErrInvalidDatabase, kind)
+}
+
+func (d *decoder) decodeMap(size, offset uint, depth int) (interface{}, uint, error) {
+	m := make(map[string]interface{}, min(size, 64))
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: map key is not a string", ErrInvalidDatabase)
+		}
+		value, next, err := d.decode(next, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		m[name] = value
+		offset = next
+	}
+	return m, offset, nil
+}
+
+func (d *decoder) decodeArray(size, offset uint, depth int) (interface{}, uint, error) {
+	array := make([]interface{}, 0, min(size, 64))
+	for i := uint(0); i < size; i++ {
+		value, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		array = append(array, value)
+		offset = next
+	}
+	return array, offset, nil
+}
+
+// size reads the payload size of a control byte and its extra bytes
+func (d *decoder) size(control byte, offset uint) (uint, uint, error) {
+	size := uint(control & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+	extra := size - 28
+	if offset+extra > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%w: truncated size", ErrInvalidDatabase)
+	}
+	value := uint(uintOf(d.buffer[offset : offset+extra]))
+	switch size {
+	case 29:
+		size = 29 + value
+	case 30:
+		size = 285 + value
+	default:
+		size = 65821 + value
+	}
+	return size, offset + extra, nil
+}
+
+// pointer reads the target of a pointer and returns it with the offset
+// after the pointer
+func (d *decoder) pointer(control byte, offset uint) (uint, uint, error) {
+	length := uint(control>>3)&0x3 + 1
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%w: truncated pointer", ErrInvalidDatabase)
+	}
+	b := d.buffer[offset : offset+length]
+	high := uint(control & 0x7)
+	var pointer uint
+	switch length {
+	case 1:
+		pointer = high<<8 | uint(b[0])
+	case 2:
+		pointer = (high<<16 | uint(uintOf(b))) + 2048
+	case 3:
+		pointer = (high<<24 | uint(uintOf(b))) + 526336
+	default:
+		pointer = uint(uintOf(b))
+	}
+	return pointer, offset + length, nil
+}
+
+// uintOf reads a big-endian unsigned integer of up to 8 bytes
+func uintOf(b []byte) uint64 {
+	var value uint64
+	for _, c := range b {
+		value = value<<8 | uint64(c)
+	}
+	return value
+}
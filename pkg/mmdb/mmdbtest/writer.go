@@ -0,0 +1,273 @@
+// Package mmdbtest builds small MaxMind DB files for tests.
+package mmdbtest
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+// Network is a network of a test database and its data: maps, slices,
+// strings, uint32 and uint64 values
+type Network struct {
+	Prefix netip.Prefix
+	Data   interface{}
+}
+
+// Options are the layout of a test database
+type Options struct {
+	DatabaseType string
+	// RecordSize is 24 (the default), 28 or 32
+	RecordSize int
+	// IPVersion is 6 (the default), with IPv4 networks below ::/96, or 4
+	IPVersion int
+}
+
+type node struct {
+	children [2]*node
+	// data is the index of the data of each branch, -1 for none
+	data [2]int
+	id   int
+}
+
+// Build returns a database of the networks. Identical strings are written
+// once and referenced by pointers, like MaxMind's writer does.
+func Build(networks []Network, options Options) ([]byte, error) {
+	if options.RecordSize == 0 {
+		options.RecordSize = 24
+	}
+	if options.IPVersion == 0 {
+		options.IPVersion = 6
+	}
+	if options.DatabaseType == "" {
+		options.DatabaseType = "Test"
+	}
+
+	root := newNode()
+	for i, network := range networks {
+		bits, length, err := prefixBits(network.Prefix, options.IPVersion)
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			return nil, fmt.Errorf("network %s has no prefix bits", network.Prefix)
+		}
+		current := root
+		for depth := 0; depth < length-1; depth++ {
+			bit := bits[depth]
+			if current.children[bit] == nil {
+				current.children[bit] = newNode()
+			}
+			current = current.children[bit]
+		}
+		current.data[bits[length-1]] = i
+	}
+
+	// Number the nodes breadth first, the root being 0
+	var nodes []*node
+	queue := []*node{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		current.id = len(nodes)
+		nodes = append(nodes, current)
+		for _, child := range current.children {
+			if child != nil {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	enc := &encoder{strings: make(map[string]int)}
+	offsets := make([]int, len(networks))
+	for i, network := range networks {
+		offsets[i] = enc.buf.Len()
+		if err := enc.encode(network.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeCount := len(nodes)
+	var tree bytes.Buffer
+	for _, current := range nodes {
+		var records [2]int
+		for bit := range records {
+			switch {
+			case current.children[bit] != nil:
+				records[bit] = current.children[bit].id
+			case current.data[bit] >= 0:
+				records[bit] = nodeCount + 16 + offsets[current.data[bit]]
+			default:
+				records[bit] = nodeCount
+			}
+		}
+		writeNode(&tree, records, options.RecordSize)
+	}
+
+	var out bytes.Buffer
+	out.Write(tree.Bytes())
+	out.Write(make([]byte, 16))
+	out.Write(enc.buf.Bytes())
+	out.WriteString("\xAB\xCD\xEFMaxMind.com")
+	meta := &encoder{strings: make(map[string]int)}
+	err := meta.encode(map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(1700000000),
+		"database_type":               options.DatabaseType,
+		"ip_version":                  uint16(options.IPVersion),
+		"languages":                   []interface{}{"en"},
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(options.RecordSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out.Write(meta.buf.Bytes())
+	return out.Bytes(), nil
+}
+
+func newNode() *node {
+	return &node{data: [2]int{-1, -1}}
+}
+
+// prefixBits returns the address bits of a prefix in the tree of the IP
+// version and the prefix length in that tree
+func prefixBits(prefix netip.Prefix, ipVersion int) ([]int, int, error) {
+	addr := prefix.Masked().Addr()
+	length := prefix.Bits()
+	var ip []byte
+	switch {
+	case addr.Is4() && ipVersion == 4:
+		ip4 := addr.As4()
+		ip = ip4[:]
+	case addr.Is4():
+		ip16 := addr.As16()
+		// As16 maps IPv4 to ::ffff:0:0/96; the tree has it below ::/96
+		ip16[10], ip16[11] = 0, 0
+		ip = ip16[:]
+		length += 96
+	case ipVersion == 4:
+		return nil, 0, fmt.Errorf("IPv6 network %s in an IPv4 database", prefix)
+	default:
+		ip16 := addr.As16()
+		ip = ip16[:]
+	}
+	bits := make([]int, len(ip)*8)
+	for i := range bits {
+		bits[i] = int(ip[i/8]>>(7-uint(i%8))) & 1
+	}
+	return bits, length, nil
+}
+
+func writeNode(w *bytes.Buffer, records [2]int, recordSize int) {
+	left, right := uint32(records[0]), uint32(records[1])
+	switch recordSize {
+	case 24:
+		w.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left), byte(right >> 16), byte(right >> 8), byte(right)})
+	case 28:
+		w.Write([]byte{byte(left >> 16), byte(left >> 8), byte(left),
+			byte(left>>20)&0xF0 | byte(right>>24)&0x0F,
+			byte(right >> 16), byte(right >> 8), byte(right)})
+	default:
+		w.Write([]byte{byte(left >> 24), byte(left >> 16), byte(left >> 8), byte(left),
+			byte(right >> 24), byte(right >> 16), byte(right >> 8), byte(right)})
+	}
+}
+
+// encoder writes values of the data section
+type encoder struct {
+	buf     bytes.Buffer
+	strings map[string]int
+}
+
+func (e *encoder) encode(value interface{}) error {
+	switch value := value.(type) {
+	case string:
+		if offset, ok := e.strings[value]; ok {
+			e.pointer(offset)
+			return nil
+		}
+		e.strings[value] = e.buf.Len()
+		e.control(2, len(value))
+		e.buf.WriteString(value)
+	case uint16:
+		e.uint(5, uint64(value))
+	case uint32:
+		e.uint(6, uint64(value))
+	case uint64:
+		e.uint(9, value)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		e.control(7, len(keys))
+		for _, key := range keys {
+			if err := e.encode(key); err != nil {
+				return err
+			}
+			if err := e.encode(value[key]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		e.control(11, len(value))
+		for _, item := range value {
+			if err := e.encode(item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported data type %T", value)
+	}
+	return nil
+}
+
+// control writes the control byte of a type with its payload size
+func (e *encoder) control(kind, size int) {
+	first := byte(kind << 5)
+	var extended []byte
+	if kind > 7 {
+		first = 0
+		extended = []byte{byte(kind - 7)}
+	}
+	switch {
+	case size < 29:
+		e.buf.WriteByte(first | byte(size))
+		e.buf.Write(extended)
+	case size < 285:
+		e.buf.WriteByte(first | 29)
+		e.buf.Write(extended)
+		e.buf.WriteByte(byte(size - 29))
+	default:
+		size -= 285
+		e.buf.WriteByte(first | 30)
+		e.buf.Write(extended)
+		e.buf.Write([]byte{byte(size >> 8), byte(size)})
+	}
+}
+
+func (e *encoder) uint(kind int, value uint64) {
+	var b []byte
+	for ; value > 0; value >>= 8 {
+		b = append([]byte{byte(value)}, b...)
+	}
+	e.control(kind, len(b))
+	e.buf.Write(b)
+}
+
+func (e *encoder) pointer(offset int) {
+	switch {
+	case offset < 2048:
+		e.buf.Write([]byte{0x20 | byte(offset>>8)&0x7, byte(offset)})
+	case offset < 526336:
+		offset -= 2048
+		e.buf.Write([]byte{0x28 | byte(offset>>16)&0x7, byte(offset >> 8), byte(offset)})
+	default:
+		offset -= 526336
+		e.buf.Write([]byte{0x30 | byte(offset>>24)&0x7, byte(offset >> 16), byte(offset >> 8), byte(offset)})
+	}
+}
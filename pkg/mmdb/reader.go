@@ -0,0 +1,205 @@
+// Package mmdb reads MaxMind DB files, the format of the GeoLite2 databases
+// and of most ip-to-asn databases, without external dependencies.
+package mmdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker starts the metadata at the end of the file
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// maxMetadataSize is the part of the file end searched for the metadata
+const maxMetadataSize = 128 << 10
+
+// dataSeparator is the size of the zero bytes between tree and data
+const dataSeparator = 16
+
+// ErrInvalidDatabase is returned for files that are not valid MaxMind DBs
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB")
+
+// Metadata describes a database
+type Metadata struct {
+	DatabaseType string
+	IPVersion    uint
+	NodeCount    uint
+	RecordSize   uint
+	BuildEpoch   uint64
+	Languages    []string
+}
+
+// Reader looks up networks in a database held in memory; it is safe for
+// concurrent use
+type Reader struct {
+	Metadata     Metadata
+	tree         []byte
+	data         decoder
+	nodeByteSize uint
+	ipv4Start    uint
+}
+
+// Open reads a database file
+func Open(path string) (*Reader, error) {
+	buffer, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(buffer)
+}
+
+// New returns a reader of a database in buffer
+func New(buffer []byte) (*Reader, error) {
+	searchStart := max(len(buffer)-maxMetadataSize, 0)
+	index := bytes.LastIndex(buffer[searchStart:], metadataMarker)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidDatabase)
+	}
+	metadataStart := searchStart + index
+	metadata, err := decodeMetadata(buffer[metadataStart+len(metadataMarker):])
+	if err != nil {
+		return nil, err
+	}
+
+	switch metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, metadata.RecordSize)
+	}
+	if metadata.IPVersion != 4 && metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", ErrInvalidDatabase, metadata.IPVersion)
+	}
+	nodeByteSize := metadata.RecordSize / 4
+	treeSize := metadata.NodeCount * nodeByteSize
+	if metadata.NodeCount == 0 || treeSize+dataSeparator > uint(metadataStart) {
+		return nil, fmt.Errorf("%w: search tree exceeds the file", ErrInvalidDatabase)
+	}
+
+	r := &Reader{
+		Metadata:     metadata,
+		tree:         buffer[:treeSize],
+		data:         decoder{buffer: buffer[treeSize+dataSeparator : metadataStart]},
+		nodeByteSize: nodeByteSize,
+	}
+	if metadata.IPVersion == 6 {
+		// IPv4 addresses are looked up below ::/96
+		node := uint(0)
+		for i := 0; i < 96 && node < metadata.NodeCount; i++ {
+			node = r.record(node, 0)
+		}
+		r.ipv4Start = node
+	}
+	return r, nil
+}
+
+func decodeMetadata(buffer []byte) (Metadata, error) {
+	value, _, err := (&decoder{buffer: buffer}).decode(0, 0)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("%w: metadata: %v", ErrInvalidDatabase, err)
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return Metadata{}, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+	metadata := Metadata{
+		NodeCount:  uint(toUint(fields["node_count"])),
+		RecordSize: uint(toUint(fields["record_size"])),
+		IPVersion:  uint(toUint(fields["ip_version"])),
+		BuildEpoch: toUint(fields["build_epoch"]),
+	}
+	metadata.DatabaseType, _ = fields["database_type"].(string)
+	if languages, ok := fields["languages"].([]interface{}); ok {
+		for _, language := range languages {
+			if language, ok := language.(string); ok {
+				metadata.Languages = append(metadata.Languages, language)
+			}
+		}
+	}
+	return metadata, nil
+}
+
+func toUint(value interface{}) uint64 {
+	switch value := value.(type) {
+	case uint64:
+		return value
+	case int32:
+		if value > 0 {
+			return uint64(value)
+		}
+	}
+	return 0
+}
+
+// Lookup returns the data of the network containing addr; found is false if
+// the database has no data for it
+func (r *Reader) Lookup(addr netip.Addr) (value interface{}, found bool, err error) {
+	offset, found, err := r.LookupOffset(addr)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	value, err = r.Decode(offset)
+	return value, err == nil, err
+}
+
+// LookupOffset returns the offset of the data of the network containing
+// addr. Networks sharing data share the offset, so it can key a cache.
+func (r *Reader) LookupOffset(addr netip.Addr) (offset uint, found bool, err error) {
+	addr = addr.Unmap()
+	node := uint(0)
+	var ip []byte
+	if addr.Is4() {
+		ip4 := addr.As4()
+		ip = ip4[:]
+		node = r.ipv4Start
+	} else {
+		if r.Metadata.IPVersion == 4 {
+			return 0, false, nil
+		}
+		ip16 := addr.As16()
+		ip = ip16[:]
+	}
+
+	nodeCount := r.Metadata.NodeCount
+	for i := 0; i < len(ip)*8 && node < nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i&7))) & 1
+		node = r.record(node, bit)
+	}
+	switch {
+	case node == nodeCount:
+		return 0, false, nil
+	case node > nodeCount:
+		offset = node - nodeCount - dataSeparator
+		if offset >= uint(len(r.data.buffer)) {
+			return 0, false, fmt.Errorf("%w: data pointer beyond the data section", ErrInvalidDatabase)
+		}
+		return offset, true, nil
+	}
+	return 0, false, fmt.Errorf("%w: search tree deeper than the address", ErrInvalidDatabase)
+}
+
+// Decode returns the data at an offset of LookupOffset: maps, slices,
+// strings, []byte, bools, float64, float32, int32, uint64 or *big.Int
+func (r *Reader) Decode(offset uint) (interface{}, error) {
+	value, _, err := r.data.decode(offset, 0)
+	return value, err
+}
+
+// record returns the left (bit 0) or right record of a node
+func (r *Reader) record(node, bit uint) uint {
+	b := r.tree[node*r.nodeByteSize : (node+1)*r.nodeByteSize]
+	switch r.Metadata.RecordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	}
+	b = b[bit*4:]
+	return uint(b[0])<<24 | uint(b[1])<<16 | uint(b[2])<<8 | uint(b[3])
+}
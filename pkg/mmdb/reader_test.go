@@ -0,0 +1,100 @@
+package mmdb
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/abusix/inbound-parsers/pkg/mmdb/mmdbtest"
+)
+
+var testNetworks = []mmdbtest.Network{
+	{Prefix: netip.MustParsePrefix("192.0.2.0/24"), Data: map[string]interface{}{
+		"autonomous_system_number":       uint32(64500),
+		"autonomous_system_organization": "Example Net",
+	}},
+	{Prefix: netip.MustParsePrefix("198.51.100.128/25"), Data: map[string]interface{}{
+		"autonomous_system_number":       uint32(64501),
+		"autonomous_system_organization": "Example Net",
+		"networks":                       []interface{}{"a", "b"},
+	}},
+	{Prefix: netip.MustParsePrefix("2001:db8::/32"), Data: map[string]interface{}{
+		"country": map[string]interface{}{"iso_code": "DE"},
+	}},
+}
+
+func TestLookup(t *testing.T) {
+	for _, recordSize := range []int{24, 28, 32} {
+		buffer, err := mmdbtest.Build(testNetworks, mmdbtest.Options{RecordSize: recordSize})
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader, err := New(buffer)
+		if err != nil {
+			t.Fatalf("record size %d: %v", recordSize, err)
+		}
+		if reader.Metadata.RecordSize != uint(recordSize) || reader.Metadata.IPVersion != 6 {
+			t.Errorf("Unexpected metadata %+v", reader.Metadata)
+		}
+
+		for _, tc := range []struct {
+			addr     string
+			expected interface{}
+		}{
+			{"192.0.2.77", map[string]interface{}{"autonomous_system_number": uint64(64500), "autonomous_system_organization": "Example Net"}},
+			{"::ffff:192.0.2.1", map[string]interface{}{"autonomous_system_number": uint64(64500), "autonomous_system_organization": "Example Net"}},
+			{"198.51.100.200", map[string]interface{}{"autonomous_system_number": uint64(64501), "autonomous_system_organization": "Example Net", "networks": []interface{}{"a", "b"}}},
+			{"198.51.100.1", nil},
+			{"203.0.113.1", nil},
+			{"2001:db8:1::1", map[string]interface{}{"country": map[string]interface{}{"iso_code": "DE"}}},
+			{"2001:db9::1", nil},
+		} {
+			value, found, err := reader.Lookup(netip.MustParseAddr(tc.addr))
+			if err != nil {
+				t.Fatalf("record size %d, %s: %v", recordSize, tc.addr, err)
+			}
+			if found != (tc.expected != nil) || !reflect.DeepEqual(value, tc.expected) {
+				t.Errorf("record size %d, %s: got %v (found %t), expected %v", recordSize, tc.addr, value, found, tc.expected)
+			}
+		}
+	}
+}
+
+func TestLookup_IPv4Database(t *testing.T) {
+	buffer, err := mmdbtest.Build(testNetworks[:2], mmdbtest.Options{IPVersion: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := New(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, found, err := reader.LookupOffset(netip.MustParseAddr("192.0.2.1"))
+	if err != nil || !found {
+		t.Fatalf("Expected 192.0.2.1 to be found: %v", err)
+	}
+	second, _, _ := reader.LookupOffset(netip.MustParseAddr("192.0.2.254"))
+	if first != second {
+		t.Errorf("Expected one offset for a network, got %d and %d", first, second)
+	}
+	if _, found, err := reader.Lookup(netip.MustParseAddr("2001:db8::1")); found || err != nil {
+		t.Errorf("Expected IPv6 addresses not to be found, got %t, %v", found, err)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	buffer, err := mmdbtest.Build(testNetworks, mmdbtest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, corrupt := range map[string][]byte{
+		"empty":     nil,
+		"no marker": []byte("not a database"),
+		"truncated": buffer[len(buffer)-60:],
+	} {
+		if _, err := New(corrupt); !errors.Is(err, ErrInvalidDatabase) {
+			t.Errorf("%s: expected ErrInvalidDatabase, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package parity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// maxExamples is the number of deviations kept per sample for reports
+const maxExamples = 10
+
+// maxGreedyPairs bounds the pairwise comparisons of unmatched events; larger
+// leftovers are paired in order
+const maxGreedyPairs = 1 << 20
+
+// Status is the parity status of a sample
+type Status string
+
+const (
+	StatusMatch    Status = "match"
+	StatusAccepted Status = "accepted"
+	StatusMismatch Status = "mismatch"
+	StatusError    Status = "error"
+)
+
+// Deviation is a difference between the Python and the Go output of a sample
+type Deviation struct {
+	// Event is the index of the Python event; -1 for sample level deviations
+	// and events only Go produced
+	Event  int    `json:"event"`
+	Field  string `json:"field"`
+	Python string `json:"python"`
+	Go     string `json:"go"`
+}
+
+// Result is the comparison of one sample
+type Result struct {
+	Sample string `json:"sample"`
+	// Parser is the Python parser of the sample, else the Go parser
+	Parser        string `json:"parser"`
+	GoParser      string `json:"go_parser,omitempty"`
+	PythonEvents  int    `json:"python_events"`
+	GoEvents      int    `json:"go_events"`
+	MatchedEvents int    `json:"matched_events"`
+	// Deviations holds the first deviations that are not allowlisted
+	Deviations     []Deviation    `json:"deviations,omitempty"`
+	DeviationCount int            `json:"deviation_count"`
+	Fields         map[string]int `json:"fields,omitempty"`
+	// Accepted counts the allowlisted deviations per field
+	Accepted map[string]int `json:"accepted,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// Status returns the parity status of the sample
+func (r *Result) Status() Status {
+	switch {
+	case r.Error != "":
+		return StatusError
+	case r.DeviationCount > 0:
+		return StatusMismatch
+	case len(r.Accepted) > 0:
+		return StatusAccepted
+	}
+	return StatusMatch
+}
+
+func (r *Result) add(allowlist Allowlist, deviation Deviation) {
+	if allowlist.accepts(r.Parser, r.Sample, deviation.Field) {
+		if r.Accepted == nil {
+			r.Accepted = make(map[string]int)
+		}
+		r.Accepted[deviation.Field]++
+		return
+	}
+	if r.Fields == nil {
+		r.Fields = make(map[string]int)
+	}
+	r.Fields[deviation.Field]++
+	r.DeviationCount++
+	if len(r.Deviations) < maxExamples {
+		r.Deviations = append(r.Deviations, deviation)
+	}
+}
+
+// Compare compares the normalized Go events of a sample (see FromGo and
+// FromGoJSON) with its Python output
+func Compare(sample string, python *PythonOutput, goNormalized []Event, allowlist Allowlist) *Result {
+	goParser := ""
+	if len(goNormalized) > 0 {
+		goParser = goNormalized[0]["parser"]
+	}
+	return compare(sample, python, goParser, goNormalized, allowlist)
+}
+
+// CompareDeclined compares the Python output of a sample that the Go parser
+// goParser ignored or rejected, which matches if Python named the same
+// parser and has no events either
+func CompareDeclined(sample string, python *PythonOutput, goParser string, allowlist Allowlist) *Result {
+	return compare(sample, python, goParser, nil, allowlist)
+}
+
+func compare(sample string, python *PythonOutput, goParser string, goNormalized []Event, allowlist Allowlist) *Result {
+	pythonNormalized := python.Events()
+
+	result := &Result{
+		Sample:       sample,
+		Parser:       python.Parser(),
+		PythonEvents: len(pythonNormalized),
+		GoEvents:     len(goNormalized),
+		GoParser:     goParser,
+	}
+	if result.Parser == "" {
+		result.Parser = result.GoParser
+	}
+	if python.Parser() != result.GoParser {
+		result.add(allowlist, Deviation{Event: -1, Field: FieldSampleParser, Python: python.Parser(), Go: result.GoParser})
+	}
+
+	pairs, missing, extra := match(pythonNormalized, goNormalized)
+	for _, pair := range pairs {
+		pythonEvent, goEvent := pythonNormalized[pair[0]], goNormalized[pair[1]]
+		before := result.DeviationCount
+		for _, field := range pythonEvent.diff(goEvent) {
+			result.add(allowlist, Deviation{Event: pair[0], Field: field, Python: pythonEvent[field], Go: goEvent[field]})
+		}
+		if result.DeviationCount == before {
+			result.MatchedEvents++
+		}
+	}
+	for _, i := range missing {
+		result.add(allowlist, Deviation{Event: i, Field: FieldEventCount, Python: pythonNormalized[i].describe()})
+	}
+	for _, i := range extra {
+		result.add(allowlist, Deviation{Event: -1, Field: FieldEventCount, Go: goNormalized[i].describe()})
+	}
+	return result
+}
+
+// match pairs Python and Go events: identical events first, then the
+// remaining events by ascending number of differing fields.
+// It returns the index pairs and the unpaired indices of both sides.
+func match(python, goEvents []Event) (pairs [][2]int, missing, extra []int) {
+	byKey := make(map[string][]int)
+	for i, event := range goEvents {
+		byKey[event.key()] = append(byKey[event.key()], i)
+	}
+	used := make([]bool, len(goEvents))
+	var leftPython []int
+	for i, event := range python {
+		candidates := byKey[event.key()]
+		if len(candidates) == 0 {
+			leftPython = append(leftPython, i)
+			continue
+		}
+		pairs = append(pairs, [2]int{i, candidates[0]})
+		used[candidates[0]] = true
+		byKey[event.key()] = candidates[1:]
+	}
+	var leftGo []int
+	for i := range goEvents {
+		if !used[i] {
+			leftGo = append(leftGo, i)
+		}
+	}
+
+	if len(leftPython)*len(leftGo) > maxGreedyPairs {
+		for n := 0; n < len(leftPython) && n < len(leftGo); n++ {
+			pairs = append(pairs, [2]int{leftPython[n], leftGo[n]})
+		}
+		if len(leftPython) > len(leftGo) {
+			missing = leftPython[len(leftGo):]
+		} else {
+			extra = leftGo[len(leftPython):]
+		}
+		return pairs, missing, extra
+	}
+
+	// Pair the closest events first, so an event is not taken by a worse match
+	type candidate struct{ python, goEvent, diff int }
+	candidates := make([]candidate, 0, len(leftPython)*len(leftGo))
+	for _, i := range leftPython {
+		for _, j := range leftGo {
+			candidates = append(candidates, candidate{i, j, len(python[i].diff(goEvents[j]))})
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].diff < candidates[b].diff })
+	pythonPaired := make(map[int]bool)
+	for _, c := range candidates {
+		if pythonPaired[c.python] || used[c.goEvent] {
+			continue
+		}
+		pairs = append(pairs, [2]int{c.python, c.goEvent})
+		pythonPaired[c.python], used[c.goEvent] = true, true
+	}
+	for _, i := range leftPython {
+		if !pythonPaired[i] {
+			missing = append(missing, i)
+		}
+	}
+	for _, j := range leftGo {
+		if !used[j] {
+			extra = append(extra, j)
+		}
+	}
+	return pairs, missing, extra
+}
+
+// describe returns what an unpaired event is about for reports
+func (e Event) describe() string {
+	for _, field := range []string{"ip", "url"} {
+		if e[field] != "" {
+			return e[field]
+		}
+	}
+	return "event"
+}
+
+// Allowlist maps parser names to the deviations accepted for them; entries
+// under "*" apply to all parsers
+type Allowlist map[string][]AllowedDeviation
+
+// AllowedDeviation is an accepted deviation of one field
+type AllowedDeviation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+	// Samples restricts the entry to these samples (names without .eml)
+	Samples []string `json:"samples,omitempty"`
+}
+
+// LoadAllowlist reads an allowlist JSON file
+func LoadAllowlist(path string) (Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var allowlist Allowlist
+	if err := json.Unmarshal(data, &allowlist); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for parser, entries := range allowlist {
+		for _, entry := range entries {
+			if entry.Reason == "" {
+				return nil, fmt.Errorf("%s: %s deviation of %s has no reason", path, entry.Field, parser)
+			}
+		}
+	}
+	return allowlist, nil
+}
+
+func (a Allowlist) accepts(parser, sample, field string) bool {
+	for _, key := range []string{"*", parser} {
+		for _, entry := range a[key] {
+			if entry.Field != field {
+				continue
+			}
+			if len(entry.Samples) == 0 {
+				return true
+			}
+			for _, s := range entry.Samples {
+				if s == sample {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
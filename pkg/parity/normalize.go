@@ -0,0 +1,76 @@
+package parity
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the timestamp notations of both implementations: Python's
+// str(datetime) and isoformat(), and Go's RFC 3339
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// normalizeTime returns a timestamp as RFC 3339 in UTC; unparseable values
+// are returned trimmed. Timestamps without zone are taken as UTC.
+func normalizeTime(value string) string {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// normalizeIP returns the canonical notation of an IP address: IPv6
+// compressed and lowercase, IPv4-mapped IPv6 and zero-padded IPv4 as plain
+// IPv4. Other values are returned trimmed.
+func normalizeIP(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String()
+	}
+	if ip := parsePaddedIPv4(value); ip != nil {
+		return ip.String()
+	}
+	return value
+}
+
+// parsePaddedIPv4 parses IPv4 addresses with leading zeros (010.000.000.001),
+// which net.ParseIP rejects
+func parsePaddedIPv4(value string) net.IP {
+	octets := strings.Split(value, ".")
+	if len(octets) != 4 {
+		return nil
+	}
+	ip := make(net.IP, 4)
+	for i, octet := range octets {
+		if octet == "" || len(octet) > 3 {
+			return nil
+		}
+		n, err := strconv.Atoi(octet)
+		if err != nil || n > 255 {
+			return nil
+		}
+		ip[i] = byte(n)
+	}
+	return ip
+}
+
+func normalizeEmail(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
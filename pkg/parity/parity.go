@@ -0,0 +1,272 @@
+// Package parity compares the events of the Go parsers with the output of the
+// Python parsers recorded in the .assertions.json files of the sample mails.
+// Events are matched independently of their order, and differences known to
+// be benign (timestamp formatting, null vs omitted values, IP notation,
+// ordering of event type attributes) are normalized away before comparing.
+package parity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+// Fields are the event fields compared, in report order. They are the
+// fields the Python dumps record; list_name and malware are the name and
+// malware attributes of the event types.
+var Fields = []string{
+	"parser",
+	"ip",
+	"url",
+	"report_id",
+	"date",
+	"received_date",
+	"send_date",
+	"sender_email",
+	"recipient_email",
+	"list_name",
+	"malware",
+}
+
+// Sample level pseudo fields of deviations
+const (
+	// FieldSampleParser is a different parser name for the whole sample
+	FieldSampleParser = "sample_parser"
+	// FieldEventCount is an event without counterpart on the other side
+	FieldEventCount = "event_count"
+)
+
+// Event is an event reduced to the compared fields with normalized values;
+// absent values are ""
+type Event map[string]string
+
+// PythonOutput is the content of a .assertions.json file
+type PythonOutput struct {
+	Metadata     map[string]interface{} `json:"metadata"`
+	ParserOutput struct {
+		Parser   *string       `json:"parser"`
+		Reporter string        `json:"reporter"`
+		Rejected bool          `json:"rejected"`
+		Events   []PythonEvent `json:"events"`
+	} `json:"parser_output"`
+}
+
+// PythonEvent is an event of a Python dump
+type PythonEvent struct {
+	Date           *string                `json:"date"`
+	Parser         *string                `json:"parser"`
+	ReportID       *string                `json:"report_id"`
+	ReceivedDate   *string                `json:"received_date"`
+	SendDate       *string                `json:"send_date"`
+	SenderEmail    *string                `json:"sender_email"`
+	RecipientEmail *string                `json:"recipient_email"`
+	Resources      map[string]interface{} `json:"resources"`
+	EventTypes     []struct {
+		Name    *string `json:"name"`
+		Malware *string `json:"malware"`
+	} `json:"event_types"`
+}
+
+// LoadPython reads a .assertions.json file
+func LoadPython(path string) (*PythonOutput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var output PythonOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &output, nil
+}
+
+// Parser returns the name of the Python parser that handled the sample, or ""
+func (p *PythonOutput) Parser() string {
+	return deref(p.ParserOutput.Parser)
+}
+
+// Events returns the normalized Python events
+func (p *PythonOutput) Events() []Event {
+	normalized := make([]Event, 0, len(p.ParserOutput.Events))
+	for _, pe := range p.ParserOutput.Events {
+		var listNames, malware []string
+		for _, eventType := range pe.EventTypes {
+			listNames = append(listNames, deref(eventType.Name))
+			malware = append(malware, deref(eventType.Malware))
+		}
+		normalized = append(normalized, Event{
+			"parser":          deref(pe.Parser),
+			"ip":              normalizeIP(resourceString(pe.Resources, "ip")),
+			"url":             strings.TrimSpace(resourceString(pe.Resources, "url")),
+			"report_id":       strings.TrimSpace(deref(pe.ReportID)),
+			"date":            normalizeTime(deref(pe.Date)),
+			"received_date":   normalizeTime(deref(pe.ReceivedDate)),
+			"send_date":       normalizeTime(deref(pe.SendDate)),
+			"sender_email":    normalizeEmail(deref(pe.SenderEmail)),
+			"recipient_email": normalizeEmail(deref(pe.RecipientEmail)),
+			"list_name":       normalizeSet(listNames),
+			"malware":         normalizeSet(malware),
+		})
+	}
+	return normalized
+}
+
+// FromGo returns the normalized form of Go events
+func FromGo(evs []*events.Event) []Event {
+	normalized := make([]Event, 0, len(evs))
+	for _, event := range evs {
+		var listNames, malware []string
+		for _, eventType := range event.EventTypes {
+			switch typed := eventType.(type) {
+			case *events.Blacklist:
+				listNames = append(listNames, typed.ListName)
+			case *events.Malware:
+				malware = append(malware, typed.Infection)
+			case *events.MalwareHosting:
+				malware = append(malware, typed.MalwareName)
+			case *events.Bot:
+				malware = append(malware, typed.BotType)
+			}
+		}
+		normalized = append(normalized, Event{
+			"parser":          event.Parser,
+			"ip":              normalizeIP(event.IP),
+			"url":             strings.TrimSpace(event.URL),
+			"report_id":       strings.TrimSpace(event.ReportID),
+			"date":            formatTime(event.EventDate),
+			"received_date":   formatTime(event.ReceivedDate),
+			"send_date":       formatTime(event.SendDate),
+			"sender_email":    normalizeEmail(event.SenderEmail),
+			"recipient_email": normalizeEmail(event.RecipientEmail),
+			"list_name":       normalizeSet(listNames),
+			"malware":         normalizeSet(malware),
+		})
+	}
+	return normalized
+}
+
+// goJSONEvent holds the compared fields of an event serialized by the Go parsers
+type goJSONEvent struct {
+	IP             string     `json:"ip"`
+	URL            string     `json:"url"`
+	Parser         string     `json:"parser"`
+	ReportID       string     `json:"report_id"`
+	SenderEmail    string     `json:"sender_email"`
+	RecipientEmail string     `json:"recipient_email"`
+	ReceivedDate   *time.Time `json:"received_date"`
+	SendDate       *time.Time `json:"send_date"`
+	EventDate      *time.Time `json:"event_date"`
+	EventTypes     []struct {
+		ListName    string `json:"list_name"`
+		Infection   string `json:"infection"`
+		MalwareName string `json:"malware_name"`
+		BotType     string `json:"bot_type"`
+	} `json:"event_types"`
+}
+
+// FromGoJSON returns the normalized form of serialized Go events, given as
+// JSON arrays (the output of bento-parsers process) or one event per line
+func FromGoJSON(data []byte) ([]Event, error) {
+	var normalized []Event
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return normalized, nil
+		} else if err != nil {
+			return nil, err
+		}
+		var batch []goJSONEvent
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+			if err := json.Unmarshal(raw, &batch); err != nil {
+				return nil, err
+			}
+		} else {
+			var event goJSONEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return nil, err
+			}
+			batch = append(batch, event)
+		}
+		for _, event := range batch {
+			var listNames, malware []string
+			for _, eventType := range event.EventTypes {
+				listNames = append(listNames, eventType.ListName)
+				malware = append(malware, eventType.Infection, eventType.MalwareName, eventType.BotType)
+			}
+			normalized = append(normalized, Event{
+				"parser":          event.Parser,
+				"ip":              normalizeIP(event.IP),
+				"url":             strings.TrimSpace(event.URL),
+				"report_id":       strings.TrimSpace(event.ReportID),
+				"date":            formatTime(event.EventDate),
+				"received_date":   formatTime(event.ReceivedDate),
+				"send_date":       formatTime(event.SendDate),
+				"sender_email":    normalizeEmail(event.SenderEmail),
+				"recipient_email": normalizeEmail(event.RecipientEmail),
+				"list_name":       normalizeSet(listNames),
+				"malware":         normalizeSet(malware),
+			})
+		}
+	}
+}
+
+// key identifies an event by all its compared values
+func (e Event) key() string {
+	var b strings.Builder
+	for _, field := range Fields {
+		b.WriteString(e[field])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// diff returns the fields in which two events differ
+func (e Event) diff(other Event) []string {
+	var fields []string
+	for _, field := range Fields {
+		if e[field] != other[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func resourceString(resources map[string]interface{}, key string) string {
+	switch value := resources[key].(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// normalizeSet joins the non-empty values sorted and without duplicates
+func normalizeSet(values []string) string {
+	seen := make(map[string]bool)
+	var set []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !seen[value] {
+			seen[value] = true
+			set = append(set, value)
+		}
+	}
+	sort.Strings(set)
+	return strings.Join(set, ",")
+}
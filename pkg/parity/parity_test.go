@@ -0,0 +1,147 @@
+package parity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/abusix/inbound-parsers/events"
+)
+
+func loadPythonJSON(t *testing.T, data string) *PythonOutput {
+	t.Helper()
+	var output PythonOutput
+	if err := json.Unmarshal([]byte(data), &output); err != nil {
+		t.Fatal(err)
+	}
+	return &output
+}
+
+func goEvent(ip string, eventTypes ...events.EventType) *events.Event {
+	event := events.NewEvent("spamhaus")
+	event.IP = ip
+	event.EventTypes = eventTypes
+	return event
+}
+
+const pythonDump = `{
+  "metadata": {},
+  "parser_output": {
+    "parser": "spamhaus",
+    "rejected": false,
+    "events": [
+      {"date": "2024-03-01 10:00:00+00:00", "parser": "spamhaus", "report_id": null,
+       "resources": {"ip": "2001:0DB8:0000::0001"},
+       "event_types": [{"name": null, "malware": null}]},
+      {"date": null, "parser": "spamhaus", "report_id": "",
+       "resources": {"ip": "192.000.002.010"},
+       "event_types": [{"name": null, "malware": "mirai"}, {"name": "SBL123", "malware": null}]}
+    ]
+  }
+}`
+
+func TestCompare_NormalizesBenignDifferences(t *testing.T) {
+	date := time.Date(2024, 3, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600))
+	first := goEvent("2001:db8::1", events.NewSpam())
+	first.EventDate = &date
+	second := goEvent("192.0.2.10", events.NewBlacklist("SBL123"), events.NewBot("mirai"))
+
+	// Go emits the events in the other order
+	result := Compare("spamhaus.0", loadPythonJSON(t, pythonDump), FromGo([]*events.Event{second, first}), nil)
+	if result.Status() != StatusMatch {
+		t.Fatalf("Expected match, got %s: %+v", result.Status(), result.Deviations)
+	}
+	if result.MatchedEvents != 2 {
+		t.Errorf("Expected 2 matched events, got %d", result.MatchedEvents)
+	}
+}
+
+func TestCompare_ReportsDeviations(t *testing.T) {
+	second := goEvent("192.0.2.11", events.NewBlacklist("SBL123"), events.NewBot("mirai"))
+
+	result := Compare("spamhaus.0", loadPythonJSON(t, pythonDump), FromGo([]*events.Event{second}), nil)
+	if result.Status() != StatusMismatch {
+		t.Fatalf("Expected mismatch, got %s", result.Status())
+	}
+	if result.Fields["ip"] != 1 || result.Fields[FieldEventCount] != 1 {
+		t.Errorf("Expected one ip deviation and one missing event, got %v", result.Fields)
+	}
+	for _, deviation := range result.Deviations {
+		if deviation.Field == "ip" && (deviation.Python != "192.0.2.10" || deviation.Go != "192.0.2.11" || deviation.Event != 1) {
+			t.Errorf("Unexpected ip deviation: %+v", deviation)
+		}
+	}
+}
+
+func TestCompare_Allowlist(t *testing.T) {
+	date := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	first := goEvent("2001:db8::1")
+	first.EventDate = &date
+	second := goEvent("192.0.2.10", events.NewBlacklist("SBL123"), events.NewBot("mirai"))
+
+	allowlist := Allowlist{
+		"spamhaus": {{Field: "date", Reason: "test", Samples: []string{"spamhaus.0"}}},
+	}
+	result := Compare("spamhaus.0", loadPythonJSON(t, pythonDump), FromGo([]*events.Event{first, second}), allowlist)
+	if result.Status() != StatusAccepted || result.Accepted["date"] != 1 {
+		t.Errorf("Expected accepted date deviation, got %s %v", result.Status(), result.Accepted)
+	}
+
+	result = Compare("spamhaus.1", loadPythonJSON(t, pythonDump), FromGo([]*events.Event{first, second}), allowlist)
+	if result.Status() != StatusMismatch {
+		t.Errorf("Expected the entry to be restricted to spamhaus.0, got %s", result.Status())
+	}
+}
+
+func TestScoreboard(t *testing.T) {
+	board := NewScoreboard([]*Result{
+		{Sample: "a.0", Parser: "a"},
+		{Sample: "a.1", Parser: "a", DeviationCount: 1, Fields: map[string]int{"ip": 1}},
+		{Sample: "b.0", Parser: "b", Accepted: map[string]int{"date": 1}},
+	})
+	if len(board.Parsers) != 2 || board.Parsers[0].Parser != "a" {
+		t.Fatalf("Unexpected parsers: %+v", board.Parsers)
+	}
+	if board.Parsers[0].Parity() != 50 || board.Total.Match != 1 || board.Total.Accepted != 1 || board.Total.Mismatch != 1 {
+		t.Errorf("Unexpected scores: %+v %+v", board.Parsers[0], board.Total)
+	}
+}
+
+func TestCompareDeclined(t *testing.T) {
+	rejected := loadPythonJSON(t, `{"parser_output": {"parser": "mail_reject", "rejected": true, "events": []}}`)
+	if result := CompareDeclined("mail_reject.0", rejected, "mail_reject", nil); result.Status() != StatusMatch {
+		t.Errorf("Expected match, got %s: %+v", result.Status(), result.Deviations)
+	}
+	if result := CompareDeclined("mail_reject.0", rejected, "autoreply", nil); result.Fields[FieldSampleParser] != 1 {
+		t.Errorf("Expected a sample_parser deviation, got %v", result.Fields)
+	}
+	if result := CompareDeclined("spamhaus.0", loadPythonJSON(t, pythonDump), "spamhaus", nil); result.Fields[FieldEventCount] != 2 {
+		t.Errorf("Expected two missing events, got %v", result.Fields)
+	}
+}
+
+func TestFromGoJSON_MatchesFromGo(t *testing.T) {
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	first := goEvent("2001:db8::1", events.NewSpam())
+	first.EventDate = &date
+	evs := []*events.Event{first, goEvent("192.0.2.10", events.NewBlacklist("SBL123"), events.NewMalware("mirai"))}
+
+	array, err := json.Marshal(evs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two process output lines: an array per email
+	normalized, err := FromGoJSON(append(append(array, '\n'), "[]\n"...))
+	if err != nil {
+		t.Fatalf("FromGoJSON failed: %v", err)
+	}
+	expected := FromGo(evs)
+	if len(normalized) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(normalized))
+	}
+	for i := range expected {
+		if diff := normalized[i].diff(expected[i]); len(diff) > 0 {
+			t.Errorf("Event %d differs in %v: %v vs %v", i, diff, normalized[i], expected[i])
+		}
+	}
+}
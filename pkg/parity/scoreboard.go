@@ -0,0 +1,124 @@
+package parity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Score is the parity of the samples of one parser
+type Score struct {
+	Parser        string `json:"parser"`
+	Samples       int    `json:"samples"`
+	Match         int    `json:"match"`
+	Accepted      int    `json:"accepted"`
+	Mismatch      int    `json:"mismatch"`
+	Errors        int    `json:"errors"`
+	PythonEvents  int    `json:"python_events"`
+	GoEvents      int    `json:"go_events"`
+	MatchedEvents int    `json:"matched_events"`
+	// Fields counts the deviations that are not allowlisted per field
+	Fields map[string]int `json:"fields,omitempty"`
+}
+
+// Parity returns the share of samples with parity (match or accepted) in percent
+func (s *Score) Parity() float64 {
+	if s.Samples == 0 {
+		return 0
+	}
+	return float64(s.Match+s.Accepted) * 100 / float64(s.Samples)
+}
+
+func (s *Score) add(result *Result) {
+	s.Samples++
+	switch result.Status() {
+	case StatusMatch:
+		s.Match++
+	case StatusAccepted:
+		s.Accepted++
+	case StatusMismatch:
+		s.Mismatch++
+	case StatusError:
+		s.Errors++
+	}
+	s.PythonEvents += result.PythonEvents
+	s.GoEvents += result.GoEvents
+	s.MatchedEvents += result.MatchedEvents
+	for field, count := range result.Fields {
+		if s.Fields == nil {
+			s.Fields = make(map[string]int)
+		}
+		s.Fields[field] += count
+	}
+}
+
+// Scoreboard is the parity per parser and in total
+type Scoreboard struct {
+	Total   Score     `json:"total"`
+	Parsers []*Score  `json:"parsers"`
+	Results []*Result `json:"results,omitempty"`
+}
+
+// NewScoreboard aggregates sample results per parser
+func NewScoreboard(results []*Result) *Scoreboard {
+	board := &Scoreboard{Total: Score{Parser: "total"}, Results: results}
+	byParser := make(map[string]*Score)
+	for _, result := range results {
+		parser := result.Parser
+		if parser == "" {
+			parser = "(none)"
+		}
+		score, ok := byParser[parser]
+		if !ok {
+			score = &Score{Parser: parser}
+			byParser[parser] = score
+			board.Parsers = append(board.Parsers, score)
+		}
+		score.add(result)
+		board.Total.add(result)
+	}
+	sort.Slice(board.Parsers, func(i, j int) bool {
+		return board.Parsers[i].Parser < board.Parsers[j].Parser
+	})
+	return board
+}
+
+// Markdown renders the scoreboard as a Markdown report
+func (b *Scoreboard) Markdown() string {
+	var out strings.Builder
+	out.WriteString("# Parser Parity\n\n")
+	out.WriteString("Go parser output compared with the Python output recorded in\n")
+	out.WriteString("`testdata/sample_mails/*.assertions.json`. Generated by\n")
+	out.WriteString("`go run ./cmd/validate-assertions`; do not edit by hand.\n\n")
+	fmt.Fprintf(&out, "**Samples:** %d — match %d, accepted %d, mismatch %d, errors %d (%.1f%% parity)\n\n",
+		b.Total.Samples, b.Total.Match, b.Total.Accepted, b.Total.Mismatch, b.Total.Errors, b.Total.Parity())
+	fmt.Fprintf(&out, "**Events:** Python %d, Go %d, matched %d\n\n", b.Total.PythonEvents, b.Total.GoEvents, b.Total.MatchedEvents)
+
+	out.WriteString("| Parser | Samples | Parity | Match | Accepted | Mismatch | Errors | Python events | Go events | Matched events | Deviating fields |\n")
+	out.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|---|\n")
+	for _, score := range b.Parsers {
+		fmt.Fprintf(&out, "| %s | %d | %.0f%% | %d | %d | %d | %d | %d | %d | %d | %s |\n",
+			score.Parser, score.Samples, score.Parity(), score.Match, score.Accepted, score.Mismatch, score.Errors,
+			score.PythonEvents, score.GoEvents, score.MatchedEvents, formatFields(score.Fields))
+	}
+	return out.String()
+}
+
+// formatFields lists field counts by descending count
+func formatFields(fields map[string]int) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if fields[names[i]] != fields[names[j]] {
+			return fields[names[i]] > fields[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s (%d)", name, fields[name])
+	}
+	return strings.Join(parts, ", ")
+}
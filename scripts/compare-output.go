@@ -1,73 +1,82 @@
 // Package main provides a comparison tool for V1 (Python) vs V2 (Go) parser output
-// This validates that the Go migration produces byte-for-byte identical JSON output
+// It validates that the Go migration produces the same events as the Python
+// parsers, after normalizing known-benign differences (see pkg/parity)
 package main
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"os"
-	"reflect"
+	"path/filepath"
+	"sort"
+	"strings"
 
-	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/abusix/inbound-parsers/pkg/parity"
 )
 
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <v1-output.json> <v2-output.json>\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "\nCompares Python (V1) and Go (V2) parser output for identical JSON\n")
+	allowlistPath := flag.String("allowlist", "", "accepted deviations per parser (JSON)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-allowlist file] <v1-output.json> <v2-output.json>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nCompares Python (V1) output in the .assertions.json format with Go (V2)\n")
+		fmt.Fprintf(os.Stderr, "events (JSON arrays as written by bento-parsers process, or NDJSON)\n")
+	}
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
 		os.Exit(1)
 	}
+	v1File, v2File := flag.Arg(0), flag.Arg(1)
 
-	v1File := os.Args[1]
-	v2File := os.Args[2]
+	allowlist := parity.Allowlist{}
+	if *allowlistPath != "" {
+		var err error
+		if allowlist, err = parity.LoadAllowlist(*allowlistPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading allowlist: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	// Read V1 output
-	v1Data, err := os.ReadFile(v1File)
+	python, err := parity.LoadPython(v1File)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading V1 file: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Read V2 output
 	v2Data, err := os.ReadFile(v2File)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading V2 file: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Parse JSON
-	var v1JSON, v2JSON interface{}
-	if err := json.Unmarshal(v1Data, &v1JSON); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing V1 JSON: %v\n", err)
+	goEvents, err := parity.FromGoJSON(v2Data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing V2 JSON: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := json.Unmarshal(v2Data, &v2JSON); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing V2 JSON: %v\n", err)
-		os.Exit(1)
+	sample := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(v1File), ".assertions.json"), ".eml")
+	result := parity.Compare(sample, python, goEvents, allowlist)
+	fmt.Printf("Events: V1 %d, V2 %d, matched %d\n", result.PythonEvents, result.GoEvents, result.MatchedEvents)
+	accepted := make([]string, 0, len(result.Accepted))
+	for field := range result.Accepted {
+		accepted = append(accepted, field)
+	}
+	sort.Strings(accepted)
+	for _, field := range accepted {
+		fmt.Printf("Accepted deviations of %s: %d\n", field, result.Accepted[field])
 	}
 
-	// Compare
-	if reflect.DeepEqual(v1JSON, v2JSON) {
-		fmt.Println("✅ MATCH: Outputs are identical!")
+	if result.Status() != parity.StatusMismatch {
+		fmt.Println("✅ MATCH: Outputs are equivalent!")
 		os.Exit(0)
 	}
 
-	// Outputs differ - show detailed diff
-	fmt.Println("❌ MISMATCH: Outputs differ")
-	fmt.Println()
-
-	// Pretty print both for comparison
-	v1Pretty, _ := json.MarshalIndent(v1JSON, "", "  ")
-	v2Pretty, _ := json.MarshalIndent(v2JSON, "", "  ")
-
-	// Show diff
-	dmp := diffmatchpatch.New()
-	diffs := dmp.DiffMain(string(v1Pretty), string(v2Pretty), false)
-
-	fmt.Println("Differences:")
-	fmt.Println(dmp.DiffPrettyText(diffs))
-
+	fmt.Printf("❌ MISMATCH: %d deviations\n\n", result.DeviationCount)
+	for _, deviation := range result.Deviations {
+		fmt.Printf("  event %d %s: v1 %q, v2 %q\n", deviation.Event, deviation.Field, deviation.Python, deviation.Go)
+	}
+	if result.DeviationCount > len(result.Deviations) {
+		fmt.Printf("  ... and %d more\n", result.DeviationCount-len(result.Deviations))
+	}
 	os.Exit(1)
 }